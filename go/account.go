@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+	accountTokenMinGap   = time.Minute // between emails of one kind to one user
+	accountMailTimeout   = 30 * time.Second
+)
+
+// newAccountToken returns a mailed account token and the hash stored for it.
+func newAccountToken() (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashAccountToken(token), nil
+}
+
+func hashAccountToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// accountLink returns the frontend page a mailed token opens.
+func (a *authServer) accountLink(path, token string) string {
+	return strings.TrimRight(a.appBaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// sendAccountToken issues a token for purpose and mails it in the background. The
+// token is stored before this returns; delivery failures are only logged.
+func (a *authServer) sendAccountToken(ctx context.Context, userID, email, purpose string) error {
+	token, hash, err := newAccountToken()
+	if err != nil {
+		return err
+	}
+	ttl, msg := passwordResetTTL, mailMessage{To: email}
+	switch purpose {
+	case tokenPasswordReset:
+		msg.Subject = "Reset your Aetherion password"
+		msg.Body = "Someone asked to reset the password for your Aetherion account.\n\n" +
+			"Open this link within an hour to choose a new password:\n" + a.accountLink("/reset-password", token) + "\n\n" +
+			"If it wasn't you, ignore this email; your password is unchanged.\n"
+	case tokenEmailVerification:
+		ttl = emailVerificationTTL
+		msg.Subject = "Verify your Aetherion email address"
+		msg.Body = "Open this link within 48 hours to verify your email address:\n" + a.accountLink("/verify-email", token) + "\n"
+	}
+	t := &accountToken{TokenHash: hash, UserID: userID, Purpose: purpose, Email: email, ExpiresAt: time.Now().Add(ttl)}
+	if err := a.sessions.InsertAccountToken(ctx, t, accountTokenMinGap); err != nil {
+		return err
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), accountMailTimeout)
+		defer cancel()
+		if err := a.mailer.Send(ctx, msg); err != nil {
+			log.Error().Err(err).Str("user_id", userID).Str("purpose", purpose).Msg("account email not sent")
+		}
+	}()
+	return nil
+}
+
+// RequestPasswordReset mails a reset link to the account with this email address. It
+// reports success whether or not such an account exists.
+func (a *authServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.StatusResponse, error) {
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "password reset is not configured")
+	}
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "a valid email is required")
+	}
+	resp := &pb.StatusResponse{Success: true, Message: "if an account uses that email, a reset link has been sent"}
+	u, err := a.store.UserByEmail(ctx, addr.Address)
+	if errors.Is(err, errUserNotFound) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "password reset failed: %v", err)
+	}
+	if err := a.sendAccountToken(ctx, u.ID, u.Email, tokenPasswordReset); err != nil && !errors.Is(err, errAccountTokenThrottled) {
+		return nil, status.Errorf(codes.Internal, "password reset failed: %v", err)
+	}
+	return resp, nil
+}
+
+// ResetPassword sets a new password with a mailed reset token and signs the user out
+// everywhere.
+func (a *authServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.StatusResponse, error) {
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "password reset is not configured")
+	}
+	if req.Token == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and new_password are required")
+	}
+	userID, err := a.sessions.ResetPassword(ctx, hashAccountToken(req.Token), hashPassword(req.NewPassword))
+	if errors.Is(err, errAccountTokenInvalid) {
+		return nil, status.Error(codes.InvalidArgument, "reset link is invalid or expired")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "password reset failed: %v", err)
+	}
+	if u, err := a.store.UserByID(ctx, userID); err == nil {
+		if err := a.guard.store.ResetLoginCounter(ctx, loginUserKey(u.Username)); err != nil {
+			log.Warn().Err(err).Msg("login guard: counter reset failed")
+		}
+	}
+	a.reloadDenylist(ctx)
+	log.Info().Str("user_id", userID).Msg("password reset")
+	return &pb.StatusResponse{Success: true, Message: "password changed; sign in again"}, nil
+}
+
+// VerifyEmail marks the caller's address verified with a mailed token. It needs no
+// access token, so the link works in any browser.
+func (a *authServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.StatusResponse, error) {
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "email verification is not configured")
+	}
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	userID, err := a.sessions.VerifyEmail(ctx, hashAccountToken(req.Token))
+	if errors.Is(err, errAccountTokenInvalid) {
+		return nil, status.Error(codes.InvalidArgument, "verification link is invalid or expired")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "email verification failed: %v", err)
+	}
+	log.Info().Str("user_id", userID).Msg("email verified")
+	return &pb.StatusResponse{Success: true, Message: "email verified"}, nil
+}
+
+// ResendVerificationEmail mails a new verification link to the caller.
+func (a *authServer) ResendVerificationEmail(ctx context.Context, _ *pb.Empty) (*pb.StatusResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "email verification is not configured")
+	}
+	u, err := a.store.UserByID(ctx, c.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "email verification failed: %v", err)
+	}
+	if u.Email == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no email address on file")
+	}
+	if u.EmailVerified {
+		return &pb.StatusResponse{Success: true, Message: "email already verified"}, nil
+	}
+	if err := a.sendAccountToken(ctx, c.UserID, u.Email, tokenEmailVerification); err != nil {
+		if errors.Is(err, errAccountTokenThrottled) {
+			return nil, status.Error(codes.ResourceExhausted, "a verification email was sent recently; try again in a minute")
+		}
+		return nil, status.Errorf(codes.Internal, "email verification failed: %v", err)
+	}
+	return &pb.StatusResponse{Success: true, Message: "verification email sent"}, nil
+}
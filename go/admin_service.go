@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	pb "aetherion/gen"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultListUsersLimit = 100
+
+// adminServiceServer implements the operator RPCs. Access is restricted to admins by
+// methodAccess, so handlers do not check the role again.
+type adminServiceServer struct {
+	pb.UnimplementedAdminServiceServer
+	users userStore
+	bots  *botServiceServer
+}
+
+func newAdminServiceServer(users userStore, bots *botServiceServer) *adminServiceServer {
+	return &adminServiceServer{users: users, bots: bots}
+}
+
+func (s *adminServiceServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultListUsersLimit
+	}
+	users, err := s.users.ListUsers(ctx, limit, int(req.Offset))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list users: %v", err)
+	}
+	out := &pb.ListUsersResponse{Users: make([]*pb.UserInfo, 0, len(users))}
+	for _, u := range users {
+		out.Users = append(out.Users, u.info())
+	}
+	return out, nil
+}
+
+// ForceStopBots stops a bot, or every active bot owned by a user, regardless of owner.
+func (s *adminServiceServer) ForceStopBots(ctx context.Context, req *pb.ForceStopBotsRequest) (*pb.ForceStopBotsResponse, error) {
+	var ids []string
+	switch {
+	case req.BotId != "":
+		if _, ok := s.bots.reg.get(req.BotId); !ok {
+			return nil, status.Error(codes.NotFound, "bot not found")
+		}
+		ids = []string{req.BotId}
+	case req.UserId != "":
+		s.bots.reg.mu.RLock()
+		for id, bot := range s.bots.reg.bots {
+			if bot.UserId == req.UserId && bot.IsActive {
+				ids = append(ids, id)
+			}
+		}
+		s.bots.reg.mu.RUnlock()
+		sort.Strings(ids)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "bot_id or user_id is required")
+	}
+
+	c, _ := callerFromContext(ctx)
+	out := &pb.ForceStopBotsResponse{}
+	for _, id := range ids {
+		resp, err := s.bots.StopBot(ctx, &pb.BotIdRequest{BotId: id})
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			log.Warn().Str("bot_id", id).Str("reason", resp.Message).Msg("force stop skipped bot")
+			continue
+		}
+		out.StoppedBotIds = append(out.StoppedBotIds, id)
+		log.Warn().Str("bot_id", id).Str("admin", c.UserID).Str("reason", req.Reason).Msg("bot force-stopped")
+	}
+	return out, nil
+}
@@ -0,0 +1,615 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// quantityEpsilon absorbs float rounding when comparing quantities stored with 8 decimals.
+const quantityEpsilon = 1e-9
+
+// algoRetention is how long finished algos stay queryable before they are forgotten.
+const algoRetention = 24 * time.Hour
+
+// priceSource returns the current reference price for a symbol.
+type priceSource func(ctx context.Context, symbol string) (float64, error)
+
+// volumeProfile accumulates traded volume per minute of the UTC day from the tick
+// stream, so VWAP can weight its slices by when the market actually trades.
+type volumeProfile struct {
+	mu      sync.RWMutex
+	buckets map[string]*[24 * 60]float64 // symbol -> volume by minute of day
+}
+
+func newVolumeProfile() *volumeProfile {
+	return &volumeProfile{buckets: make(map[string]*[24 * 60]float64)}
+}
+
+// Run records trade sizes from price ticks until ctx is canceled.
+func (p *volumeProfile) Run(ctx context.Context, bus *EventBus) {
+	id, ch := bus.Subscribe(1024)
+	defer bus.Unsubscribe(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			if evt.Type != EventPriceTick {
+				continue
+			}
+			tick := evt.Data.(PriceTick)
+			p.Record(tick.Symbol, tick.Size, tick.Ts)
+		}
+	}
+}
+
+func (p *volumeProfile) Record(symbol string, size float64, ts time.Time) {
+	if size <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.buckets[symbol]
+	if !ok {
+		b = new([24 * 60]float64)
+		p.buckets[symbol] = b
+	}
+	t := ts.UTC()
+	b[t.Hour()*60+t.Minute()] += size
+}
+
+// Weights returns the relative traded volume of n consecutive windows of length step
+// starting at start. It falls back to equal weights when no volume has been observed.
+func (p *volumeProfile) Weights(symbol string, start time.Time, step time.Duration, n int) []float64 {
+	weights := make([]float64, n)
+	p.mu.RLock()
+	b := p.buckets[symbol]
+	var total float64
+	if b != nil {
+		for i := 0; i < n; i++ {
+			from := start.Add(time.Duration(i) * step).UTC()
+			minutes := int(math.Ceil(step.Minutes()))
+			if minutes < 1 {
+				minutes = 1
+			}
+			for m := 0; m < minutes; m++ {
+				t := from.Add(time.Duration(m) * time.Minute)
+				weights[i] += b[t.Hour()*60+t.Minute()]
+			}
+			total += weights[i]
+		}
+	}
+	p.mu.RUnlock()
+	if total <= 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// splitQuantity divides total across weights, rounding each slice to 8 decimals and
+// giving the rounding remainder to the last slice so the slices sum to total.
+func splitQuantity(total float64, weights []float64) []float64 {
+	slices := make([]float64, len(weights))
+	if len(weights) == 0 {
+		return slices
+	}
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	if sum <= 0 {
+		return slices
+	}
+	var allocated float64
+	for i, w := range weights[:len(weights)-1] {
+		slices[i] = math.Round(total*w/sum*1e8) / 1e8
+		allocated += slices[i]
+	}
+	slices[len(slices)-1] = math.Round((total-allocated)*1e8) / 1e8
+	return slices
+}
+
+// slippageBps is the execution cost of avgPrice versus arrival in basis points;
+// positive means the fills were worse than the arrival price for this side.
+func slippageBps(side pb.OrderSide, arrival, avgPrice float64) float64 {
+	if arrival <= 0 || avgPrice <= 0 {
+		return 0
+	}
+	diff := (avgPrice - arrival) / arrival * 1e4
+	if side == pb.OrderSide_SELL {
+		return -diff
+	}
+	return diff
+}
+
+// algoOrder is a working parent order. All fields are guarded by algoEngine.mu.
+type algoOrder struct {
+	state          *pb.AlgoOrder
+	req            *pb.CreateAlgoOrderRequest
+	slices         []float64     // planned child quantities (TWAP/VWAP)
+	step           time.Duration // time between slices (TWAP/VWAP)
+	children       map[string]*pb.Order
+	seenFills      map[string]bool
+	filledQty      float64
+	filledNotional float64
+	sentQty        float64
+	doneSending    bool
+	cancel         context.CancelFunc
+	clipDone       chan struct{} // ICEBERG: signaled when the working clip is no longer open
+	finishedAt     time.Time
+}
+
+// childOrderPlacer places and cancels child orders. OrderServiceServer implements it.
+type childOrderPlacer interface {
+	CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error)
+	CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error)
+}
+
+// algoEngine slices parent orders into child orders placed through OrderService and
+// follows their fills on the EventBus. Algo state is held in memory only, and finished
+// algos are forgotten after the retention.
+type algoEngine struct {
+	mu      sync.Mutex
+	orders  childOrderPlacer
+	bus     *EventBus
+	price   priceSource
+	profile *volumeProfile
+	algos   map[string]*algoOrder
+	byChild map[string]*algoOrder
+	ctx     context.Context
+	// retention is how long finished algos are kept; after is time.After, which tests
+	// replace to run schedules without waiting
+	retention time.Duration
+	after     func(time.Duration) <-chan time.Time
+}
+
+func newAlgoEngine(orders childOrderPlacer, bus *EventBus, price priceSource, profile *volumeProfile) *algoEngine {
+	return &algoEngine{
+		orders:  orders,
+		bus:     bus,
+		price:   price,
+		profile: profile,
+		algos:   make(map[string]*algoOrder),
+		byChild: make(map[string]*algoOrder),
+		ctx:     withCaller(context.Background(), caller{Role: roleService}),
+		// Finished algos stay queryable for a day
+		retention: algoRetention,
+		after:     time.After,
+	}
+}
+
+// Start follows order events until ctx is canceled; algos started afterwards stop with ctx.
+// Children are placed as a service, since CreateAlgoOrder already checked that the
+// caller owns the bot and no caller is attached once the RPC returns.
+func (e *algoEngine) Start(ctx context.Context) {
+	e.mu.Lock()
+	e.ctx = withCaller(ctx, caller{Role: roleService})
+	e.mu.Unlock()
+	id, ch := e.bus.Subscribe(1024)
+	go func() {
+		defer e.bus.Unsubscribe(id)
+		prune := time.NewTicker(time.Hour)
+		defer prune.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-prune.C:
+				e.prune(now)
+			case evt, ok := <-ch:
+				if !ok {
+					return
+				}
+				if evt.Type != EventOrderUpdate {
+					continue
+				}
+				oe := evt.Data.(OrderEvent)
+				e.applyChildUpdate(oe.Order, oe.Fill)
+			}
+		}
+	}()
+}
+
+func (e *algoEngine) Create(ctx context.Context, req *pb.CreateAlgoOrderRequest) (*pb.AlgoOrder, error) {
+	qty := decimalToFloat(req.Quantity)
+	if req.BotId == "" || req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "bot_id and symbol are required")
+	}
+	if qty <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "quantity must be positive")
+	}
+	if req.Side != pb.OrderSide_BUY && req.Side != pb.OrderSide_SELL {
+		return nil, status.Error(codes.InvalidArgument, "side must be BUY or SELL")
+	}
+
+	now := time.Now()
+	a := &algoOrder{
+		req:       req,
+		children:  make(map[string]*pb.Order),
+		seenFills: make(map[string]bool),
+		clipDone:  make(chan struct{}, 1),
+	}
+	switch req.Algo {
+	case pb.AlgoType_TWAP, pb.AlgoType_VWAP:
+		if req.DurationMinutes <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "duration_minutes must be positive for TWAP/VWAP")
+		}
+		n := int(req.NumSlices)
+		if n <= 0 {
+			n = int(req.DurationMinutes)
+		}
+		a.step = time.Duration(req.DurationMinutes) * time.Minute / time.Duration(n)
+		weights := make([]float64, n)
+		for i := range weights {
+			weights[i] = 1
+		}
+		if req.Algo == pb.AlgoType_VWAP && e.profile != nil {
+			weights = e.profile.Weights(req.Symbol, now, a.step, n)
+		}
+		a.slices = splitQuantity(qty, weights)
+	case pb.AlgoType_ICEBERG:
+		if decimalToFloat(req.DisplayQuantity) <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "display_quantity must be positive for ICEBERG")
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "algo must be TWAP, VWAP or ICEBERG")
+	}
+
+	arrival, err := e.price(ctx, req.Symbol)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "arrival price unavailable: %v", err)
+	}
+
+	a.state = &pb.AlgoOrder{
+		Id:             uuid.New().String(),
+		BotId:          req.BotId,
+		Symbol:         req.Symbol,
+		Side:           req.Side,
+		Algo:           req.Algo,
+		Status:         pb.AlgoStatus_ALGO_WORKING,
+		Quantity:       floatToDecimal(qty),
+		QuantitySent:   floatToDecimal(0),
+		QuantityFilled: floatToDecimal(0),
+		SlicesTotal:    int32(len(a.slices)),
+		ArrivalPrice:   arrival,
+		CreatedAt:      timestamppb.New(now),
+		UpdatedAt:      timestamppb.New(now),
+	}
+
+	e.mu.Lock()
+	runCtx, cancel := context.WithCancel(e.ctx)
+	a.cancel = cancel
+	e.algos[a.state.Id] = a
+	snapshot := e.snapshotLocked(a)
+	e.mu.Unlock()
+
+	log.Info().Str("algo_order_id", a.state.Id).Str("algo", req.Algo.String()).Str("bot_id", req.BotId).Str("symbol", req.Symbol).Float64("quantity", qty).Float64("arrival_price", arrival).Msg("algo order started")
+	if req.Algo == pb.AlgoType_ICEBERG {
+		go e.runIceberg(runCtx, a)
+	} else {
+		go e.runScheduled(runCtx, a, now)
+	}
+	return snapshot, nil
+}
+
+func (e *algoEngine) Get(id string) (*pb.AlgoOrder, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	a, ok := e.algos[id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "algo order not found")
+	}
+	return e.snapshotLocked(a), nil
+}
+
+// Cancel stops slicing and cancels any child orders that are still open.
+func (e *algoEngine) Cancel(ctx context.Context, id string) (*pb.AlgoOrder, error) {
+	e.mu.Lock()
+	a, ok := e.algos[id]
+	if !ok {
+		e.mu.Unlock()
+		return nil, status.Error(codes.NotFound, "algo order not found")
+	}
+	if a.state.Status != pb.AlgoStatus_ALGO_WORKING {
+		snapshot := e.snapshotLocked(a)
+		e.mu.Unlock()
+		return snapshot, nil
+	}
+	a.cancel()
+	e.finishLocked(a, pb.AlgoStatus_ALGO_CANCELED, "canceled by request")
+	var open []string
+	for childID, child := range a.children {
+		if isOpenOrderStatus(child.Status) {
+			open = append(open, childID)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, childID := range open {
+		if _, err := e.orders.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: childID, BotId: a.req.BotId}); err != nil {
+			log.Warn().Err(err).Str("algo_order_id", id).Str("order_id", childID).Msg("failed to cancel child order")
+		}
+	}
+	return e.Get(id)
+}
+
+// prune forgets algos that finished more than the retention before now.
+func (e *algoEngine) prune(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, a := range e.algos {
+		if a.state.Status == pb.AlgoStatus_ALGO_WORKING || now.Sub(a.finishedAt) < e.retention {
+			continue
+		}
+		for childID := range a.children {
+			delete(e.byChild, childID)
+		}
+		delete(e.algos, id)
+	}
+}
+
+// runScheduled releases TWAP/VWAP slices at evenly spaced times from start.
+func (e *algoEngine) runScheduled(ctx context.Context, a *algoOrder, start time.Time) {
+	for i, qty := range a.slices {
+		if wait := time.Until(start.Add(time.Duration(i) * a.step)); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-e.after(wait):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if qty > 0 {
+			if err := e.placeChild(ctx, a, qty); err != nil {
+				e.fail(a, fmt.Sprintf("slice %d: %v", i+1, err))
+				return
+			}
+		}
+		e.mu.Lock()
+		a.state.SlicesSent++
+		e.mu.Unlock()
+	}
+	e.mu.Lock()
+	a.doneSending = true
+	e.maybeCompleteLocked(a)
+	e.mu.Unlock()
+}
+
+// runIceberg keeps one clip of at most display_quantity working until the parent is filled.
+func (e *algoEngine) runIceberg(ctx context.Context, a *algoOrder) {
+	display := decimalToFloat(a.req.DisplayQuantity)
+	total := decimalToFloat(a.state.Quantity)
+	for {
+		e.mu.Lock()
+		remaining := total - a.filledQty
+		clipNo := a.state.SlicesSent + 1
+		e.mu.Unlock()
+		if remaining <= quantityEpsilon {
+			break
+		}
+		clip := math.Min(display, remaining)
+		if err := e.placeChild(ctx, a, clip); err != nil {
+			e.fail(a, fmt.Sprintf("clip %d: %v", clipNo, err))
+			return
+		}
+		e.mu.Lock()
+		a.state.SlicesSent++
+		e.mu.Unlock()
+
+		// Wait for the clip to leave the book; the signal can be stale from an earlier clip.
+		for working := true; working; {
+			select {
+			case <-ctx.Done():
+				return
+			case <-a.clipDone:
+			}
+			e.mu.Lock()
+			working = hasOpenChild(a)
+			e.mu.Unlock()
+		}
+
+		// A clip that left the book without filling means the venue or the user pulled it.
+		e.mu.Lock()
+		var pulled *pb.Order
+		for _, child := range a.children {
+			if (child.Status == pb.OrderStatus_CANCELED || child.Status == pb.OrderStatus_EXPIRED || child.Status == pb.OrderStatus_REJECTED) && decimalToFloat(child.QuantityFilled) < decimalToFloat(child.QuantityRequested)-quantityEpsilon {
+				pulled = child
+			}
+		}
+		e.mu.Unlock()
+		if pulled != nil {
+			e.fail(a, fmt.Sprintf("clip %s ended %s", pulled.Id, pulled.Status))
+			return
+		}
+	}
+	e.mu.Lock()
+	a.doneSending = true
+	e.maybeCompleteLocked(a)
+	e.mu.Unlock()
+}
+
+func (e *algoEngine) placeChild(ctx context.Context, a *algoOrder, qty float64) error {
+	orderType := pb.OrderType_MARKET
+	if a.req.LimitPrice != nil {
+		orderType = pb.OrderType_LIMIT
+	}
+	e.mu.Lock()
+	clientOrderID := fmt.Sprintf("algo-%s-%d", a.state.Id, len(a.state.ChildOrderIds)+1)
+	e.mu.Unlock()
+	child, err := e.orders.CreateOrder(ctx, &pb.CreateOrderRequest{
+		BotId:         a.req.BotId,
+		Symbol:        a.req.Symbol,
+		Side:          a.req.Side,
+		Type:          orderType,
+		Quantity:      floatToDecimal(qty),
+		LimitPrice:    a.req.LimitPrice,
+		ClientOrderId: clientOrderID,
+	})
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	a.children[child.Id] = child
+	a.state.ChildOrderIds = append(a.state.ChildOrderIds, child.Id)
+	a.sentQty += qty
+	e.byChild[child.Id] = a
+	e.mu.Unlock()
+	// The returned order may already carry fills if the venue executed synchronously.
+	for _, fill := range child.Trades {
+		e.applyChildUpdate(child, fill)
+	}
+	e.applyChildUpdate(child, nil)
+	return nil
+}
+
+// applyChildUpdate folds a child order status change or fill into its parent.
+func (e *algoEngine) applyChildUpdate(order *pb.Order, fill *pb.Trade) {
+	if order == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	a, ok := e.byChild[order.Id]
+	if !ok {
+		return
+	}
+	// Events can arrive after the state returned by CreateOrder; never move a child backwards.
+	if prev, ok := a.children[order.Id]; !ok || isNewerOrderState(order, prev) {
+		a.children[order.Id] = order
+	}
+	if fill != nil && !a.seenFills[fill.TradeId] {
+		a.seenFills[fill.TradeId] = true
+		a.filledQty += fill.Quantity
+		a.filledNotional += fill.Quantity * fill.Price
+	}
+	a.state.UpdatedAt = timestamppb.Now()
+	if a.req.Algo == pb.AlgoType_ICEBERG && !isOpenOrderStatus(order.Status) {
+		select {
+		case a.clipDone <- struct{}{}:
+		default:
+		}
+	}
+	e.maybeCompleteLocked(a)
+}
+
+// isNewerOrderState reports whether next may replace prev as the latest known state of an order.
+func isNewerOrderState(next, prev *pb.Order) bool {
+	if !isOpenOrderStatus(prev.Status) && isOpenOrderStatus(next.Status) {
+		return false
+	}
+	return !next.GetUpdatedAt().AsTime().Before(prev.GetUpdatedAt().AsTime())
+}
+
+// hasOpenChild reports whether any child order can still fill. Callers hold algoEngine.mu.
+func hasOpenChild(a *algoOrder) bool {
+	for _, child := range a.children {
+		if isOpenOrderStatus(child.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+// maybeCompleteLocked marks the algo COMPLETED once every slice is out and no child is still open.
+func (e *algoEngine) maybeCompleteLocked(a *algoOrder) {
+	if a.state.Status != pb.AlgoStatus_ALGO_WORKING || !a.doneSending || hasOpenChild(a) {
+		return
+	}
+	msg := ""
+	if unfilled := decimalToFloat(a.state.Quantity) - a.filledQty; unfilled > quantityEpsilon {
+		msg = fmt.Sprintf("horizon ended with %.8f unfilled", unfilled)
+	}
+	e.finishLocked(a, pb.AlgoStatus_ALGO_COMPLETED, msg)
+}
+
+func (e *algoEngine) fail(a *algoOrder, msg string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if a.state.Status != pb.AlgoStatus_ALGO_WORKING {
+		return
+	}
+	a.cancel()
+	e.finishLocked(a, pb.AlgoStatus_ALGO_FAILED, msg)
+}
+
+func (e *algoEngine) finishLocked(a *algoOrder, st pb.AlgoStatus, msg string) {
+	a.state.Status = st
+	a.state.Message = msg
+	a.state.UpdatedAt = timestamppb.Now()
+	a.finishedAt = time.Now()
+	log.Info().Str("algo_order_id", a.state.Id).Str("status", st.String()).Str("message", msg).Msg("algo order finished")
+}
+
+// snapshotLocked returns a copy of the algo's state with progress and execution quality filled in.
+func (e *algoEngine) snapshotLocked(a *algoOrder) *pb.AlgoOrder {
+	out := proto.Clone(a.state).(*pb.AlgoOrder)
+	out.QuantitySent = floatToDecimal(a.sentQty)
+	out.QuantityFilled = floatToDecimal(a.filledQty)
+	if a.filledQty > 0 {
+		out.AverageFillPrice = a.filledNotional / a.filledQty
+		out.SlippageBps = slippageBps(a.state.Side, a.state.ArrivalPrice, out.AverageFillPrice)
+	}
+	return out
+}
+
+///////////////////////////////////////
+// OrderServiceServer algo RPCs
+///////////////////////////////////////
+
+func (s *OrderServiceServer) CreateAlgoOrder(ctx context.Context, req *pb.CreateAlgoOrderRequest) (*pb.AlgoOrder, error) {
+	if s.algos == nil {
+		return nil, status.Error(codes.Unavailable, "algo engine not initialized")
+	}
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
+	return s.algos.Create(ctx, req)
+}
+
+func (s *OrderServiceServer) GetAlgoOrderStatus(ctx context.Context, req *pb.AlgoOrderRequest) (*pb.AlgoOrder, error) {
+	if s.algos == nil {
+		return nil, status.Error(codes.Unavailable, "algo engine not initialized")
+	}
+	return s.authorizedAlgo(ctx, req.AlgoOrderId)
+}
+
+func (s *OrderServiceServer) CancelAlgoOrder(ctx context.Context, req *pb.AlgoOrderRequest) (*pb.AlgoOrder, error) {
+	if s.algos == nil {
+		return nil, status.Error(codes.Unavailable, "algo engine not initialized")
+	}
+	if _, err := s.authorizedAlgo(ctx, req.AlgoOrderId); err != nil {
+		return nil, err
+	}
+	return s.algos.Cancel(ctx, req.AlgoOrderId)
+}
+
+// authorizedAlgo returns the algo order if the caller owns its bot.
+func (s *OrderServiceServer) authorizedAlgo(ctx context.Context, algoID string) (*pb.AlgoOrder, error) {
+	algo, err := s.algos.Get(algoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeBot(ctx, s.bots, algo.BotId); err != nil {
+		return nil, err
+	}
+	return algo, nil
+}
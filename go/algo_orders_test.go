@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSplitQuantitySumsToTotal(t *testing.T) {
+	slices := splitQuantity(1.0, []float64{1, 1, 1})
+	if len(slices) != 3 {
+		t.Fatalf("expected 3 slices, got %d", len(slices))
+	}
+	var sum float64
+	for _, s := range slices {
+		sum += s
+	}
+	if math.Abs(sum-1.0) > 1e-12 {
+		t.Errorf("expected slices to sum to 1.0, got %.10f (%v)", sum, slices)
+	}
+	if slices[0] != 0.33333333 {
+		t.Errorf("expected first slice rounded to 8 decimals, got %v", slices[0])
+	}
+
+	weighted := splitQuantity(10, []float64{1, 3})
+	if weighted[0] != 2.5 || weighted[1] != 7.5 {
+		t.Errorf("expected [2.5 7.5], got %v", weighted)
+	}
+}
+
+func TestVolumeProfileWeights(t *testing.T) {
+	p := newVolumeProfile()
+	start := time.Date(2025, 8, 15, 14, 0, 0, 0, time.UTC)
+	p.Record("BTC-USD", 1, start)
+	p.Record("BTC-USD", 3, start.Add(time.Minute))
+
+	w := p.Weights("BTC-USD", start, time.Minute, 3)
+	if w[0] != 1 || w[1] != 3 || w[2] != 0 {
+		t.Errorf("expected [1 3 0], got %v", w)
+	}
+	// No observed volume falls back to equal weights.
+	w = p.Weights("ETH-USD", start, time.Minute, 2)
+	if w[0] != 1 || w[1] != 1 {
+		t.Errorf("expected uniform weights, got %v", w)
+	}
+}
+
+func TestSlippageBps(t *testing.T) {
+	if got := slippageBps(pb.OrderSide_BUY, 100, 101); math.Abs(got-100) > 1e-9 {
+		t.Errorf("buy above arrival: expected 100bps, got %v", got)
+	}
+	if got := slippageBps(pb.OrderSide_SELL, 100, 101); math.Abs(got+100) > 1e-9 {
+		t.Errorf("sell above arrival: expected -100bps, got %v", got)
+	}
+	if got := slippageBps(pb.OrderSide_BUY, 0, 101); got != 0 {
+		t.Errorf("missing arrival price: expected 0, got %v", got)
+	}
+}
+
+func TestDecimalConversions(t *testing.T) {
+	dv := numericValueToDecimal("1.50000000")
+	if dv.Units != 1 || dv.Nanos != 500000000 {
+		t.Errorf("expected 1.5, got %d.%09d", dv.Units, dv.Nanos)
+	}
+	if got := decimalToFloat(floatToDecimal(0.12345678)); math.Abs(got-0.12345678) > 1e-12 {
+		t.Errorf("expected round trip of 0.12345678, got %v", got)
+	}
+	for _, f := range []float64{42.25, -42.25, -0.25} {
+		if got := decimalToFloat(numericValueToDecimal(decimalValueToNumeric(floatToDecimal(f)))); got != f {
+			t.Errorf("expected numeric round trip of %v, got %v", f, got)
+		}
+	}
+	if got := decimalValueToNumeric(numericValueToDecimal("-0.50000000")); got != "-0.500000000" {
+		t.Errorf("expected -0.5 to keep its sign, got %s", got)
+	}
+}
+
+// fakeChildOrders places child orders without a database. With fill set each child is
+// filled in full at 101 as it is placed; otherwise it rests until the test fills it.
+type fakeChildOrders struct {
+	fill   bool
+	placed chan *pb.Order
+
+	mu       sync.Mutex
+	created  []*pb.CreateOrderRequest
+	canceled []string
+}
+
+func (f *fakeChildOrders) CreateOrder(_ context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	order := &pb.Order{
+		Id: uuid.NewString(), BotId: req.BotId, Symbol: req.Symbol, Side: req.Side, Status: pb.OrderStatus_SUBMITTED,
+		QuantityRequested: req.Quantity, QuantityFilled: floatToDecimal(0), UpdatedAt: timestamppb.Now(),
+	}
+	if f.fill {
+		order.Status = pb.OrderStatus_FILLED
+		order.QuantityFilled = req.Quantity
+		order.Trades = []*pb.Trade{{TradeId: uuid.NewString(), Quantity: decimalToFloat(req.Quantity), Price: 101}}
+	}
+	f.mu.Lock()
+	f.created = append(f.created, req)
+	f.mu.Unlock()
+	if f.placed != nil {
+		f.placed <- proto.Clone(order).(*pb.Order)
+	}
+	return order, nil
+}
+
+func (f *fakeChildOrders) CancelOrder(_ context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.canceled = append(f.canceled, req.OrderId)
+	return &pb.Order{Id: req.OrderId, Status: pb.OrderStatus_CANCELED}, nil
+}
+
+func (f *fakeChildOrders) quantities() []float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []float64
+	for _, req := range f.created {
+		out = append(out, decimalToFloat(req.Quantity))
+	}
+	return out
+}
+
+// newTestAlgoEngine returns an engine with an arrival price of 100 whose schedule waits
+// return at once and are recorded.
+func newTestAlgoEngine(orders *fakeChildOrders) (*algoEngine, func() []time.Duration) {
+	e := newAlgoEngine(orders, NewEventBus(), func(context.Context, string) (float64, error) { return 100, nil }, newVolumeProfile())
+	var mu sync.Mutex
+	var waits []time.Duration
+	e.after = func(d time.Duration) <-chan time.Time {
+		mu.Lock()
+		waits = append(waits, d)
+		mu.Unlock()
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	return e, func() []time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Duration(nil), waits...)
+	}
+}
+
+func waitForAlgoStatus(t *testing.T, e *algoEngine, id string, want pb.AlgoStatus) *pb.AlgoOrder {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		algo, err := e.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if algo.Status == want {
+			return algo
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected algo %s, got %v: %s", want, algo.Status, algo.Message)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTWAPSchedulesEqualSlices(t *testing.T) {
+	orders := &fakeChildOrders{fill: true}
+	e, waits := newTestAlgoEngine(orders)
+	algo, err := e.Create(context.Background(), &pb.CreateAlgoOrderRequest{
+		BotId: "bot", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Algo: pb.AlgoType_TWAP,
+		Quantity: floatToDecimal(3), DurationMinutes: 3, NumSlices: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := waitForAlgoStatus(t, e, algo.Id, pb.AlgoStatus_ALGO_COMPLETED)
+
+	if got := orders.quantities(); len(got) != 3 || got[0] != 1 || got[1] != 1 || got[2] != 1 {
+		t.Errorf("expected three slices of 1, got %v", got)
+	}
+	// The first slice goes at once and each later one a step after the start
+	w := waits()
+	if len(w) != 2 {
+		t.Fatalf("expected two waits, got %v", w)
+	}
+	for i, d := range w {
+		want := time.Duration(i+1) * time.Minute
+		if d > want || d < want-time.Second {
+			t.Errorf("slice %d: expected to wait about %v, got %v", i+2, want, d)
+		}
+	}
+	if done.SlicesSent != 3 || decimalToFloat(done.QuantityFilled) != 3 || len(done.ChildOrderIds) != 3 {
+		t.Errorf("expected 3 slices sent and filled, got %v", done)
+	}
+	if done.AverageFillPrice != 101 || math.Abs(done.SlippageBps-100) > 1e-9 || done.Message != "" {
+		t.Errorf("expected fills at 101 to cost 100bps, got %v", done)
+	}
+}
+
+func TestVWAPWeightsSlicesByVolume(t *testing.T) {
+	// Keep the volume buckets and the algo's start in the same minute
+	if now := time.Now(); now.Second() >= 58 {
+		time.Sleep(time.Until(now.Truncate(time.Minute).Add(time.Minute)))
+	}
+	orders := &fakeChildOrders{fill: true}
+	e, _ := newTestAlgoEngine(orders)
+	now := time.Now()
+	e.profile.Record("BTC-USD", 1, now)
+	e.profile.Record("BTC-USD", 3, now.Add(time.Minute))
+
+	algo, err := e.Create(context.Background(), &pb.CreateAlgoOrderRequest{
+		BotId: "bot", Symbol: "BTC-USD", Side: pb.OrderSide_SELL, Algo: pb.AlgoType_VWAP,
+		Quantity: floatToDecimal(2), DurationMinutes: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForAlgoStatus(t, e, algo.Id, pb.AlgoStatus_ALGO_COMPLETED)
+	if got := orders.quantities(); len(got) != 2 || got[0] != 0.5 || got[1] != 1.5 {
+		t.Errorf("expected slices of 0.5 and 1.5 following volume, got %v", got)
+	}
+}
+
+func TestIcebergRefillsClips(t *testing.T) {
+	orders := &fakeChildOrders{placed: make(chan *pb.Order, 4)}
+	e, _ := newTestAlgoEngine(orders)
+	algo, err := e.Create(context.Background(), &pb.CreateAlgoOrderRequest{
+		BotId: "bot", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Algo: pb.AlgoType_ICEBERG,
+		Quantity: floatToDecimal(2.5), DisplayQuantity: floatToDecimal(1), LimitPrice: floatToDecimal(99),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []float64{1, 1, 0.5} {
+		var clip *pb.Order
+		select {
+		case clip = <-orders.placed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected clip %d to be placed", i+1)
+		}
+		if got := decimalToFloat(clip.QuantityRequested); got != want {
+			t.Errorf("clip %d: expected %v, got %v", i+1, want, got)
+		}
+		// Only one clip works at a time
+		select {
+		case <-orders.placed:
+			t.Fatalf("clip %d placed while clip %d was working", i+2, i+1)
+		case <-time.After(20 * time.Millisecond):
+		}
+		clip.Status = pb.OrderStatus_FILLED
+		clip.QuantityFilled = clip.QuantityRequested
+		clip.UpdatedAt = timestamppb.New(time.Now().Add(time.Second))
+		e.applyChildUpdate(clip, &pb.Trade{TradeId: uuid.NewString(), Quantity: want, Price: 99})
+	}
+
+	done := waitForAlgoStatus(t, e, algo.Id, pb.AlgoStatus_ALGO_COMPLETED)
+	if done.SlicesSent != 3 || decimalToFloat(done.QuantityFilled) != 2.5 || done.AverageFillPrice != 99 {
+		t.Errorf("expected three clips filling 2.5 at 99, got %v", done)
+	}
+	if orders.created[0].Type != pb.OrderType_LIMIT {
+		t.Errorf("expected limit clips, got %v", orders.created[0].Type)
+	}
+}
+
+func TestCancelAlgoCancelsOpenChildrenAndIsPruned(t *testing.T) {
+	orders := &fakeChildOrders{placed: make(chan *pb.Order, 4)}
+	e, _ := newTestAlgoEngine(orders)
+	e.after = func(time.Duration) <-chan time.Time { return nil } // later slices never come due
+	ctx := context.Background()
+	algo, err := e.Create(ctx, &pb.CreateAlgoOrderRequest{
+		BotId: "bot", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Algo: pb.AlgoType_TWAP,
+		Quantity: floatToDecimal(2), DurationMinutes: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var first *pb.Order
+	select {
+	case first = <-orders.placed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the first slice to be placed")
+	}
+	waitForAlgoStatus(t, e, algo.Id, pb.AlgoStatus_ALGO_WORKING)
+	// SlicesSent is bumped after the child is placed, so wait for it before canceling
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		if got, _ := e.Get(algo.Id); got.SlicesSent == 1 || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	canceled, err := e.Cancel(ctx, algo.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != pb.AlgoStatus_ALGO_CANCELED || canceled.SlicesSent != 1 {
+		t.Errorf("expected a canceled algo with one slice sent, got %v", canceled)
+	}
+	orders.mu.Lock()
+	if len(orders.canceled) != 1 || orders.canceled[0] != first.Id {
+		t.Errorf("expected the open child %s to be canceled, got %v", first.Id, orders.canceled)
+	}
+	orders.mu.Unlock()
+	if again, err := e.Cancel(ctx, algo.Id); err != nil || again.Status != pb.AlgoStatus_ALGO_CANCELED {
+		t.Errorf("expected canceling again to return the canceled algo, got %v, %v", again, err)
+	}
+
+	e.prune(time.Now())
+	if _, err := e.Get(algo.Id); err != nil {
+		t.Errorf("expected a recently finished algo to be kept, got %v", err)
+	}
+	e.prune(time.Now().Add(algoRetention + time.Minute))
+	if _, err := e.Get(algo.Id); status.Code(err) != codes.NotFound {
+		t.Errorf("expected the finished algo to be forgotten, got %v", err)
+	}
+	e.mu.Lock()
+	children := len(e.byChild)
+	e.mu.Unlock()
+	if children != 0 {
+		t.Errorf("expected the algo's children to be forgotten, got %d", children)
+	}
+}
+
+func TestAlgoChildOrdersAreAuthorizedAsService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot := &pb.Bot{BotId: "bot", UserId: "someone"}
+	bots := func(string) (*pb.Bot, bool) { return bot, true }
+	e := newAlgoEngine(&fakeChildOrders{}, NewEventBus(), nil, newVolumeProfile())
+	if err := authorizeBot(e.ctx, bots, bot.BotId); err != nil {
+		t.Errorf("expected children placed before Start to be authorized, got %v", err)
+	}
+	e.Start(ctx)
+	if err := authorizeBot(e.ctx, bots, bot.BotId); err != nil {
+		t.Errorf("expected children to be authorized, got %v", err)
+	}
+}
+
+func TestAlgoRunsThroughOrderService(t *testing.T) {
+	db := testDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	u := &userAccount{Username: "algo-owner", Email: "algo-owner@example.com", PasswordHash: hashPassword("pw"), Role: roleUser}
+	if err := db.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	bot := &pb.Bot{BotId: uuid.NewString(), UserId: u.ID, Name: "bot", Symbol: "BTC-USD", Strategy: "MOMENTUM", AccountValue: 1000}
+	if err := db.CreateBot(ctx, bot); err != nil {
+		t.Fatal(err)
+	}
+
+	price := func(context.Context, string) (float64, error) { return 100, nil }
+	orders := newOrderServiceServer(db, NewEventBus(), sessionBoundary{})
+	orders.bots = func(id string) (*pb.Bot, bool) { return bot, id == bot.BotId }
+	orders.venues = newVenueRouter(newPaperVenue(price), nil)
+	orders.algos = newAlgoEngine(orders, orders.bus, price, newVolumeProfile())
+	orders.algos.Start(ctx)
+	orders.algos.after = func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	req := &pb.CreateAlgoOrderRequest{
+		BotId: bot.BotId, Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Algo: pb.AlgoType_TWAP,
+		Quantity: floatToDecimal(2), DurationMinutes: 2,
+	}
+	other := withCaller(ctx, caller{UserID: uuid.NewString(), Role: roleUser})
+	if _, err := orders.CreateAlgoOrder(other, req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected another user's algo to be denied, got %v", err)
+	}
+	algo, err := orders.CreateAlgoOrder(withCaller(ctx, caller{UserID: u.ID, Role: roleUser}), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := waitForAlgoStatus(t, orders.algos, algo.Id, pb.AlgoStatus_ALGO_COMPLETED)
+	if len(done.ChildOrderIds) != 2 || decimalToFloat(done.QuantityFilled) != 2 {
+		t.Errorf("expected two children filling 2, got %v", done)
+	}
+	for _, id := range done.ChildOrderIds {
+		child, err := db.GetOrder(ctx, id)
+		if err != nil || child.BotId != bot.BotId || child.Status != pb.OrderStatus_FILLED {
+			t.Errorf("expected child %s to be a filled order of the bot, got %v, %v", id, child, err)
+		}
+	}
+}
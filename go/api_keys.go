@@ -0,0 +1,379 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// apiKeyPrefix marks API key secrets so the interceptor can tell them from JWTs.
+const apiKeyPrefix = "aek_"
+
+// API key scopes. read allows Get, List, Stream and Subscribe RPCs; trade allows every
+// user RPC; admin additionally keeps the owner's admin or service role.
+const (
+	scopeRead  = "read"
+	scopeTrade = "trade"
+	scopeAdmin = "admin"
+)
+
+const (
+	apiKeyCacheTTL      = 30 * time.Second
+	apiKeyTouchInterval = time.Minute
+)
+
+// newAPIKey returns a new API key secret and the hash stored for it.
+func newAPIKey() (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return secret, hashAPIKey(secret), nil
+}
+
+func hashAPIKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// normalizeScopes validates scopes and removes duplicates.
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		switch s {
+		case scopeRead, scopeTrade, scopeAdmin:
+		default:
+			return nil, fmt.Errorf("unknown scope %q", s)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	return out, nil
+}
+
+// parseAllowedIPs parses an allowlist of IPs and CIDRs.
+func parseAllowedIPs(entries []string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", e)
+			}
+			out = append(out, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP %q", e)
+		}
+		addr = addr.Unmap()
+		out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return out, nil
+}
+
+// ipAllowed reports whether the peer of ctx is in allowed. An empty allowlist allows
+// every address.
+func ipAllowed(ctx context.Context, allowed []netip.Prefix) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiKeyCaller is the identity an API key authenticates as. Without the admin scope the
+// owner's admin or service role is dropped.
+func apiKeyCaller(k *apiKey) caller {
+	c := caller{UserID: k.UserID, Role: k.Role, APIKeyID: k.ID, Scopes: k.Scopes}
+	if c.Role == "" || !c.hasScope(scopeAdmin) {
+		c.Role = roleUser
+	}
+	if k.ExpiresAt != nil {
+		c.ExpiresAt = *k.ExpiresAt
+	}
+	return c
+}
+
+type cachedAPIKey struct {
+	key       *apiKey
+	allowed   []netip.Prefix
+	fetchedAt time.Time
+	touchedAt time.Time
+}
+
+// apiKeyAuth authenticates API keys for the auth interceptors. Keys are cached for
+// apiKeyCacheTTL, so a key revoked on another instance keeps working here for up to
+// that long.
+type apiKeyAuth struct {
+	db    *DBService // nil rejects every API key (injected)
+	mu    sync.Mutex
+	cache map[string]*cachedAPIKey // hex key hash -> key
+}
+
+func newAPIKeyAuth() *apiKeyAuth {
+	return &apiKeyAuth{cache: make(map[string]*cachedAPIKey)}
+}
+
+// authenticate returns the caller for an API key secret.
+func (a *apiKeyAuth) authenticate(ctx context.Context, secret string) (caller, error) {
+	if a == nil || a.db == nil {
+		return caller{}, status.Error(codes.Unauthenticated, "api keys are not configured")
+	}
+	hash := hashAPIKey(secret)
+	id := hex.EncodeToString(hash)
+
+	a.mu.Lock()
+	entry, ok := a.cache[id]
+	a.mu.Unlock()
+	if !ok || time.Since(entry.fetchedAt) > apiKeyCacheTTL {
+		k, err := a.db.GetAPIKeyByHash(ctx, hash)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Warn().Err(err).Msg("api key lookup failed")
+			}
+			a.mu.Lock()
+			delete(a.cache, id)
+			a.mu.Unlock()
+			return caller{}, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		allowed, err := parseAllowedIPs(k.AllowedIPs)
+		if err != nil {
+			return caller{}, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		fresh := &cachedAPIKey{key: k, allowed: allowed, fetchedAt: time.Now()}
+		a.mu.Lock()
+		if ok {
+			fresh.touchedAt = entry.touchedAt
+		}
+		a.cache[id] = fresh
+		a.mu.Unlock()
+		entry = fresh
+	}
+
+	k := entry.key
+	if k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt) {
+		return caller{}, status.Error(codes.Unauthenticated, "api key expired")
+	}
+	if !ipAllowed(ctx, entry.allowed) {
+		return caller{}, status.Error(codes.PermissionDenied, "api key not allowed from this address")
+	}
+	a.touch(entry)
+	return apiKeyCaller(k), nil
+}
+
+// touch records the key's use at most once per apiKeyTouchInterval.
+func (a *apiKeyAuth) touch(entry *cachedAPIKey) {
+	a.mu.Lock()
+	if time.Since(entry.touchedAt) < apiKeyTouchInterval {
+		a.mu.Unlock()
+		return
+	}
+	entry.touchedAt = time.Now()
+	a.mu.Unlock()
+	go func(id string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.db.TouchAPIKey(ctx, id); err != nil {
+			log.Warn().Err(err).Str("key_id", id).Msg("api key touch failed")
+		}
+	}(entry.key.ID)
+}
+
+// forget drops a revoked key from the cache.
+func (a *apiKeyAuth) forget(keyID string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id, entry := range a.cache {
+		if entry.key.ID == keyID {
+			delete(a.cache, id)
+		}
+	}
+}
+
+// forgetUser drops a deleted user's keys from the cache.
+func (a *apiKeyAuth) forgetUser(userID string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id, entry := range a.cache {
+		if entry.key.UserID == userID {
+			delete(a.cache, id)
+		}
+	}
+}
+
+// CreateAPIKey creates an API key for the caller. The secret is only returned here.
+// Keys cannot create keys, and only admins and services may create admin-scoped keys.
+func (a *authServer) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	c, err := a.apiKeyManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !c.IsPrivileged() {
+		for _, s := range scopes {
+			if s == scopeAdmin {
+				return nil, status.Error(codes.PermissionDenied, "admin scope requires an admin or service account")
+			}
+		}
+	}
+	if _, err := parseAllowedIPs(req.AllowedIps); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.ExpiresInDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expires_in_days must not be negative")
+	}
+
+	secret, hash, err := newAPIKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "api key generation failed: %v", err)
+	}
+	allowedIPs := make([]string, 0, len(req.AllowedIps))
+	for _, ip := range req.AllowedIps {
+		allowedIPs = append(allowedIPs, strings.TrimSpace(ip))
+	}
+	k := &apiKey{
+		ID:         uuid.New().String(),
+		UserID:     c.UserID,
+		Name:       name,
+		Prefix:     secret[:len(apiKeyPrefix)+6],
+		KeyHash:    hash,
+		Scopes:     scopes,
+		AllowedIPs: allowedIPs,
+	}
+	if req.ExpiresInDays > 0 {
+		exp := time.Now().AddDate(0, 0, int(req.ExpiresInDays))
+		k.ExpiresAt = &exp
+	}
+	if err := a.sessions.InsertAPIKey(ctx, k); err != nil {
+		return nil, status.Errorf(codes.Internal, "create api key failed: %v", err)
+	}
+	log.Info().Str("user_id", c.UserID).Str("key_id", k.ID).Strs("scopes", scopes).Msg("api key created")
+
+	key := &pb.APIKey{
+		Id:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		AllowedIps: k.AllowedIPs,
+		CreatedAt:  timestamppb.New(k.CreatedAt),
+	}
+	if k.ExpiresAt != nil {
+		key.ExpiresAt = timestamppb.New(*k.ExpiresAt)
+	}
+	return &pb.CreateAPIKeyResponse{Key: key, Secret: secret}, nil
+}
+
+// ListAPIKeys returns the caller's API keys, without their secrets.
+func (a *authServer) ListAPIKeys(ctx context.Context, _ *pb.Empty) (*pb.ListAPIKeysResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "api keys are not configured")
+	}
+	keys, err := a.sessions.ListAPIKeys(ctx, c.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list api keys failed: %v", err)
+	}
+	return &pb.ListAPIKeysResponse{Keys: keys}, nil
+}
+
+// RevokeAPIKey revokes one of the caller's API keys; admins may revoke anyone's.
+func (a *authServer) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.StatusResponse, error) {
+	c, err := a.apiKeyManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.KeyId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "key_id must be a valid UUID")
+	}
+	owner := c.UserID
+	if c.IsAdmin() {
+		owner = ""
+	}
+	if err := a.sessions.RevokeAPIKey(ctx, owner, req.KeyId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "api key not found")
+		}
+		return nil, status.Errorf(codes.Internal, "revoke api key failed: %v", err)
+	}
+	a.apiKeys.forget(req.KeyId)
+	return &pb.StatusResponse{Success: true, Message: "api key revoked", Id: req.KeyId}, nil
+}
+
+// apiKeyManager returns the caller of an API key management RPC. These require a
+// signed-in user rather than an API key.
+func (a *authServer) apiKeyManager(ctx context.Context) (caller, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return caller{}, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.APIKeyID != "" {
+		return caller{}, status.Error(codes.PermissionDenied, "api keys cannot manage api keys")
+	}
+	if a.sessions == nil {
+		return caller{}, status.Error(codes.FailedPrecondition, "api keys are not configured")
+	}
+	return c, nil
+}
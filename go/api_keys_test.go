@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIKeyScopes(t *testing.T) {
+	if _, err := normalizeScopes([]string{"read", "withdraw"}); err == nil {
+		t.Error("expected an unknown scope to be rejected")
+	}
+	if _, err := normalizeScopes(nil); err == nil {
+		t.Error("expected at least one scope to be required")
+	}
+
+	read := apiKeyCaller(&apiKey{ID: "k1", UserID: "alice", Role: roleAdmin, Scopes: []string{scopeRead}})
+	if read.Role != roleUser {
+		t.Errorf("expected a key without the admin scope to drop the admin role, got %q", read.Role)
+	}
+	cases := []struct {
+		c      caller
+		method string
+		level  accessLevel
+		want   bool
+	}{
+		{read, "/trading.BotService/ListBots", accessUser, true},
+		{read, "/trading.OrderService/StreamOrderUpdates", accessUser, true},
+		{read, "/trading.OrderService/CreateOrder", accessUser, false},
+		{apiKeyCaller(&apiKey{ID: "k2", Role: roleUser, Scopes: []string{scopeTrade}}), "/trading.OrderService/CreateOrder", accessUser, true},
+		{apiKeyCaller(&apiKey{ID: "k2", Role: roleUser, Scopes: []string{scopeTrade}}), "/trading.OrderService/RunReconciliation", accessService, false},
+		{apiKeyCaller(&apiKey{ID: "k3", Role: roleService, Scopes: []string{scopeAdmin}}), "/trading.OrderService/RunReconciliation", accessService, true},
+		{caller{UserID: "alice", Role: roleUser}, "/trading.OrderService/CreateOrder", accessUser, true},
+	}
+	for _, tc := range cases {
+		if got := tc.c.scopeAllows(tc.method, tc.level); got != tc.want {
+			t.Errorf("scopeAllows(%v, %s) = %v, want %v", tc.c.Scopes, tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestAPIKeyIPAllowlist(t *testing.T) {
+	allowed, err := parseAllowedIPs([]string{"10.0.0.0/8", "192.168.1.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseAllowedIPs([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("expected an invalid CIDR to be rejected")
+	}
+	from := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000}})
+	}
+	for ip, want := range map[string]bool{"10.1.2.3": true, "192.168.1.5": true, "192.168.1.6": false, "::ffff:10.0.0.1": true} {
+		if got := ipAllowed(from(ip), allowed); got != want {
+			t.Errorf("ipAllowed(%s) = %v, want %v", ip, got, want)
+		}
+	}
+	if !ipAllowed(from("8.8.8.8"), nil) {
+		t.Error("expected an empty allowlist to allow any address")
+	}
+}
+
+func TestAPIKeyRoutedAwayFromJWT(t *testing.T) {
+	secret, hash, err := newAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(secret, apiKeyPrefix) || string(hashAPIKey(secret)) != string(hash) {
+		t.Fatalf("unexpected api key %q", secret)
+	}
+	keys, err := newSigningKeys("0123456789abcdef0123456789abcdef", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without a database every API key is rejected rather than parsed as a JWT.
+	for _, md := range []metadata.MD{
+		metadata.Pairs("authorization", "Bearer "+secret),
+		metadata.Pairs("x-api-key", secret),
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := callerFromMetadata(ctx, keys, nil, newAPIKeyAuth())
+		if status.Code(err) != codes.Unauthenticated || !strings.Contains(err.Error(), "api keys") {
+			t.Errorf("expected api key authentication to be attempted, got %v", err)
+		}
+	}
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"crypto/cipher"
+	"errors"
 	"log"
+	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"strings"
 	"time"
@@ -11,177 +15,44 @@ import (
 	pb "aetherion/gen"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
-type userStore interface {
-	CreateUser(ctx context.Context, username, email, pwHash, role string) error
-	GetUserHash(ctx context.Context, username string) (string, error)
-	GetUser(ctx context.Context, username string) (*pb.UserInfo, error)
-	GetUserRole(ctx context.Context, username string) (string, error)
-}
-
-// memUserStore implements userStore in-memory
-type memUserStore struct {
-	users map[string]struct {
-		Email string
-		Hash  string
-		Role  string
-	}
-}
-
-func newMemUserStore() *memUserStore {
-	return &memUserStore{users: make(map[string]struct {
-		Email string
-		Hash  string
-		Role  string
-	})}
-}
-
-func (m *memUserStore) CreateUser(_ context.Context, u, email, h, role string) error {
-	if _, ok := m.users[u]; ok {
-		return fmt.Errorf("exists")
-	}
-	m.users[u] = struct {
-		Email string
-		Hash  string
-		Role  string
-	}{Email: email, Hash: h, Role: role}
-	return nil
-}
-
-func (m *memUserStore) GetUserRole(_ context.Context, username string) (string, error) {
-	v, ok := m.users[username]
-	if !ok {
-		return "", fmt.Errorf("notfound")
-	}
-	return v.Role, nil
-}
-
-// context key for user hash
-type ctxKeyUserHash struct{}
-
-func (m *memUserStore) GetUserHash(_ context.Context, u string) (string, error) {
-	v, ok := m.users[u]
-	if !ok {
-		return "", fmt.Errorf("notfound")
-	}
-	return v.Hash, nil
-}
-
-// Add GetUserByUsername to pgUserStore
-func (p *pgUserStore) GetUserByUsername(ctx context.Context, username string) (string, error) {
-	var id string
-	err := p.db.QueryRow(ctx, `SELECT id FROM users WHERE username=$1`, username).Scan(&id)
-	if err != nil {
-		return "", fmt.Errorf("notfound")
-	}
-	return id, nil
-}
-
-// pgUserStore implements userStore with Postgres
-type pgUserStore struct{ db *pgx.Conn }
-
-func newPgUserStore(ctx context.Context, dsn string) (*pgUserStore, error) {
-	conn, err := pgx.Connect(ctx, dsn)
-	if err != nil {
-		return nil, err
-	}
-	// ensure table
-	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS users (
-        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-        username TEXT UNIQUE NOT NULL,
-        email TEXT,
-        password_hash TEXT NOT NULL,
-		role TEXT NOT NULL DEFAULT 'user',
-        created_at TIMESTAMPTZ DEFAULT now()
-    )`)
-	if err != nil {
-		conn.Close(ctx)
-		return nil, err
-	}
-	return &pgUserStore{db: conn}, nil
-}
-
-// Update CreateUser to use UUID
-func (p *pgUserStore) CreateUser(ctx context.Context, username, email, pwHash string, role string) error {
-	id := uuid.New().String()
-	_, err := p.db.Exec(ctx, `INSERT INTO users (id, username, email, password_hash, role) VALUES ($1,$2,$3,$4,$5)`, id, username, email, pwHash, role)
-	if err != nil {
-		if strings.Contains(err.Error(), "duplicate") {
-			return fmt.Errorf("exists")
-		}
-		return err
-	}
-	return nil
-}
-func (p *pgUserStore) GetUserHash(ctx context.Context, u string) (string, error) {
-	var h string
-	err := p.db.QueryRow(ctx, `SELECT password_hash FROM users WHERE username=$1`, u).Scan(&h)
-	if err != nil {
-		return "", fmt.Errorf("notfound")
-	}
-	return h, nil
-}
-
-// Implement GetUser for memUserStore
-func (m *memUserStore) GetUser(_ context.Context, username string) (*pb.UserInfo, error) {
-	v, ok := m.users[username]
-	if !ok {
-		return nil, fmt.Errorf("notfound")
-	}
-	return &pb.UserInfo{
-		Username:      username,
-		Email:         v.Email,
-		CreatedAtUnix: time.Now().Unix(), // Demo: use current time
-	}, nil
-}
-
-// Implement GetUser for pgUserStore
-func (p *pgUserStore) GetUser(ctx context.Context, username string) (*pb.UserInfo, error) {
-	var email string
-	var createdAt time.Time
-	err := p.db.QueryRow(ctx, `SELECT email, created_at FROM users WHERE username=$1`, username).Scan(&email, &createdAt)
-	if err != nil {
-		return nil, fmt.Errorf("notfound")
-	}
-	return &pb.UserInfo{
-		Username:      username,
-		Email:         email,
-		CreatedAtUnix: createdAt.Unix(),
-	}, nil
-}
-
 type authServer struct {
 	pb.UnimplementedAuthServiceServer
-	store  userStore
-	secret []byte
+	store      userStore
+	keys       *signingKeys
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	sessions   *DBService   // refresh tokens and API keys; nil issues access tokens only (injected)
+	denylist   *jtiDenylist // revoked access tokens (injected)
+	apiKeys    *apiKeyAuth  // API key cache, cleared on revoke (injected)
+	guard      *loginGuard  // failed-login backoff and audit trail
+	mailer     Mailer       // password reset and verification emails
+	appBaseURL string       // frontend URL mailed links point at
+	// Two-factor: TOTP secrets are sealed with totpKey; nil disables enrollment (injected)
+	totpKey          cipher.AEAD
+	mfaRequiredRoles map[string]bool
+	// DeleteAccount stops and unregisters the user's bots (injected)
+	bots *botServiceServer
 }
 
-func newAuthServer(secret string) *authServer {
-	// Attempt Postgres init if DSN provided
-	dsn := os.Getenv("POSTGRES_DSN")
-	var store userStore
-	if dsn != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		pgStore, err := newPgUserStore(ctx, dsn)
-		if err != nil {
-			log.Printf("postgres user store init failed, falling back to memory: %v", err)
-			store = newMemUserStore()
-		} else {
-			store = pgStore
-		}
-	} else {
-		store = newMemUserStore()
+func newAuthServer(keys *signingKeys, store userStore) *authServer {
+	return &authServer{
+		store:            store,
+		keys:             keys,
+		accessTTL:        defaultAccessTokenTTL,
+		refreshTTL:       defaultRefreshTokenTTL,
+		guard:            newLoginGuard(newMemLoginAttemptStore(), defaultLoginMaxFailures, defaultLoginLockout),
+		mailer:           &logMailer{},
+		appBaseURL:       "http://localhost:3000",
+		mfaRequiredRoles: map[string]bool{roleAdmin: true},
 	}
-	return &authServer{store: store, secret: []byte(secret)}
 }
 
 func hashPassword(pw string) string {
@@ -202,143 +73,205 @@ func (a *authServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb
 		return &pb.AuthResponse{Success: false, Message: "username, password, and email required"}, nil
 	}
 	// Always create as "user" unless you want to allow specifying
-	if err := a.store.CreateUser(ctx, req.Username, req.Email, hashPassword(req.Password), "user"); err != nil {
-		if err.Error() == "exists" {
+	u := &userAccount{Username: req.Username, Email: req.Email, PasswordHash: hashPassword(req.Password), Role: roleUser}
+	if err := a.store.CreateUser(ctx, u); err != nil {
+		if errors.Is(err, errUserExists) {
 			return &pb.AuthResponse{Success: false, Message: "user exists"}, nil
 		}
 		return &pb.AuthResponse{Success: false, Message: err.Error()}, nil
 	}
-	// Get UUID for JWT
-	var userID, role string
-	if pgStore, ok := a.store.(*pgUserStore); ok {
-		userID, _ = pgStore.GetUserByUsername(ctx, req.Username)
-		role, _ = pgStore.GetUserRole(ctx, req.Username)
-	} else if memStore, ok := a.store.(*memUserStore); ok {
-		userID = req.Username
-		role, _ = memStore.GetUserRole(ctx, req.Username)
-	} else {
-		userID = req.Username
-		role = "user"
-	}
-	exp := time.Now().Add(1 * time.Hour)
-	claims := jwt.MapClaims{"sub": userID, "exp": exp.Unix(), "role": role}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(a.secret)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "token signing failed: %v", err)
+	resp := &pb.AuthResponse{Success: true, Message: "registered"}
+	if err := a.issueTokens(ctx, resp, u.ID, u.Username, u.Role); err != nil {
+		return nil, err
 	}
-	return &pb.AuthResponse{Success: true, Message: "registered", Token: signed, ExpiresAtUnix: exp.Unix(), Role: role}, nil
+	if a.sessions != nil {
+		if err := a.sendAccountToken(ctx, u.ID, u.Email, tokenEmailVerification); err != nil {
+			log.Printf("[Register] verification email for %s not queued: %v", req.Username, err)
+		}
+	}
+	return resp, nil
 }
 
 func (a *authServer) Login(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
-	stored, err := a.store.GetUserHash(ctx, req.Username)
-	if err != nil || !verifyPassword(stored, req.Password) {
+	ip := a.guard.clientIP(ctx)
+	if err := a.guard.check(ctx, req.Username, ip); err != nil {
+		return nil, err
+	}
+	u, err := a.store.UserByUsername(ctx, req.Username)
+	if err != nil || !verifyPassword(u.PasswordHash, req.Password) {
+		a.guard.fail(ctx, req.Username, ip, "invalid credentials")
 		return &pb.AuthResponse{Success: false, Message: "invalid credentials"}, nil
 	}
-	var userID, role, email string
-	if pgStore, ok := a.store.(*pgUserStore); ok {
-		userID, _ = pgStore.GetUserByUsername(ctx, req.Username)
-		role, _ = pgStore.GetUserRole(ctx, req.Username)
-		// Fetch email
-		_ = pgStore.db.QueryRow(ctx, `SELECT email FROM users WHERE username=$1`, req.Username).Scan(&email)
-	} else if memStore, ok := a.store.(*memUserStore); ok {
-		userID = req.Username
-		role, _ = memStore.GetUserRole(ctx, req.Username)
-		email = memStore.users[req.Username].Email
-	} else {
-		userID = req.Username
-		role = "user"
-		email = ""
-	}
-	exp := time.Now().Add(1 * time.Hour)
-	claims := jwt.MapClaims{"sub": userID, "exp": exp.Unix(), "role": role}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(a.secret)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "token signing failed: %v", err)
+	if next := a.secondFactor(ctx, challenge{UserID: u.ID, Username: u.Username, Role: u.Role}); next != nil {
+		log.Printf("[Login] Password accepted for user %s; second factor pending", req.Username)
+		a.guard.audit(ctx, req.Username, ip, false, next.Message)
+		return next, nil
+	}
+	a.guard.succeed(ctx, req.Username, ip, "password")
+	resp := &pb.AuthResponse{Success: true, Message: "ok", Username: u.Username, Email: u.Email}
+	if err := a.issueTokens(ctx, resp, u.ID, u.Username, u.Role); err != nil {
+		return nil, err
 	}
 	log.Printf("[Login] Generated JWT token for user: %s", req.Username)
-	return &pb.AuthResponse{
-		Success:       true,
-		Message:       "ok",
-		Token:         signed,
-		ExpiresAtUnix: exp.Unix(),
-		Role:          role,
-		Username:      req.Username,
-		Email:         email,
-	}, nil
+	return resp, nil
 }
 
-// Add GetUser handler to authServer
+// GetUser returns a user's profile. Other users' id, role and verification status are
+// only shown to admins; everyone else sees the username, email and signup time.
 func (a *authServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserInfo, error) {
-	user, err := a.store.GetUser(ctx, req.Username)
+	user, err := a.store.UserByUsername(ctx, req.Username)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
-	return user, nil
-}
-
-func (p *pgUserStore) GetUserRole(ctx context.Context, username string) (string, error) {
-	var role string
-	err := p.db.QueryRow(ctx, `SELECT role FROM users WHERE username=$1`, username).Scan(&role)
-	if err != nil {
-		return "", fmt.Errorf("notfound")
+	if c, ok := callerFromContext(ctx); ok && (c.UserID == user.ID || c.IsAdmin()) {
+		return user.info(), nil
 	}
-	return role, nil
+	return &pb.UserInfo{Username: user.Username, Email: user.Email, CreatedAtUnix: user.CreatedAt.Unix()}, nil
 }
 
-func authUnaryInterceptor(secret []byte) grpc.UnaryServerInterceptor {
+// authUnaryInterceptor authenticates the bearer token, puts the caller in the context
+// and enforces methodAccess.
+func authUnaryInterceptor(keys *signingKeys, denylist *jtiDenylist, apiKeys *apiKeyAuth) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		if os.Getenv("AUTH_DISABLED") == "1" {
 			log.Println("Auth disabled, skipping interceptor")
-			return handler(ctx, req)
+			return handler(withCaller(ctx, caller{Role: roleAdmin}), req)
 		}
-		if info.FullMethod == "/trading.AuthService/Login" || info.FullMethod == "/trading.AuthService/Register" {
-			log.Println("AuthService method, skipping interceptor")
+		level := methodPolicy(info.FullMethod)
+		if level == accessPublic {
 			return handler(ctx, req)
 		}
-		if info.FullMethod == "/trading.TradingService/GetPrice" {
-			log.Println("TradingService GetPrice method, skipping interceptor")
-			return handler(ctx, req)
+		c, err := callerFromMetadata(ctx, keys, denylist, apiKeys)
+		if err != nil {
+			return nil, err
+		}
+		if !c.allows(level) {
+			log.Printf("Role %q denied for %s", c.Role, info.FullMethod)
+			return nil, status.Error(codes.PermissionDenied, "insufficient role")
 		}
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			log.Println("Missing metadata in context")
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		if !c.scopeAllows(info.FullMethod, level) {
+			log.Printf("API key %s lacks scope for %s", c.APIKeyID, info.FullMethod)
+			return nil, status.Error(codes.PermissionDenied, "insufficient api key scope")
 		}
-		vals := md.Get("authorization")
-		if len(vals) == 0 {
-			log.Println("Missing authorization header")
-			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		return handler(withCaller(ctx, c), req)
+	}
+}
+
+// authStreamInterceptor is authUnaryInterceptor for streaming RPCs.
+func authStreamInterceptor(keys *signingKeys, denylist *jtiDenylist, apiKeys *apiKeyAuth) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if os.Getenv("AUTH_DISABLED") == "1" {
+			return handler(srv, &contextStream{ServerStream: ss, ctx: withCaller(ss.Context(), caller{Role: roleAdmin})})
 		}
-		tokenStr := vals[0]
-		if len(tokenStr) > 7 && (tokenStr[:7] == "Bearer " || tokenStr[:7] == "bearer ") {
-			tokenStr = tokenStr[7:]
+		level := methodPolicy(info.FullMethod)
+		if level == accessPublic {
+			return handler(srv, ss)
 		}
-		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				log.Println("Unexpected signing method")
-				return nil, fmt.Errorf("unexpected signing method")
-			}
-			return secret, nil
-		})
+		c, err := callerFromMetadata(ss.Context(), keys, denylist, apiKeys)
 		if err != nil {
-			log.Printf("Error parsing token: %v", err)
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+			return err
 		}
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok || !token.Valid {
-			log.Println("Invalid JWT claims")
-			return nil, status.Error(codes.Unauthenticated, "invalid token claims")
+		if !c.allows(level) {
+			log.Printf("Role %q denied for %s", c.Role, info.FullMethod)
+			return status.Error(codes.PermissionDenied, "insufficient role")
 		}
-		sub, ok := claims["sub"].(string)
-		if !ok || sub == "" {
-			log.Println("Missing sub claim in JWT")
-			return nil, status.Error(codes.Unauthenticated, "missing sub claim")
+		if !c.scopeAllows(info.FullMethod, level) {
+			log.Printf("API key %s lacks scope for %s", c.APIKeyID, info.FullMethod)
+			return status.Error(codes.PermissionDenied, "insufficient api key scope")
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withCaller(ss.Context(), c)})
+	}
+}
+
+// authHTTPRequest authenticates an HTTP request to an endpoint that mirrors the gRPC
+// method fullMethod, from the same headers, and returns a context carrying the caller.
+func authHTTPRequest(r *http.Request, fullMethod string, keys *signingKeys, denylist *jtiDenylist, apiKeys *apiKeyAuth) (context.Context, error) {
+	if os.Getenv("AUTH_DISABLED") == "1" {
+		return withCaller(r.Context(), caller{Role: roleAdmin}), nil
+	}
+	md := metadata.MD{}
+	for _, h := range []string{"authorization", "x-api-key"} {
+		if v := r.Header.Get(h); v != "" {
+			md.Set(h, v)
 		}
-		ctx = context.WithValue(ctx, "user_id", sub)
-		return handler(ctx, req)
 	}
+	// API key IP allowlists check the peer address
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: net.TCPAddrFromAddrPort(addr)})
+	}
+	level := methodPolicy(fullMethod)
+	c, err := callerFromMetadata(ctx, keys, denylist, apiKeys)
+	if err != nil {
+		return nil, err
+	}
+	if !c.allows(level) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	if !c.scopeAllows(fullMethod, level) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient api key scope")
+	}
+	return withCaller(r.Context(), c), nil
+}
+
+// callerFromMetadata authenticates the API key in the x-api-key header, or else the
+// bearer token in the authorization header, which may be a JWT or an API key. Revoked
+// JWTs are rejected.
+func callerFromMetadata(ctx context.Context, keys *signingKeys, denylist *jtiDenylist, apiKeys *apiKeyAuth) (caller, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		log.Println("Missing metadata in context")
+		return caller{}, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	if vals := md.Get("x-api-key"); len(vals) > 0 {
+		return apiKeys.authenticate(ctx, vals[0])
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		log.Println("Missing authorization header")
+		return caller{}, status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	tokenStr := vals[0]
+	if len(tokenStr) > 7 && (tokenStr[:7] == "Bearer " || tokenStr[:7] == "bearer ") {
+		tokenStr = tokenStr[7:]
+	}
+	if strings.HasPrefix(tokenStr, apiKeyPrefix) {
+		return apiKeys.authenticate(ctx, tokenStr)
+	}
+	token, err := keys.parse(tokenStr)
+	if err != nil {
+		log.Printf("Error parsing token: %v", err)
+		return caller{}, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		log.Println("Invalid JWT claims")
+		return caller{}, status.Error(codes.Unauthenticated, "invalid token claims")
+	}
+	sub, ok := claims["sub"].(string)
+	if !ok || sub == "" {
+		log.Println("Missing sub claim in JWT")
+		return caller{}, status.Error(codes.Unauthenticated, "missing sub claim")
+	}
+	if typ, _ := claims["typ"].(string); typ != "" {
+		log.Printf("Non-access token (%s) presented", typ)
+		return caller{}, status.Error(codes.Unauthenticated, "not an access token")
+	}
+	jti, _ := claims["jti"].(string)
+	if denylist.isDenied(jti) {
+		log.Println("Revoked token presented")
+		return caller{}, status.Error(codes.Unauthenticated, "token revoked")
+	}
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = roleUser
+	}
+	c := caller{UserID: sub, Role: role, TokenID: jti}
+	c.SessionID, _ = claims["sid"].(string)
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		c.ExpiresAt = exp.Time
+	}
+	return c, nil
 }
 
 // Removed unused function authUnaryInterceptorWithFallback to fix compile error (U1000)
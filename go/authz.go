@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JWT role claims. Services are trusted backends such as the orchestrator: like admins
+// they may act on any user's bots, but they cannot call admin RPCs.
+const (
+	roleUser    = "user"
+	roleAdmin   = "admin"
+	roleService = "service"
+)
+
+// caller is the authenticated identity of an RPC, set by authUnaryInterceptor.
+type caller struct {
+	UserID    string
+	Role      string
+	TokenID   string    // jti of the access token
+	SessionID string    // refresh session the token was issued in, if any
+	ExpiresAt time.Time // access token or API key expiry
+	APIKeyID  string    // set when authenticated with an API key
+	Scopes    []string  // API key scopes; nil for access tokens, which are unrestricted
+}
+
+func (c caller) IsAdmin() bool { return c.Role == roleAdmin }
+
+// IsPrivileged reports whether the caller may act on any user's resources.
+func (c caller) IsPrivileged() bool { return c.Role == roleAdmin || c.Role == roleService }
+
+type callerKey struct{}
+
+func withCaller(ctx context.Context, c caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+func callerFromContext(ctx context.Context) (caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(caller)
+	return c, ok
+}
+
+// accessLevel is who may call an RPC.
+type accessLevel int
+
+const (
+	accessUser    accessLevel = iota // any authenticated caller
+	accessPublic                     // no token required
+	accessService                    // services and admins
+	accessAdmin                      // admins only
+)
+
+// methodAccess is the access policy for each RPC. Methods not listed require an
+// authenticated caller; per-resource ownership is checked by the handlers.
+var methodAccess = map[string]accessLevel{
+	"/trading.AuthService/Register":             accessPublic,
+	"/trading.AuthService/Login":                accessPublic,
+	"/trading.AuthService/RefreshToken":         accessPublic,
+	"/trading.AuthService/EnrollTOTP":           accessPublic, // challenge token or bearer checked by the handler
+	"/trading.AuthService/VerifyTOTP":           accessPublic,
+	"/trading.AuthService/CompleteLogin":        accessPublic,
+	"/trading.AuthService/RequestPasswordReset": accessPublic,
+	"/trading.AuthService/ResetPassword":        accessPublic,
+	"/trading.AuthService/VerifyEmail":          accessPublic,
+	"/trading.TradingService/GetPrice":          accessPublic,
+
+	// Users start and stop strategies through BotService, which checks bot ownership
+	"/trading.TradingService/StartStrategy": accessService,
+	"/trading.TradingService/StopStrategy":  accessService,
+
+	"/trading.OrderService/RunReconciliation":       accessService,
+	"/trading.OrderService/GetReconciliationReport": accessAdmin,
+
+	"/trading.AdminService/ListUsers":     accessAdmin,
+	"/trading.AdminService/ForceStopBots": accessAdmin,
+}
+
+func methodPolicy(fullMethod string) accessLevel {
+	return methodAccess[fullMethod]
+}
+
+// allows reports whether the caller meets an access level.
+func (c caller) allows(level accessLevel) bool {
+	switch level {
+	case accessPublic:
+		return true
+	case accessUser:
+		return c.UserID != "" || c.IsPrivileged()
+	case accessService:
+		return c.IsPrivileged()
+	case accessAdmin:
+		return c.IsAdmin()
+	}
+	return false
+}
+
+func (c caller) hasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeAllows reports whether the caller's API key scopes permit a method. Service
+// and admin methods need the admin scope, read-only keys are limited to readMethod.
+func (c caller) scopeAllows(fullMethod string, level accessLevel) bool {
+	if c.APIKeyID == "" || c.hasScope(scopeAdmin) {
+		return true
+	}
+	if level == accessService || level == accessAdmin {
+		return false
+	}
+	return c.hasScope(scopeTrade) || (c.hasScope(scopeRead) && readMethod(fullMethod))
+}
+
+// readMethod reports whether an RPC only reads state, going by its name.
+func readMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Stream", "Subscribe", "Export"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// botLookup finds a registered bot; botRegistry.get satisfies it.
+type botLookup func(botID string) (*pb.Bot, bool)
+
+// authorizeBot allows the bot's owner, admins and services. It returns Unauthenticated
+// without a caller, NotFound for an unknown bot and PermissionDenied for someone else's bot.
+func authorizeBot(ctx context.Context, bots botLookup, botID string) error {
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.IsPrivileged() {
+		return nil
+	}
+	if botID == "" {
+		return status.Error(codes.InvalidArgument, "bot_id is required")
+	}
+	if bots == nil {
+		return status.Error(codes.PermissionDenied, "bot ownership cannot be verified")
+	}
+	bot, ok := bots(botID)
+	if !ok {
+		return status.Error(codes.NotFound, "bot not found")
+	}
+	return authorizeBotOwner(ctx, bot)
+}
+
+// authorizeBotOwner is authorizeBot for a bot the caller has already looked up.
+func authorizeBotOwner(ctx context.Context, bot *pb.Bot) error {
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.IsPrivileged() || (bot.GetUserId() != "" && bot.GetUserId() == c.UserID) {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "bot belongs to another user")
+}
+
+// authorizeUser allows a caller to act on their own user id, and privileged callers on any.
+func authorizeUser(ctx context.Context, userID string) error {
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.IsPrivileged() || (userID != "" && userID == c.UserID) {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "not allowed for another user")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeBot(t *testing.T) {
+	bots := map[string]*pb.Bot{"b1": {BotId: "b1", UserId: "alice"}}
+	lookup := func(id string) (*pb.Bot, bool) { b, ok := bots[id]; return b, ok }
+	as := func(userID, role string) context.Context {
+		return withCaller(context.Background(), caller{UserID: userID, Role: role})
+	}
+
+	cases := []struct {
+		name  string
+		ctx   context.Context
+		botID string
+		want  codes.Code
+	}{
+		{"owner", as("alice", roleUser), "b1", codes.OK},
+		{"other user", as("bob", roleUser), "b1", codes.PermissionDenied},
+		{"admin", as("root", roleAdmin), "b1", codes.OK},
+		{"service", as("orchestrator", roleService), "b1", codes.OK},
+		{"unknown bot", as("alice", roleUser), "b2", codes.NotFound},
+		{"no caller", context.Background(), "b1", codes.Unauthenticated},
+	}
+	for _, tc := range cases {
+		if got := status.Code(authorizeBot(tc.ctx, lookup, tc.botID)); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMethodPolicy(t *testing.T) {
+	user := caller{UserID: "alice", Role: roleUser}
+	service := caller{UserID: "orchestrator", Role: roleService}
+	admin := caller{UserID: "root", Role: roleAdmin}
+
+	cases := []struct {
+		method string
+		c      caller
+		want   bool
+	}{
+		{"/trading.AuthService/Login", caller{}, true},
+		{"/trading.BotService/ListBots", caller{}, false},
+		{"/trading.BotService/ListBots", user, true},
+		{"/trading.OrderService/RunReconciliation", user, false},
+		{"/trading.OrderService/RunReconciliation", service, true},
+		{"/trading.TradingService/StopStrategy", user, false},
+		{"/trading.TradingService/StartStrategy", service, true},
+		{"/trading.AdminService/ListUsers", service, false},
+		{"/trading.AdminService/ForceStopBots", admin, true},
+	}
+	for _, tc := range cases {
+		if got := tc.c.allows(methodPolicy(tc.method)); got != tc.want {
+			t.Errorf("%s as %q: expected %v, got %v", tc.method, tc.c.Role, tc.want, got)
+		}
+	}
+}
+
+func TestExecuteTradeRequiresBotOwner(t *testing.T) {
+	botID := "6f1c2d1e-8a7b-4c8e-9f3a-2b1d0c9e8f7a"
+	trading := newTradingServer()
+	trading.bots = func(id string) (*pb.Bot, bool) { return &pb.Bot{BotId: id, UserId: "alice"}, id == botID }
+	bob := withCaller(context.Background(), caller{UserID: "bob", Role: roleUser})
+	_, err := trading.ExecuteTrade(bob, &pb.TradeRequest{
+		Symbol: "BTC-USD", Side: "BUY", Size: 1, Price: 100, BotId: botID, UserId: "0b6f4c1a-3d2e-4f5a-8b9c-1d2e3f4a5b6c",
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for another user's bot, got %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errBotNotFound = errors.New("bot not found")
+
+// BotRepository stores bots. DBService keeps them in Postgres and
+// mirroredBotRepository in a JSON file copied to Postgres; botRegistry caches either.
+type BotRepository interface {
+	ListBots(ctx context.Context) ([]*pb.Bot, error)
+	CreateBot(ctx context.Context, bot *pb.Bot) error
+	UpdateBot(ctx context.Context, bot *pb.Bot) error // editable fields and run state
+	DeleteBot(ctx context.Context, botID string) error
+}
+
+// fileBotRepository implements BotRepository in a JSON file, rewritten atomically on
+// every change. It suits single-instance development setups.
+type fileBotRepository struct {
+	mu   sync.Mutex
+	path string
+	bots map[string]*pb.Bot
+}
+
+func newFileBotRepository(path string) (*fileBotRepository, error) {
+	f := &fileBotRepository{path: path, bots: make(map[string]*pb.Bot)}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, os.MkdirAll(filepath.Dir(path), 0o755)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b = bytes.TrimSpace(b)
+	if bytes.HasPrefix(b, []byte("[")) {
+		// Older files hold a bare array of bots
+		b = append(append([]byte(`{"bots":`), b...), '}')
+	}
+	var list pb.BotList
+	if len(b) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, &list); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	for _, bot := range list.Bots {
+		if bot.BotId != "" {
+			f.bots[bot.BotId] = bot
+		}
+	}
+	return f, nil
+}
+
+func (f *fileBotRepository) ListBots(_ context.Context) ([]*pb.Bot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return sortedBots(f.bots, true), nil
+}
+
+func (f *fileBotRepository) CreateBot(_ context.Context, bot *pb.Bot) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.bots[bot.BotId]; ok {
+		return fmt.Errorf("bot %s already exists", bot.BotId)
+	}
+	now := time.Now()
+	bot.CreatedAtUnixMs = now.UnixMilli()
+	bot.CreatedAt = timestamppb.New(now)
+	return f.write(bot.BotId, proto.Clone(bot).(*pb.Bot))
+}
+
+func (f *fileBotRepository) UpdateBot(_ context.Context, bot *pb.Bot) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	next, err := f.mergedLocked(bot)
+	if err != nil {
+		return err
+	}
+	return f.write(bot.BotId, next)
+}
+
+// merged returns the stored bot as UpdateBot would store it, without storing it.
+func (f *fileBotRepository) merged(bot *pb.Bot) (*pb.Bot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.mergedLocked(bot)
+}
+
+// mergedLocked returns a copy of the stored bot with bot's editable fields and run
+// state applied. Callers hold f.mu.
+func (f *fileBotRepository) mergedLocked(bot *pb.Bot) (*pb.Bot, error) {
+	cur, ok := f.bots[bot.BotId]
+	if !ok {
+		return nil, errBotNotFound
+	}
+	next := proto.Clone(cur).(*pb.Bot)
+	next.Name, next.Description, next.Parameters, next.IsActive = bot.Name, bot.Description, bot.Parameters, bot.IsActive
+	next.StrategyStatus, next.StatusReason = bot.StrategyStatus, bot.StatusReason
+	next.UpdatedAt = timestamppb.Now()
+	return next, nil
+}
+
+func (f *fileBotRepository) DeleteBot(_ context.Context, botID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.bots[botID]; !ok {
+		return errBotNotFound
+	}
+	return f.write(botID, nil)
+}
+
+// write saves the file with botID set to bot, or removed when bot is nil, and only
+// then applies the change in memory. Callers hold f.mu.
+func (f *fileBotRepository) write(botID string, bot *pb.Bot) error {
+	next := make(map[string]*pb.Bot, len(f.bots)+1)
+	for id, b := range f.bots {
+		next[id] = b
+	}
+	if bot == nil {
+		delete(next, botID)
+	} else {
+		next[botID] = bot
+	}
+	b, err := protojson.MarshalOptions{Indent: "  "}.Marshal(&pb.BotList{Bots: sortedBots(next, false)})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(f.path, b, 0o644); err != nil {
+		return fmt.Errorf("failed to save bots: %w", err)
+	}
+	f.bots = next
+	return nil
+}
+
+// botMirror is where mirroredBotRepository copies bots. DBService implements it.
+type botMirror interface {
+	UpsertBot(ctx context.Context, bot *pb.Bot) error
+	DeleteBot(ctx context.Context, botID string) error
+}
+
+// mirroredBotRepository keeps bots in a JSON file and writes every change through to
+// the database too, because orders, trades, order events, checkpoints and performance
+// snapshots reference bots(id). The file is the source of truth: bots are read from
+// it, and newMirroredBotRepository copies it to the database on startup.
+type mirroredBotRepository struct {
+	file   *fileBotRepository
+	mirror botMirror
+}
+
+func newMirroredBotRepository(ctx context.Context, file *fileBotRepository, mirror botMirror) (*mirroredBotRepository, error) {
+	bots, err := file.ListBots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bot := range bots {
+		if err := mirror.UpsertBot(ctx, bot); err != nil {
+			return nil, fmt.Errorf("failed to copy bot %s to the database: %w", bot.BotId, err)
+		}
+	}
+	return &mirroredBotRepository{file: file, mirror: mirror}, nil
+}
+
+func (m *mirroredBotRepository) ListBots(ctx context.Context) ([]*pb.Bot, error) {
+	return m.file.ListBots(ctx)
+}
+
+// CreateBot writes the file first, which sets the creation time, and takes the bot
+// back out of the file if the database refuses it.
+func (m *mirroredBotRepository) CreateBot(ctx context.Context, bot *pb.Bot) error {
+	if err := m.file.CreateBot(ctx, bot); err != nil {
+		return err
+	}
+	if err := m.mirror.UpsertBot(ctx, bot); err != nil {
+		return errors.Join(err, m.file.DeleteBot(ctx, bot.BotId))
+	}
+	return nil
+}
+
+// UpdateBot writes the database first; if the file write then fails, the next
+// startup copies the file's version back over it.
+func (m *mirroredBotRepository) UpdateBot(ctx context.Context, bot *pb.Bot) error {
+	next, err := m.file.merged(bot)
+	if err != nil {
+		return err
+	}
+	if err := m.mirror.UpsertBot(ctx, next); err != nil {
+		return err
+	}
+	return m.file.UpdateBot(ctx, bot)
+}
+
+func (m *mirroredBotRepository) DeleteBot(ctx context.Context, botID string) error {
+	if err := m.mirror.DeleteBot(ctx, botID); err != nil && !errors.Is(err, errBotNotFound) {
+		return err
+	}
+	return m.file.DeleteBot(ctx, botID)
+}
+
+// sortedBots returns bots oldest first, cloned when clone is set.
+func sortedBots(m map[string]*pb.Bot, clone bool) []*pb.Bot {
+	out := make([]*pb.Bot, 0, len(m))
+	for _, b := range m {
+		if clone {
+			b = proto.Clone(b).(*pb.Bot)
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAtUnixMs != out[j].CreatedAtUnixMs {
+			return out[i].CreatedAtUnixMs < out[j].CreatedAtUnixMs
+		}
+		return out[i].BotId < out[j].BotId
+	})
+	return out
+}
+
+// writeFileAtomic replaces path with data so readers see either the old or the new
+// file, never a partial one.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// botRegistry is a write-through cache of a BotRepository. Reads are served from
+// memory; changes are written to the repository first and cached only once stored.
+type botRegistry struct {
+	mu   sync.RWMutex
+	bots map[string]*pb.Bot
+	repo BotRepository
+	// wmu serializes writes so the cache applies them in the order they were stored
+	wmu sync.Mutex
+	// changed is called with mu held after a bot is created, updated or removed
+	changed func(bot *pb.Bot)
+}
+
+// newBotRegistry loads every bot from repo.
+func newBotRegistry(ctx context.Context, repo BotRepository) (*botRegistry, error) {
+	bots, err := repo.ListBots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &botRegistry{bots: make(map[string]*pb.Bot, len(bots)), repo: repo}
+	for _, b := range bots {
+		r.bots[b.BotId] = b
+	}
+	return r, nil
+}
+
+// get returns a registered bot.
+func (r *botRegistry) get(botID string) (*pb.Bot, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	bot, ok := r.bots[botID]
+	return bot, ok
+}
+
+// create stores a new bot and caches it.
+func (r *botRegistry) create(ctx context.Context, bot *pb.Bot) error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if err := r.repo.CreateBot(ctx, bot); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bots[bot.BotId] = bot
+	r.notifyLocked(bot)
+	return nil
+}
+
+// update applies fn to a copy of the bot and stores it, then applies fn to the cached
+// bot and returns a snapshot. fn may run twice, so it must only set fields.
+func (r *botRegistry) update(ctx context.Context, botID string, fn func(bot *pb.Bot)) (*pb.Bot, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	r.mu.RLock()
+	cur, ok := r.bots[botID]
+	var next *pb.Bot
+	if ok {
+		next = proto.Clone(cur).(*pb.Bot)
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return nil, errBotNotFound
+	}
+	fn(next)
+	if err := r.repo.UpdateBot(ctx, next); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(cur)
+	r.notifyLocked(cur)
+	return proto.Clone(cur).(*pb.Bot), nil
+}
+
+// remove deletes a bot from the repository and the cache and returns it. A bot the
+// repository no longer has is still dropped from the cache.
+func (r *botRegistry) remove(ctx context.Context, botID string) (*pb.Bot, error) {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+	if err := r.repo.DeleteBot(ctx, botID); err != nil && !errors.Is(err, errBotNotFound) {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bot, ok := r.bots[botID]
+	if !ok {
+		return nil, errBotNotFound
+	}
+	delete(r.bots, botID)
+	bot.IsActive = false
+	r.notifyLocked(bot)
+	return bot, nil
+}
+
+func (r *botRegistry) notifyLocked(bot *pb.Bot) {
+	if r.changed != nil {
+		r.changed(bot)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFileBotRepositoryPersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "bots.json")
+	repo, err := newFileBotRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := newBotRegistry(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*pb.Bot{
+		{BotId: "b1", Name: "one", UserId: "u1", AccountValue: 5000, Parameters: map[string]string{"fast": "5"}},
+		{BotId: "b2", Name: "two", UserId: "u1"},
+	} {
+		if err := reg.create(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := reg.create(ctx, &pb.Bot{BotId: "b1"}); err == nil {
+		t.Error("expected a duplicate bot id to be refused")
+	}
+	if _, err := reg.update(ctx, "b1", func(b *pb.Bot) {
+		b.Name = "renamed"
+		b.IsActive = true
+		b.Parameters["strategy_id"] = "s1"
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.remove(ctx, "b2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.update(ctx, "b2", func(*pb.Bot) {}); err != errBotNotFound {
+		t.Errorf("expected errBotNotFound for a removed bot, got %v", err)
+	}
+
+	reopened, err := newFileBotRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bots, _ := reopened.ListBots(ctx)
+	if len(bots) != 1 {
+		t.Fatalf("expected 1 bot after reopening, got %d", len(bots))
+	}
+	b := bots[0]
+	if b.BotId != "b1" || b.Name != "renamed" || !b.IsActive || b.AccountValue != 5000 || b.Parameters["strategy_id"] != "s1" {
+		t.Errorf("unexpected bot after reopening: %v", b)
+	}
+	if b.CreatedAt == nil || b.CreatedAtUnixMs == 0 {
+		t.Error("expected the creation time to be stored")
+	}
+}
+
+func TestFileBotRepositoryReadsLegacyArray(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bots.json")
+	if err := os.WriteFile(path, []byte(`[{"botId":"b1","name":"old","accountValue":1000}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := newFileBotRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bots, _ := repo.ListBots(context.Background())
+	if len(bots) != 1 || bots[0].Name != "old" || bots[0].AccountValue != 1000 {
+		t.Errorf("unexpected bots %v", bots)
+	}
+}
+
+// failingBotRepository refuses every write.
+type failingBotRepository struct{ *fileBotRepository }
+
+func (*failingBotRepository) UpdateBot(context.Context, *pb.Bot) error { return os.ErrPermission }
+
+func TestBotRegistryKeepsCacheWhenWriteFails(t *testing.T) {
+	ctx := context.Background()
+	files, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &failingBotRepository{files}
+	reg, _ := newBotRegistry(ctx, repo)
+	if err := reg.create(ctx, &pb.Bot{BotId: "b1", Name: "one"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.update(ctx, "b1", func(b *pb.Bot) { b.Name = "two" }); err == nil {
+		t.Fatal("expected the failed write to be reported")
+	}
+	if b, _ := reg.get("b1"); b.Name != "one" {
+		t.Errorf("expected the cache to keep the stored name, got %q", b.Name)
+	}
+}
+
+// memBotMirror is a botMirror in memory that can be made to refuse writes.
+type memBotMirror struct {
+	bots map[string]*pb.Bot
+	fail bool
+}
+
+func (m *memBotMirror) UpsertBot(_ context.Context, bot *pb.Bot) error {
+	if m.fail {
+		return os.ErrPermission
+	}
+	m.bots[bot.BotId] = proto.Clone(bot).(*pb.Bot)
+	return nil
+}
+
+func (m *memBotMirror) DeleteBot(_ context.Context, botID string) error {
+	if _, ok := m.bots[botID]; !ok {
+		return errBotNotFound
+	}
+	delete(m.bots, botID)
+	return nil
+}
+
+func TestMirroredBotRepositoryWritesThrough(t *testing.T) {
+	ctx := context.Background()
+	files, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := files.CreateBot(ctx, &pb.Bot{BotId: "old", Name: "from the file"}); err != nil {
+		t.Fatal(err)
+	}
+	mirror := &memBotMirror{bots: make(map[string]*pb.Bot)}
+	repo, err := newMirroredBotRepository(ctx, files, mirror)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mirror.bots["old"].GetName() != "from the file" {
+		t.Errorf("expected bots in the file to be copied on startup, got %v", mirror.bots)
+	}
+
+	reg, _ := newBotRegistry(ctx, repo)
+	if err := reg.create(ctx, &pb.Bot{BotId: "b1", Name: "one", AccountValue: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.update(ctx, "b1", func(b *pb.Bot) { b.Name = "two"; b.IsActive = true }); err != nil {
+		t.Fatal(err)
+	}
+	if b := mirror.bots["b1"]; b.GetName() != "two" || !b.GetIsActive() || b.GetAccountValue() != 5000 || b.GetCreatedAt() == nil {
+		t.Errorf("expected the whole updated bot to be copied, got %v", b)
+	}
+	if _, err := reg.remove(ctx, "old"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := mirror.bots["old"]; ok {
+		t.Error("expected the deleted bot to be removed from the copy")
+	}
+
+	mirror.fail = true
+	if err := reg.create(ctx, &pb.Bot{BotId: "b2"}); !errors.Is(err, os.ErrPermission) {
+		t.Fatalf("expected the refused copy to fail the create, got %v", err)
+	}
+	if bots, _ := files.ListBots(ctx); len(bots) != 1 || bots[0].BotId != "b1" {
+		t.Errorf("expected the refused bot to be taken out of the file, got %v", bots)
+	}
+	if _, err := reg.update(ctx, "b1", func(b *pb.Bot) { b.Name = "three" }); err == nil {
+		t.Fatal("expected the refused copy to fail the update")
+	}
+	if bots, _ := files.ListBots(ctx); bots[0].Name != "two" {
+		t.Errorf("expected the file to keep the copied name, got %q", bots[0].Name)
+	}
+}
+
+func TestFileStoredBotCanTrade(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	files, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := newMirroredBotRepository(ctx, files, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, _ := newBotRegistry(ctx, repo)
+	bot := &pb.Bot{BotId: uuid.NewString(), UserId: uuid.NewString(), Name: "bot", Symbol: "BTC-USD", Strategy: "MOMENTUM", AccountValue: 1000}
+	if err := reg.create(ctx, bot); err != nil {
+		t.Fatal(err)
+	}
+
+	orders := newOrderServiceServer(db, NewEventBus(), sessionBoundary{})
+	orders.bots = reg.get
+	owner := withCaller(ctx, caller{UserID: bot.UserId, Role: roleUser})
+	order, err := orders.CreateOrder(owner, &pb.CreateOrderRequest{
+		BotId: bot.BotId, Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Type: pb.OrderType_MARKET, Quantity: floatToDecimal(0.1),
+	})
+	if err != nil {
+		t.Fatalf("expected an order for a file-stored bot, got %v", err)
+	}
+	if _, err := db.RecordFill(ctx, order.Id, &pb.Trade{TradeId: uuid.NewString(), BotId: bot.BotId, OrderId: order.Id, Symbol: "BTC-USD", Side: "BUY", Quantity: 0.1, Price: 100}); err != nil {
+		t.Errorf("expected a trade for a file-stored bot, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/grpc/status"
+)
+
+// resumeActiveBots restarts the strategies of bots that were active when the process
+// last stopped. A bot that cannot be resumed is stopped and marked FAILED with the
+// reason, so it is not reported as running when nothing runs it.
+func (s *botServiceServer) resumeActiveBots(ctx context.Context) (resumed, failed int) {
+	var active []*pb.Bot
+	s.reg.mu.RLock()
+	for _, bot := range s.reg.bots {
+		if bot.IsActive {
+			active = append(active, bot)
+		}
+	}
+	s.reg.mu.RUnlock()
+	sort.Slice(active, func(i, j int) bool { return active[i].BotId < active[j].BotId })
+
+	for _, bot := range active {
+		if err := s.resumeBot(ctx, bot); err != nil {
+			log.Printf("[resumeActiveBots] Bot %s could not be resumed: %v", bot.BotId, err)
+			s.markFailed(ctx, bot.BotId, status.Convert(err).Message())
+			failed++
+			continue
+		}
+		resumed++
+	}
+	if len(active) > 0 {
+		log.Printf("[resumeActiveBots] Resumed %d active bots, %d failed", resumed, failed)
+	}
+	return resumed, failed
+}
+
+// resumeBot starts a new strategy for a bot recorded as active.
+func (s *botServiceServer) resumeBot(ctx context.Context, bot *pb.Bot) error {
+	if s.venues != nil {
+		if _, err := s.venues.route(bot); err != nil {
+			return err
+		}
+	}
+	return s.startStrategy(ctx, bot)
+}
+
+// markFailed stops a bot and records why. The strategy id from before the restart
+// is dropped since no such strategy runs.
+func (s *botServiceServer) markFailed(ctx context.Context, botID, reason string) {
+	_, err := s.reg.update(ctx, botID, func(b *pb.Bot) {
+		b.IsActive = false
+		b.StrategyStatus = strategyFailed
+		b.StatusReason = reason
+		delete(b.Parameters, "strategy_id")
+	})
+	if err != nil {
+		log.Printf("[resumeActiveBots] Failed to mark bot %s failed: %v", botID, err)
+	}
+}
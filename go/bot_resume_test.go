@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	pb "aetherion/gen"
+)
+
+func TestResumeActiveBots(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bots.json")
+	repo, err := newFileBotRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*pb.Bot{
+		{BotId: "paper", Symbol: "BTC-USD", Strategy: "MOMENTUM", IsActive: true, Parameters: map[string]string{"strategy_id": "old"}},
+		{BotId: "live", Symbol: "BTC-USD", Strategy: "MOMENTUM", IsActive: true, IsLive: true, Parameters: map[string]string{"strategy_id": "old"}},
+		{BotId: "idle", Symbol: "BTC-USD", Strategy: "MOMENTUM"},
+	} {
+		if err := repo.CreateBot(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reg, err := newBotRegistry(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trading := newTradingServer()
+	s := newBotServiceServer(reg, trading, nil)
+	s.venues = newVenueRouter(nil, nil) // live trading disabled
+
+	resumed, failed := s.resumeActiveBots(ctx)
+	if resumed != 1 || failed != 1 {
+		t.Fatalf("expected 1 resumed and 1 failed, got %d and %d", resumed, failed)
+	}
+
+	paper, _ := reg.get("paper")
+	sid := paper.Parameters["strategy_id"]
+	if !paper.IsActive || paper.StrategyStatus != strategyRunning || sid == "old" {
+		t.Errorf("expected the paper bot to run a new strategy, got %v", paper)
+	}
+	trading.mu.RLock()
+	_, running := trading.strategies[sid]
+	trading.mu.RUnlock()
+	if !running {
+		t.Error("expected the resumed strategy to be registered")
+	}
+	defer trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: sid, Symbol: paper.Symbol})
+
+	reopened, err := newFileBotRepository(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bots, _ := reopened.ListBots(ctx)
+	for _, b := range bots {
+		switch b.BotId {
+		case "live":
+			if b.IsActive || b.StrategyStatus != strategyFailed || b.StatusReason != errLiveTradingDisabled.Error() || b.Parameters["strategy_id"] != "" {
+				t.Errorf("expected the live bot to be stored as failed, got %v", b)
+			}
+		case "idle":
+			if b.IsActive || b.StrategyStatus != "" {
+				t.Errorf("expected the idle bot to be left alone, got %v", b)
+			}
+		}
+	}
+}
@@ -2,139 +2,113 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"log"
-	"os"
-	"path/filepath"
-	"sync"
+	"sort"
 	"time"
 
 	pb "aetherion/gen"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type botRegistry struct {
-	mu   sync.RWMutex
-	bots map[string]*pb.Bot
-	path string
-	pg   *pgx.Conn
+// BotServiceServer implementation
+type botServiceServer struct {
+	pb.UnimplementedBotServiceServer
+	reg      *botRegistry
+	trading  *tradingServer
+	dbclient *DBService
+	bus      *EventBus // bot status snapshots for StreamBotStatus
+	// venues checks that resumed bots can still trade (injected)
+	venues *venueRouter
+	// snapshots records bots' equity every snapshotEvery; nil disables it (injected)
+	snapshots     snapshotStore
+	snapshotEvery time.Duration
 }
 
-func newBotRegistry() *botRegistry {
-	r := &botRegistry{bots: make(map[string]*pb.Bot)}
-	dsn := os.Getenv("POSTGRES_DSN")
-	if dsn != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		conn, err := pgx.Connect(ctx, dsn)
-		if err == nil {
-			log.Printf("bot registry postgres connect ok")
-			_, err2 := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS bots (
-				id TEXT PRIMARY KEY,
-				user_id TEXT NOT NULL,
-				name TEXT NOT NULL,
-				symbol TEXT NOT NULL,
-				strategy TEXT NOT NULL,
-				parameters JSONB NOT NULL,
-				is_active BOOLEAN DEFAULT TRUE,
-				created_at TIMESTAMPTZ DEFAULT now(),
-				updated_at TIMESTAMPTZ DEFAULT now(),
-				account_value NUMERIC(20, 8) DEFAULT 1000008
-            )`)
-			if err2 == nil {
-				log.Printf("bot pg table created")
-				r.pg = conn
-				r.loadFromPg(ctx)
-				return r
-			} else {
-				log.Printf("bot pg table err: %v", err2)
-			}
-		} else {
-			log.Printf("bot registry postgres connect failed: %v", err)
-		}
-	} else {
-		log.Printf("bot registry postgres dsn not set, using file storage")
-	}
-	dir := "data"
-	_ = os.MkdirAll(dir, 0o755)
-	r.path = filepath.Join(dir, "bots.json")
-	r.loadFromFile()
-	return r
+func newBotServiceServer(reg *botRegistry, trading *tradingServer, dbclient *DBService) *botServiceServer {
+	log.Printf("Creating BotServiceServer with %d registered bots", len(reg.bots))
+	s := &botServiceServer{reg: reg, trading: trading, dbclient: dbclient, bus: trading.eventBus}
+	reg.changed = s.publishLocked
+	return s
 }
 
-func (r *botRegistry) loadFromPg(ctx context.Context) {
-	rows, err := r.pg.Query(ctx, `SELECT id, user_id, name, symbol, strategy, parameters, is_active, extract(epoch from created_at)::bigint FROM bots`)
-	if err != nil {
-		log.Printf("bot load pg err: %v", err)
-		return
-	}
-	log.Printf("bot load pg ok")
-	defer rows.Close()
-	for rows.Next() {
-		var id, userID, name, symbol, strategy string
-		var paramsBytes []byte
-		var active bool
-		var created int64
-		if err := rows.Scan(&id, &userID, &name, &symbol, &strategy, &paramsBytes, &active, &created); err != nil {
-			log.Printf("bot load pg scan err: %v", err)
-			continue
-		}
-		m := map[string]string{}
-		_ = json.Unmarshal(paramsBytes, &m)
-		// Need to get real UserId for this.
-		// This is what actually sets bot's account value. Initially it seems to be docker compose.
-		r.bots[id] = &pb.Bot{BotId: id, Name: name, Symbol: symbol, Strategy: strategy, Parameters: m, IsActive: active, UserId: userID, CreatedAtUnixMs: created, AccountValue: 1000007}
+// defaultBotAccountValue is the starting balance of bots created without one, matching
+// the bots.account_value column default.
+const defaultBotAccountValue = 1000000
+
+// Strategy status values reported in pb.Bot.StrategyStatus.
+const (
+	strategyRunning = "RUNNING"
+	strategyStopped = "STOPPED"
+	strategyFailed  = "FAILED" // could not be resumed; StatusReason says why
+)
+
+// publishLocked publishes a snapshot of bot for StreamBotStatus. Callers hold s.reg.mu.
+func (s *botServiceServer) publishLocked(bot *pb.Bot) {
+	bot.UpdatedAt = timestamppb.Now()
+	if s.bus != nil {
+		s.bus.Publish(Event{Type: EventBotStatus, Data: proto.Clone(bot).(*pb.Bot)})
 	}
 }
 
-func (r *botRegistry) loadFromFile() {
-	b, err := os.ReadFile(r.path)
-	if err != nil {
-		return
-	}
-	var arr []*pb.Bot
-	if err := json.Unmarshal(b, &arr); err != nil {
-		log.Printf("bot registry load error: %v", err)
-		return
+// removeUserBots stops and unregisters the bots of a deleted user and returns their
+// ids. With Postgres the rows are already gone with the user.
+func (s *botServiceServer) removeUserBots(ctx context.Context, userID string) []string {
+	var owned []*pb.Bot
+	s.reg.mu.RLock()
+	for _, bot := range s.reg.bots {
+		if bot.UserId == userID {
+			owned = append(owned, bot)
+		}
 	}
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	for _, bot := range arr {
-		r.bots[bot.BotId] = bot
+	s.reg.mu.RUnlock()
+	ids := make([]string, 0, len(owned))
+	for _, bot := range owned {
+		s.stopStrategy(ctx, bot)
+		if _, err := s.reg.remove(ctx, bot.BotId); err != nil {
+			log.Printf("[removeUserBots] Failed to delete bot %s: %v", bot.BotId, err)
+			continue
+		}
+		ids = append(ids, bot.BotId)
 	}
+	sort.Strings(ids)
+	return ids
 }
 
-// BotServiceServer implementation
-type botServiceServer struct {
-	pb.BotServiceServer
-	reg      *botRegistry
-	trading  *tradingServer
-	dbclient *DBService
+// stopStrategy stops the bot's strategy if StartBot recorded one.
+func (s *botServiceServer) stopStrategy(ctx context.Context, bot *pb.Bot) {
+	s.reg.mu.RLock()
+	sid, ok := bot.Parameters["strategy_id"]
+	s.reg.mu.RUnlock()
+	if ok && s.trading != nil {
+		_, _ = s.trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: sid, Symbol: bot.Symbol})
+	}
 }
 
-func newBotServiceServer(reg *botRegistry, trading *tradingServer, dbclient *DBService) *botServiceServer {
-	log.Printf("Creating BotServiceServer with registry at %s", reg.path)
-	return &botServiceServer{reg: reg, trading: trading, dbclient: dbclient}
-}
 func (s *botServiceServer) DeleteBot(ctx context.Context, req *pb.BotIdRequest) (*pb.StatusResponse, error) {
 	log.Printf("[DeleteBot] Received request for bot ID: %s", req.GetBotId())
-	s.reg.mu.Lock()
-	defer s.reg.mu.Unlock()
-	bot, ok := s.reg.bots[req.GetBotId()]
+	bot, ok := s.reg.get(req.GetBotId())
 	if !ok {
 		log.Printf("[DeleteBot] Bot with ID %s not found", req.GetBotId())
 		return &pb.StatusResponse{Success: false, Message: "not found"}, nil
 	}
-	// Delete from database if dbclient is available
-	if s.dbclient != nil {
-		if err := s.dbclient.DeleteBot(ctx, req.GetBotId()); err != nil {
-			log.Printf("[DeleteBot] Failed to delete bot from DB: %v", err)
-			return &pb.StatusResponse{Success: false, Message: "db delete failed"}, nil
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
+	// A strategy left running would keep trading for a bot that no longer exists
+	s.stopStrategy(ctx, bot)
+	if _, err := s.reg.remove(ctx, req.GetBotId()); err != nil {
+		if errors.Is(err, errBotNotFound) {
+			return &pb.StatusResponse{Success: false, Message: "not found"}, nil
 		}
+		log.Printf("[DeleteBot] Failed to delete bot: %v", err)
+		return &pb.StatusResponse{Success: false, Message: "delete failed"}, nil
 	}
-	delete(s.reg.bots, req.GetBotId())
 	log.Printf("[DeleteBot] Bot %s deleted", bot.Name)
 	return &pb.StatusResponse{Success: true, Message: "bot deleted"}, nil
 }
@@ -148,8 +122,9 @@ func (s *botServiceServer) CreateBot(ctx context.Context, req *pb.CreateBotReque
 	id := uuid.New().String()
 
 	// Extract user ID from context (set by auth interceptor)
-	userID, ok := ctx.Value("user_id").(string)
-	if !ok || userID == "" {
+	c, _ := callerFromContext(ctx)
+	userID := c.UserID
+	if userID == "" {
 		log.Printf("[CreateBot] user_id missing from context")
 		return &pb.StatusResponse{Success: false, Message: "auth required"}, nil
 	}
@@ -166,33 +141,26 @@ func (s *botServiceServer) CreateBot(ctx context.Context, req *pb.CreateBotReque
 		params = map[string]string{}
 	}
 
-	if s.dbclient != nil {
-		_, err := s.dbclient.CreateBot(ctx, &pb.Bot{
-			BotId:           id,
-			Symbol:          req.GetSymbol(),
-			Strategy:        req.GetStrategy(),
-			Parameters:      params,
-			IsActive:        false,
-			Name:            req.GetName(),
-			UserId:          userID,
-			AccountValue:    req.GetAccountValue(), // <-- use this instead
-			CreatedAtUnixMs: time.Now().UnixMilli(),
-		})
-
-		log.Printf("[CreateBot] Added bot to database with ID: %s", id)
-		if err != nil {
-			log.Printf("[CreateBot] Error adding bot to database: %v", err)
-			return &pb.StatusResponse{Success: false, Message: err.Error()}, nil
-		}
+	accountValue := req.GetAccountValue()
+	if accountValue <= 0 {
+		accountValue = defaultBotAccountValue
 	}
-
-	// Create bot entry in registry
-	bot := &pb.Bot{BotId: id, Name: req.GetName(), Symbol: req.GetSymbol(), Strategy: req.GetStrategy(), Parameters: params, IsActive: false}
-
-	// Add bot to registry
-	s.reg.mu.Lock()
-	s.reg.bots[id] = bot
-	s.reg.mu.Unlock()
+	bot := &pb.Bot{
+		BotId:        id,
+		Symbol:       req.GetSymbol(),
+		Strategy:     req.GetStrategy(),
+		Parameters:   params,
+		IsActive:     false,
+		Name:         req.GetName(),
+		UserId:       userID,
+		AccountValue: accountValue,
+		IsLive:       req.GetIsLive(),
+	}
+	if err := s.reg.create(ctx, bot); err != nil {
+		log.Printf("[CreateBot] Error storing bot: %v", err)
+		return &pb.StatusResponse{Success: false, Message: err.Error()}, nil
+	}
+	log.Printf("[CreateBot] Created bot with ID: %s", id)
 
 	// Persist to file if using file storage
 	// if s.dbclient != nil {
@@ -211,11 +179,19 @@ func (s *botServiceServer) CreateBot(ctx context.Context, req *pb.CreateBotReque
 	return &pb.StatusResponse{Success: true, Message: "bot created", Id: id}, nil
 }
 
+// ListBots returns the caller's bots, or every bot for admins.
 func (s *botServiceServer) ListBots(ctx context.Context, _ *pb.Empty) (*pb.BotList, error) {
 	// log.Printf("[ListBots] Received request to list bots")
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
 	out := &pb.BotList{}
 	s.reg.mu.RLock()
 	for _, b := range s.reg.bots {
+		if !c.IsPrivileged() && b.UserId != c.UserID {
+			continue
+		}
 		out.Bots = append(out.Bots, b)
 	}
 	s.reg.mu.RUnlock()
@@ -224,46 +200,70 @@ func (s *botServiceServer) ListBots(ctx context.Context, _ *pb.Empty) (*pb.BotLi
 
 func (s *botServiceServer) StartBot(ctx context.Context, req *pb.BotIdRequest) (*pb.StatusResponse, error) {
 	log.Printf("[StartBot] Received request for bot ID: %s", req.GetBotId())
-	s.reg.mu.Lock()
-	bot, ok := s.reg.bots[req.GetBotId()]
-	s.reg.mu.Unlock()
+	bot, ok := s.reg.get(req.GetBotId())
 	if !ok {
 		log.Printf("[StartBot] Bot with ID %s not found", req.GetBotId())
 		return &pb.StatusResponse{Success: false, Message: "not found"}, nil
 	}
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
 	log.Printf("[StartBot] Bot %s found. Current IsActive: %t", bot.Name, bot.IsActive)
 
+	if err := s.startStrategy(ctx, bot); err != nil {
+		log.Printf("[StartBot] Error starting strategy for bot %s: %v", bot.Name, err)
+		return &pb.StatusResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.StatusResponse{Success: true, Message: "bot started", Id: bot.BotId}, nil
+}
+
+// startStrategy runs a new strategy for the bot and records it as running.
+func (s *botServiceServer) startStrategy(ctx context.Context, bot *pb.Bot) error {
 	// Kick off strategy via trading server
 	stratReq := &pb.StrategyRequest{Symbol: bot.Symbol, Parameters: map[string]string{"type": bot.Strategy, "user_id": bot.BotId}} // Pass bot.BotId as user_id
-	resp, err := s.trading.StartStrategy(ctx, stratReq)
+	resp, err := s.trading.runStrategy(ctx, stratReq, bot.BotId)
 	if err != nil {
-		log.Printf("[StartBot] Error starting strategy for bot %s: %v", bot.Name, err)
-		return &pb.StatusResponse{Success: false, Message: err.Error()}, nil
+		return err
 	}
 	log.Printf("[StartBot] Strategy started for bot %s. Strategy ID: %s", bot.Name, resp.Id)
 
-	s.reg.mu.Lock()
-	bot.IsActive = true
-	bot.Parameters["strategy_id"] = resp.Id
-	s.reg.mu.Unlock()
-
-	return &pb.StatusResponse{Success: true, Message: "bot started", Id: bot.BotId}, nil
+	_, err = s.reg.update(ctx, bot.BotId, func(b *pb.Bot) {
+		b.IsActive = true
+		if b.Parameters == nil {
+			b.Parameters = map[string]string{}
+		}
+		b.Parameters["strategy_id"] = resp.Id
+		b.StrategyStatus = strategyRunning
+		b.StatusReason = ""
+	})
+	if err != nil {
+		// An unrecorded strategy could never be stopped, so don't leave it running
+		log.Printf("[StartBot] Failed to persist bot %s: %v", bot.BotId, err)
+		_, _ = s.trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: resp.Id, Symbol: bot.Symbol})
+		return errors.New("failed to save bot state")
+	}
+	return nil
 }
 
 func (s *botServiceServer) StopBot(ctx context.Context, req *pb.BotIdRequest) (*pb.StatusResponse, error) {
-	s.reg.mu.Lock()
-	bot, ok := s.reg.bots[req.GetBotId()]
-	s.reg.mu.Unlock()
+	bot, ok := s.reg.get(req.GetBotId())
 	if !ok {
 		return &pb.StatusResponse{Success: false, Message: "not found"}, nil
 	}
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
 	// Attempt to stop strategy if we stored its id
-	if sid, ok2 := bot.Parameters["strategy_id"]; ok2 {
-		_, _ = s.trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: sid, Symbol: bot.Symbol})
+	s.stopStrategy(ctx, bot)
+	_, err := s.reg.update(ctx, bot.BotId, func(b *pb.Bot) {
+		b.IsActive = false
+		b.StrategyStatus = strategyStopped
+		b.StatusReason = ""
+	})
+	if err != nil {
+		log.Printf("[StopBot] Failed to persist bot %s: %v", bot.BotId, err)
+		return &pb.StatusResponse{Success: false, Message: "failed to save bot state"}, nil
 	}
-	s.reg.mu.Lock()
-	bot.IsActive = false
-	s.reg.mu.Unlock()
 
 	return &pb.StatusResponse{Success: true, Message: "bot stopped", Id: bot.BotId}, nil
 }
@@ -275,5 +275,82 @@ func (s *botServiceServer) GetBotStatus(ctx context.Context, req *pb.BotIdReques
 	if !ok {
 		return &pb.Bot{}, nil
 	}
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
 	return bot, nil
 }
+
+func (s *botServiceServer) GetBot(ctx context.Context, req *pb.BotIdRequest) (*pb.Bot, error) {
+	s.reg.mu.RLock()
+	defer s.reg.mu.RUnlock()
+	bot, ok := s.reg.bots[req.GetBotId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "bot not found")
+	}
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
+	return proto.Clone(bot).(*pb.Bot), nil
+}
+
+// UpdateBot edits a bot's name, description and parameters, and starts or stops it
+// when is_active changes.
+func (s *botServiceServer) UpdateBot(ctx context.Context, req *pb.UpdateBotRequest) (*pb.Bot, error) {
+	if req.Name != nil && *req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name cannot be empty")
+	}
+	if _, ok := req.Parameters["strategy_id"]; ok {
+		return nil, status.Error(codes.InvalidArgument, "strategy_id is managed by StartBot")
+	}
+
+	bot, ok := s.reg.get(req.GetBotId())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "bot not found")
+	}
+	if err := authorizeBotOwner(ctx, bot); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.reg.update(ctx, req.BotId, func(b *pb.Bot) {
+		if req.Name != nil {
+			b.Name = *req.Name
+		}
+		if req.Description != nil {
+			b.Description = *req.Description
+		}
+		if len(req.Parameters) > 0 && b.Parameters == nil {
+			b.Parameters = map[string]string{}
+		}
+		for k, v := range req.Parameters {
+			if v == "" {
+				delete(b.Parameters, k)
+			} else {
+				b.Parameters[k] = v
+			}
+		}
+	})
+	if errors.Is(err, errBotNotFound) {
+		return nil, status.Error(codes.NotFound, "bot not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update bot: %v", err)
+	}
+
+	if req.IsActive != nil && *req.IsActive != updated.IsActive {
+		var resp *pb.StatusResponse
+		var err error
+		if *req.IsActive {
+			resp, err = s.StartBot(ctx, &pb.BotIdRequest{BotId: req.BotId})
+		} else {
+			resp, err = s.StopBot(ctx, &pb.BotIdRequest{BotId: req.BotId})
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, status.Error(codes.FailedPrecondition, resp.Message)
+		}
+	}
+	return s.GetBot(ctx, &pb.BotIdRequest{BotId: req.BotId})
+}
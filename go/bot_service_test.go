@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+)
+
+func TestDeleteBotStopsItsStrategy(t *testing.T) {
+	ctx := context.Background()
+	repo, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, err := newBotRegistry(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.create(ctx, &pb.Bot{BotId: "b1", UserId: "alice", Symbol: "BTC-USD", Strategy: "MOMENTUM"}); err != nil {
+		t.Fatal(err)
+	}
+	trading := newTradingServer()
+	s := newBotServiceServer(reg, trading, nil)
+	s.venues = newVenueRouter(nil, nil)
+
+	aliceCtx := withCaller(ctx, caller{UserID: "alice", Role: roleUser})
+	if resp, err := s.StartBot(aliceCtx, &pb.BotIdRequest{BotId: "b1"}); err != nil || !resp.Success {
+		t.Fatalf("expected the bot to start, got %v, %v", resp, err)
+	}
+	bot, _ := reg.get("b1")
+	sid := bot.Parameters["strategy_id"]
+
+	id, ch := trading.eventBus.Subscribe(16)
+	defer trading.eventBus.Unsubscribe(id)
+	if resp, err := s.DeleteBot(aliceCtx, &pb.BotIdRequest{BotId: "b1"}); err != nil || !resp.Success {
+		t.Fatalf("expected the bot to be deleted, got %v, %v", resp, err)
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case evt := <-ch:
+			if st, ok := evt.Data.(StrategyStatus); ok && evt.Type == EventStrategyStatus && st.StrategyID == sid {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected the deleted bot's strategy to stop")
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Equity changes smaller than botEquityMinChange, or sooner than botEquityMinInterval
+// after the last update, are not published so price ticks do not flood StreamBotStatus.
+const (
+	botEquityMinChange   = 0.01
+	botEquityMinInterval = time.Second
+)
+
+// snapshotWriteTimeout bounds how long one batch of performance snapshots may take to store.
+const snapshotWriteTimeout = 10 * time.Second
+
+// botHolding is a bot's net position in one symbol and the cash its trades moved.
+type botHolding struct {
+	qty  float64
+	cash float64
+}
+
+// botEquityBook marks each bot's holdings to the latest prices. Equity is the bot's
+// starting account value plus trade cash plus positions at market.
+type botEquityBook struct {
+	holdings  map[string]map[string]*botHolding // bot id -> symbol -> holding
+	prices    map[string]float64
+	published map[string]time.Time
+}
+
+func newBotEquityBook() *botEquityBook {
+	return &botEquityBook{
+		holdings:  make(map[string]map[string]*botHolding),
+		prices:    make(map[string]float64),
+		published: make(map[string]time.Time),
+	}
+}
+
+func (b *botEquityBook) holding(botID, symbol string) *botHolding {
+	bySymbol := b.holdings[botID]
+	if bySymbol == nil {
+		bySymbol = make(map[string]*botHolding)
+		b.holdings[botID] = bySymbol
+	}
+	h := bySymbol[symbol]
+	if h == nil {
+		h = &botHolding{}
+		bySymbol[symbol] = h
+	}
+	return h
+}
+
+// apply books a fill; signedQty is negative for sells.
+func (b *botEquityBook) apply(botID, symbol string, signedQty, price float64) {
+	h := b.holding(botID, symbol)
+	h.qty += signedQty
+	h.cash -= signedQty * price
+	if _, ok := b.prices[symbol]; !ok && price > 0 {
+		b.prices[symbol] = price
+	}
+}
+
+// equity returns the bot's marked equity. Symbols without a known price are valued at zero.
+func (b *botEquityBook) equity(botID string, initial float64) float64 {
+	eq := initial
+	for symbol, h := range b.holdings[botID] {
+		eq += h.cash + h.qty*b.prices[symbol]
+	}
+	return eq
+}
+
+// cash returns the bot's starting account value plus the cash its trades moved.
+func (b *botEquityBook) cash(botID string, initial float64) float64 {
+	c := initial
+	for _, h := range b.holdings[botID] {
+		c += h.cash
+	}
+	return c
+}
+
+// holders returns the bots with an open position in symbol.
+func (b *botEquityBook) holders(symbol string) []string {
+	var out []string
+	for botID, bySymbol := range b.holdings {
+		if h, ok := bySymbol[symbol]; ok && math.Abs(h.qty) > quantityEpsilon {
+			out = append(out, botID)
+		}
+	}
+	return out
+}
+
+// RunStatusFeed keeps bots' equity and strategy status current from the EventBus and
+// publishes a bot snapshot whenever either changes.
+func (s *botServiceServer) RunStatusFeed(ctx context.Context) {
+	book := newBotEquityBook()
+	if s.dbclient != nil {
+		positions, err := s.dbclient.ListBotPositions(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("bot equity: failed to load positions")
+		}
+		for _, p := range positions {
+			*book.holding(p.BotID, p.Symbol) = botHolding{qty: p.Quantity, cash: p.Cash}
+		}
+	}
+
+	var snapshotTick <-chan time.Time
+	pending := make(chan []*performanceSnapshot, 1)
+	if s.snapshots != nil {
+		ticker := time.NewTicker(s.snapshotEvery)
+		defer ticker.Stop()
+		snapshotTick = ticker.C
+		// Snapshots are stored on their own goroutine so a slow database does not hold
+		// up status updates while the bus drops events.
+		go s.writeSnapshots(ctx, pending)
+	}
+
+	id, ch := s.bus.Subscribe(4096)
+	defer s.bus.Unsubscribe(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-snapshotTick:
+			select {
+			case pending <- s.takeSnapshots(book):
+			default:
+				log.Warn().Msg("skipping performance snapshots while the previous batch is still being stored")
+			}
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			switch evt.Type {
+			case EventPriceTick:
+				tick := evt.Data.(PriceTick)
+				book.prices[tick.Symbol] = tick.Price
+				for _, botID := range book.holders(tick.Symbol) {
+					s.refreshEquity(book, botID, false)
+				}
+			case EventOrderUpdate:
+				fill := evt.Data.(OrderEvent).Fill
+				if fill == nil || fill.BotId == "" {
+					continue
+				}
+				qty := fill.Quantity
+				if fill.Side == pb.OrderSide_SELL.String() {
+					qty = -qty
+				}
+				book.apply(fill.BotId, fill.Symbol, qty, fill.Price)
+				s.refreshEquity(book, fill.BotId, true)
+			case EventStrategyStatus:
+				s.strategyExited(evt.Data.(StrategyStatus))
+			}
+		}
+	}
+}
+
+func (s *botServiceServer) refreshEquity(book *botEquityBook, botID string, force bool) {
+	s.reg.mu.Lock()
+	defer s.reg.mu.Unlock()
+	bot, ok := s.reg.bots[botID]
+	if !ok {
+		return
+	}
+	eq := book.equity(botID, bot.AccountValue)
+	if !force {
+		if math.Abs(eq-decimalToFloat(bot.CurrentAccountValue)) < botEquityMinChange ||
+			time.Since(book.published[botID]) < botEquityMinInterval {
+			return
+		}
+	}
+	if bot.InitialAccountValue == nil {
+		bot.InitialAccountValue = floatToDecimal(bot.AccountValue)
+	}
+	bot.CurrentAccountValue = floatToDecimal(eq)
+	book.published[botID] = time.Now()
+	s.publishLocked(bot)
+}
+
+// snapshotStore keeps bots' performance history. DBService implements it.
+type snapshotStore interface {
+	RecordPerformanceSnapshots(ctx context.Context, snaps []*performanceSnapshot) error
+}
+
+// takeSnapshots marks the equity of every active bot and every bot holding a position.
+// PnL is measured from the bot's starting account value.
+func (s *botServiceServer) takeSnapshots(book *botEquityBook) []*performanceSnapshot {
+	now := timestamppb.Now()
+	var snaps []*performanceSnapshot
+	s.reg.mu.RLock()
+	for botID, bot := range s.reg.bots {
+		if !bot.IsActive && len(book.holdings[botID]) == 0 {
+			continue
+		}
+		eq := book.equity(botID, bot.AccountValue)
+		snaps = append(snaps, &performanceSnapshot{BotID: botID, BotPerformanceSnapshot: &pb.BotPerformanceSnapshot{
+			SnapshotTime: now,
+			EquityValue:  floatToDecimal(eq),
+			CashBalance:  floatToDecimal(book.cash(botID, bot.AccountValue)),
+			Pnl:          floatToDecimal(eq - bot.AccountValue),
+		}})
+	}
+	s.reg.mu.RUnlock()
+	return snaps
+}
+
+// writeSnapshots stores each batch taken by RunStatusFeed until ctx is canceled.
+func (s *botServiceServer) writeSnapshots(ctx context.Context, pending <-chan []*performanceSnapshot) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case snaps := <-pending:
+			s.recordSnapshots(ctx, snaps)
+		}
+	}
+}
+
+func (s *botServiceServer) recordSnapshots(ctx context.Context, snaps []*performanceSnapshot) {
+	if len(snaps) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, snapshotWriteTimeout)
+	defer cancel()
+	if err := s.snapshots.RecordPerformanceSnapshots(ctx, snaps); err != nil {
+		log.Warn().Err(err).Int("bots", len(snaps)).Msg("failed to record performance snapshots")
+	}
+}
+
+// strategyExited marks a bot stopped when its running strategy exits on its own.
+func (s *botServiceServer) strategyExited(st StrategyStatus) {
+	s.reg.mu.RLock()
+	bot, ok := s.reg.bots[st.BotID]
+	running := ok && bot.Parameters["strategy_id"] == st.StrategyID && bot.StrategyStatus == strategyRunning
+	s.reg.mu.RUnlock()
+	if !running {
+		return
+	}
+	_, err := s.reg.update(context.Background(), st.BotID, func(b *pb.Bot) {
+		b.IsActive = false
+		b.StrategyStatus = strategyStopped
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("bot_id", st.BotID).Msg("failed to record exited strategy")
+	}
+}
+
+// StreamBotStatus sends the bot's current state, then a new snapshot whenever its
+// state, equity or strategy status changes. The stream ends with NotFound if the bot
+// is deleted.
+func (s *botServiceServer) StreamBotStatus(req *pb.BotIdRequest, stream pb.BotService_StreamBotStatusServer) error {
+	ctx := stream.Context()
+	// Subscribe before taking the snapshot so no change in between is missed.
+	id, ch := s.bus.Subscribe(64)
+	defer s.bus.Unsubscribe(id)
+
+	bot, err := s.GetBot(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(bot); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if evt.Type != EventBotStatus {
+				continue
+			}
+			snapshot := evt.Data.(*pb.Bot)
+			if snapshot.BotId != req.BotId {
+				continue
+			}
+			if err := stream.Send(snapshot); err != nil {
+				return err
+			}
+			if _, ok := s.reg.get(req.BotId); !ok {
+				return status.Error(codes.NotFound, "bot deleted")
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	pb "aetherion/gen"
+)
+
+func TestBotEquityBook(t *testing.T) {
+	book := newBotEquityBook()
+	book.apply("bot", "BTC-USD", 2, 100)  // buy 2 @ 100
+	book.apply("bot", "BTC-USD", -1, 120) // sell 1 @ 120
+	if got := book.equity("bot", 1000); math.Abs(got-1020) > 1e-9 {
+		t.Errorf("expected 1020 marked at the first fill price, got %v", got)
+	}
+	book.prices["BTC-USD"] = 150
+	if got := book.equity("bot", 1000); math.Abs(got-1070) > 1e-9 {
+		t.Errorf("expected 1070 marked at 150, got %v", got)
+	}
+	if h := book.holders("BTC-USD"); len(h) != 1 || h[0] != "bot" {
+		t.Errorf("expected bot to hold BTC-USD, got %v", h)
+	}
+	book.apply("bot", "BTC-USD", -1, 150)
+	if h := book.holders("BTC-USD"); len(h) != 0 {
+		t.Errorf("expected no holders after closing the position, got %v", h)
+	}
+}
+
+type memSnapshotStore struct {
+	snaps    []*performanceSnapshot
+	deadline bool
+}
+
+func (m *memSnapshotStore) RecordPerformanceSnapshots(ctx context.Context, snaps []*performanceSnapshot) error {
+	m.snaps = append(m.snaps, snaps...)
+	_, m.deadline = ctx.Deadline()
+	return nil
+}
+
+func TestRecordSnapshots(t *testing.T) {
+	ctx := context.Background()
+	repo, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*pb.Bot{
+		{BotId: "active", AccountValue: 1000, IsActive: true},
+		{BotId: "holding", AccountValue: 1000},
+		{BotId: "idle", AccountValue: 1000},
+	} {
+		if err := repo.CreateBot(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reg, err := newBotRegistry(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newBotServiceServer(reg, newTradingServer(), nil)
+	store := &memSnapshotStore{}
+	s.snapshots = store
+
+	book := newBotEquityBook()
+	book.apply("holding", "BTC-USD", 2, 100)
+	book.prices["BTC-USD"] = 150
+	s.recordSnapshots(ctx, s.takeSnapshots(book))
+
+	got := map[string]*performanceSnapshot{}
+	for _, snap := range store.snaps {
+		got[snap.BotID] = snap
+	}
+	if len(got) != 2 || got["idle"] != nil {
+		t.Fatalf("expected snapshots of the active and holding bots, got %v", got)
+	}
+	if !store.deadline {
+		t.Error("expected the write to be bounded by a timeout")
+	}
+	h := got["holding"]
+	if decimalToFloat(h.EquityValue) != 1100 || decimalToFloat(h.CashBalance) != 800 || decimalToFloat(h.Pnl) != 100 {
+		t.Errorf("expected equity 1100, cash 800 and pnl 100, got %v", h.BotPerformanceSnapshot)
+	}
+	if a := got["active"]; decimalToFloat(a.EquityValue) != 1000 || decimalToFloat(a.Pnl) != 0 {
+		t.Errorf("expected the active bot flat at 1000, got %v", a.BotPerformanceSnapshot)
+	}
+}
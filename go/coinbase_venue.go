@@ -0,0 +1,507 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// coinbaseCredentials is a Coinbase Developer Platform API key: the key name
+// ("organizations/{org}/apiKeys/{key}") and its EC private key in PEM form.
+type coinbaseCredentials struct {
+	KeyName    string
+	PrivateKey string
+	UserID     string // owner of a vault key; empty for the platform key
+}
+
+// coinbaseKeyring resolves the API key to use for a bot's orders, and lists every
+// account whose fills should be polled.
+type coinbaseKeyring interface {
+	forBot(ctx context.Context, botID string) (coinbaseCredentials, error)
+	accounts(ctx context.Context) ([]coinbaseCredentials, error)
+}
+
+// staticCoinbaseKeyring trades every bot on one platform account.
+type staticCoinbaseKeyring struct{ creds coinbaseCredentials }
+
+func (k staticCoinbaseKeyring) forBot(context.Context, string) (coinbaseCredentials, error) {
+	return k.creds, nil
+}
+
+func (k staticCoinbaseKeyring) accounts(context.Context) ([]coinbaseCredentials, error) {
+	return []coinbaseCredentials{k.creds}, nil
+}
+
+// vaultCoinbaseKeyring trades each bot with its owner's key from the credential
+// vault. Keys are decrypted per request and not cached.
+type vaultCoinbaseKeyring struct {
+	vault *credentialVault
+	bots  func(botID string) (*pb.Bot, bool)
+}
+
+func (k vaultCoinbaseKeyring) forBot(ctx context.Context, botID string) (coinbaseCredentials, error) {
+	bot, ok := k.bots(botID)
+	if !ok || bot.UserId == "" {
+		return coinbaseCredentials{}, fmt.Errorf("no owner known for bot %s", botID)
+	}
+	secret, err := k.vault.Decrypt(ctx, bot.UserId, "coinbase")
+	if err != nil {
+		return coinbaseCredentials{}, fmt.Errorf("no coinbase credential for bot owner: %w", err)
+	}
+	return coinbaseCredentials{KeyName: secret.APIKey, PrivateKey: secret.APISecret, UserID: secret.UserID}, nil
+}
+
+func (k vaultCoinbaseKeyring) accounts(ctx context.Context) ([]coinbaseCredentials, error) {
+	secrets, err := k.vault.DecryptAll(ctx, "coinbase")
+	out := make([]coinbaseCredentials, 0, len(secrets))
+	for _, s := range secrets {
+		out = append(out, coinbaseCredentials{KeyName: s.APIKey, PrivateKey: s.APISecret, UserID: s.UserID})
+	}
+	return out, err
+}
+
+// coinbaseVenue executes orders through the Coinbase Advanced Trade REST API.
+// Credentials are resolved per request so rotated keys take effect without a restart.
+type coinbaseVenue struct {
+	baseURL string
+	client  *http.Client
+	keys    coinbaseKeyring
+}
+
+func newCoinbaseVenue(baseURL string, keys coinbaseKeyring) *coinbaseVenue {
+	return &coinbaseVenue{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		keys:    keys,
+	}
+}
+
+func (v *coinbaseVenue) Name() string { return "coinbase" }
+
+type coinbaseOrderResponse struct {
+	Success         bool `json:"success"`
+	SuccessResponse struct {
+		OrderID       string `json:"order_id"`
+		ClientOrderID string `json:"client_order_id"`
+	} `json:"success_response"`
+	ErrorResponse struct {
+		Error                string `json:"error"`
+		Message              string `json:"message"`
+		PreviewFailureReason string `json:"preview_failure_reason"`
+	} `json:"error_response"`
+	FailureReason string `json:"failure_reason"`
+}
+
+// PlaceOrder submits the order with its internal id as client_order_id, which Coinbase
+// uses to deduplicate retried submissions.
+func (v *coinbaseVenue) PlaceOrder(ctx context.Context, order *pb.Order) (*VenueOrderAck, error) {
+	config, err := coinbaseOrderConfiguration(order)
+	if err != nil {
+		return nil, err
+	}
+	side := order.Side.String()
+	if order.Side != pb.OrderSide_BUY && order.Side != pb.OrderSide_SELL {
+		return nil, fmt.Errorf("unsupported order side %s", side)
+	}
+	body := map[string]any{
+		"client_order_id":     order.Id,
+		"product_id":          order.Symbol,
+		"side":                side,
+		"order_configuration": config,
+	}
+	creds, err := v.keys.forBot(ctx, order.BotId)
+	if err != nil {
+		return nil, err
+	}
+	var resp coinbaseOrderResponse
+	if err := v.do(ctx, creds, http.MethodPost, "/api/v3/brokerage/orders", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		reason := resp.ErrorResponse.Message
+		if reason == "" {
+			reason = resp.ErrorResponse.Error
+		}
+		if reason == "" {
+			reason = resp.ErrorResponse.PreviewFailureReason
+		}
+		if reason == "" {
+			reason = resp.FailureReason
+		}
+		return nil, fmt.Errorf("coinbase rejected order: %s", reason)
+	}
+	return &VenueOrderAck{VenueOrderID: resp.SuccessResponse.OrderID, Status: pb.OrderStatus_SUBMITTED}, nil
+}
+
+// coinbaseOrderConfiguration maps an order's type and time in force onto one of the
+// Advanced Trade order_configuration variants.
+func coinbaseOrderConfiguration(order *pb.Order) (map[string]any, error) {
+	size := formatDecimal(order.QuantityRequested)
+	limit := formatDecimal(order.LimitPrice)
+	switch order.Type {
+	case pb.OrderType_MARKET:
+		return map[string]any{"market_market_ioc": map[string]any{"base_size": size}}, nil
+	case pb.OrderType_LIMIT:
+		switch order.TimeInForce {
+		case pb.TimeInForce_IOC:
+			return map[string]any{"sor_limit_ioc": map[string]any{"base_size": size, "limit_price": limit}}, nil
+		case pb.TimeInForce_FOK:
+			return map[string]any{"limit_limit_fok": map[string]any{"base_size": size, "limit_price": limit}}, nil
+		case pb.TimeInForce_GTD, pb.TimeInForce_DAY:
+			if order.ExpireAt == nil {
+				return nil, fmt.Errorf("%s order has no expiry", order.TimeInForce)
+			}
+			return map[string]any{"limit_limit_gtd": map[string]any{
+				"base_size":   size,
+				"limit_price": limit,
+				"end_time":    order.ExpireAt.AsTime().UTC().Format(time.RFC3339),
+				"post_only":   false,
+			}}, nil
+		default:
+			return map[string]any{"limit_limit_gtc": map[string]any{"base_size": size, "limit_price": limit, "post_only": false}}, nil
+		}
+	case pb.OrderType_STOP:
+		// Advanced Trade only supports stop-limit orders.
+		if order.LimitPrice == nil {
+			return nil, errors.New("stop orders on coinbase require a limit_price")
+		}
+		direction := "STOP_DIRECTION_STOP_DOWN"
+		if order.Side == pb.OrderSide_BUY {
+			direction = "STOP_DIRECTION_STOP_UP"
+		}
+		return map[string]any{"stop_limit_stop_limit_gtc": map[string]any{
+			"base_size":      size,
+			"limit_price":    limit,
+			"stop_price":     formatDecimal(order.StopPrice),
+			"stop_direction": direction,
+		}}, nil
+	}
+	return nil, fmt.Errorf("unsupported order type %s", order.Type)
+}
+
+func (v *coinbaseVenue) CancelOrder(ctx context.Context, order *pb.Order) error {
+	if order.VenueOrderId == "" {
+		return nil
+	}
+	var resp struct {
+		Results []struct {
+			Success       bool   `json:"success"`
+			FailureReason string `json:"failure_reason"`
+			OrderID       string `json:"order_id"`
+		} `json:"results"`
+	}
+	creds, err := v.keys.forBot(ctx, order.BotId)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{"order_ids": []string{order.VenueOrderId}}
+	if err := v.do(ctx, creds, http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", nil, body, &resp); err != nil {
+		return err
+	}
+	for _, r := range resp.Results {
+		if r.OrderID == order.VenueOrderId && !r.Success {
+			return fmt.Errorf("coinbase cancel failed: %s", r.FailureReason)
+		}
+	}
+	return nil
+}
+
+type coinbaseFill struct {
+	TradeID            string `json:"trade_id"`
+	OrderID            string `json:"order_id"`
+	ProductID          string `json:"product_id"`
+	Side               string `json:"side"`
+	Price              string `json:"price"`
+	Size               string `json:"size"`
+	Commission         string `json:"commission"`
+	LiquidityIndicator string `json:"liquidity_indicator"`
+	TradeTime          string `json:"trade_time"`
+}
+
+// Fills pages through historical fills since the given time for every account.
+// Coinbase returns newest first; the result is sorted oldest first.
+func (v *coinbaseVenue) Fills(ctx context.Context, since time.Time) ([]VenueFill, error) {
+	var out []VenueFill
+	err := v.eachAccount(ctx, "fills", func(creds coinbaseCredentials) error {
+		fills, err := v.accountFills(ctx, creds, since)
+		out = append(out, fills...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Ts.Before(out[j].Ts) })
+	return out, nil
+}
+
+// eachAccount calls fn for every account. An account that fails, for example because
+// its key was revoked, is logged and skipped so the other accounts are still polled
+// and reconciled. An error is returned only when no account succeeded.
+func (v *coinbaseVenue) eachAccount(ctx context.Context, what string, fn func(coinbaseCredentials) error) error {
+	accounts, err := v.keys.accounts(ctx)
+	if err != nil {
+		if len(accounts) == 0 {
+			return err
+		}
+		log.Warn().Err(err).Str("venue", v.Name()).Msg("skipping accounts whose credentials could not be read")
+	}
+	var errs []error
+	for _, creds := range accounts {
+		if err := fn(creds); err != nil {
+			log.Warn().Err(err).Str("venue", v.Name()).Str("account", creds.UserID).Msgf("skipping account: %s failed", what)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(accounts) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (v *coinbaseVenue) accountFills(ctx context.Context, creds coinbaseCredentials, since time.Time) ([]VenueFill, error) {
+	var out []VenueFill
+	cursor := ""
+	for {
+		q := url.Values{}
+		q.Set("start_sequence_timestamp", since.UTC().Format(time.RFC3339Nano))
+		q.Set("limit", "250")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		var resp struct {
+			Fills  []coinbaseFill `json:"fills"`
+			Cursor string         `json:"cursor"`
+		}
+		if err := v.do(ctx, creds, http.MethodGet, "/api/v3/brokerage/orders/historical/fills", q, nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, f := range resp.Fills {
+			out = append(out, f.toVenueFill(v.Name()))
+		}
+		if resp.Cursor == "" || len(resp.Fills) == 0 {
+			break
+		}
+		cursor = resp.Cursor
+	}
+	return out, nil
+}
+
+// OpenOrders lists orders that are open at Coinbase for every account.
+func (v *coinbaseVenue) OpenOrders(ctx context.Context) ([]VenueOpenOrder, error) {
+	var out []VenueOpenOrder
+	err := v.eachAccount(ctx, "open orders", func(creds coinbaseCredentials) error {
+		orders, err := v.accountOpenOrders(ctx, creds)
+		out = append(out, orders...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (v *coinbaseVenue) accountOpenOrders(ctx context.Context, creds coinbaseCredentials) ([]VenueOpenOrder, error) {
+	var out []VenueOpenOrder
+	cursor := ""
+	for {
+		q := url.Values{}
+		q.Set("order_status", "OPEN")
+		q.Set("limit", "250")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		var resp struct {
+			Orders []struct {
+				OrderID       string `json:"order_id"`
+				ClientOrderID string `json:"client_order_id"`
+				ProductID     string `json:"product_id"`
+				Side          string `json:"side"`
+				FilledSize    string `json:"filled_size"`
+			} `json:"orders"`
+			HasNext bool   `json:"has_next"`
+			Cursor  string `json:"cursor"`
+		}
+		if err := v.do(ctx, creds, http.MethodGet, "/api/v3/brokerage/orders/historical/batch", q, nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, o := range resp.Orders {
+			filled, _ := strconv.ParseFloat(o.FilledSize, 64)
+			out = append(out, VenueOpenOrder{
+				Account:       creds.UserID,
+				VenueOrderID:  o.OrderID,
+				ClientOrderID: o.ClientOrderID,
+				Symbol:        o.ProductID,
+				Side:          o.Side,
+				Filled:        filled,
+			})
+		}
+		if !resp.HasNext || resp.Cursor == "" {
+			break
+		}
+		cursor = resp.Cursor
+	}
+	return out, nil
+}
+
+// Balances lists the total (available plus on hold) balance per currency for every account.
+func (v *coinbaseVenue) Balances(ctx context.Context) ([]VenueBalance, error) {
+	var out []VenueBalance
+	err := v.eachAccount(ctx, "balances", func(creds coinbaseCredentials) error {
+		balances, err := v.accountBalances(ctx, creds)
+		out = append(out, balances...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (v *coinbaseVenue) accountBalances(ctx context.Context, creds coinbaseCredentials) ([]VenueBalance, error) {
+	type amount struct {
+		Value string `json:"value"`
+	}
+	var out []VenueBalance
+	cursor := ""
+	for {
+		q := url.Values{}
+		q.Set("limit", "250")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		var resp struct {
+			Accounts []struct {
+				Currency         string `json:"currency"`
+				AvailableBalance amount `json:"available_balance"`
+				Hold             amount `json:"hold"`
+			} `json:"accounts"`
+			HasNext bool   `json:"has_next"`
+			Cursor  string `json:"cursor"`
+		}
+		if err := v.do(ctx, creds, http.MethodGet, "/api/v3/brokerage/accounts", q, nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Accounts {
+			available, _ := strconv.ParseFloat(a.AvailableBalance.Value, 64)
+			hold, _ := strconv.ParseFloat(a.Hold.Value, 64)
+			out = append(out, VenueBalance{Account: creds.UserID, Currency: a.Currency, Total: available + hold})
+		}
+		if !resp.HasNext || resp.Cursor == "" {
+			break
+		}
+		cursor = resp.Cursor
+	}
+	return out, nil
+}
+
+func (f coinbaseFill) toVenueFill(venue string) VenueFill {
+	qty, _ := strconv.ParseFloat(f.Size, 64)
+	px, _ := strconv.ParseFloat(f.Price, 64)
+	fee, _ := strconv.ParseFloat(f.Commission, 64)
+	ts, _ := time.Parse(time.RFC3339Nano, f.TradeTime)
+	side := pb.OrderSide_BUY
+	if f.Side == "SELL" {
+		side = pb.OrderSide_SELL
+	}
+	return VenueFill{
+		Venue:        venue,
+		TradeID:      f.TradeID,
+		VenueOrderID: f.OrderID,
+		Symbol:       f.ProductID,
+		Side:         side,
+		Quantity:     qty,
+		Price:        px,
+		Commission:   fee,
+		Liquidity:    f.LiquidityIndicator,
+		Ts:           ts,
+	}
+}
+
+// do sends an authenticated request and decodes the JSON response into out.
+func (v *coinbaseVenue) do(ctx context.Context, creds coinbaseCredentials, method, path string, query url.Values, body, out any) error {
+	u, err := url.Parse(v.baseURL + path)
+	if err != nil {
+		return err
+	}
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	// The signed uri covers the path only, without the query string.
+	token, err := coinbaseJWT(creds, method, u.Host, u.Path, time.Now())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("coinbase %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// coinbaseJWT signs a short-lived ES256 token for a single request, as required by
+// Coinbase Developer Platform API keys.
+func coinbaseJWT(creds coinbaseCredentials, method, host, path string, now time.Time) (string, error) {
+	// Keys copied from the CDP portal or an env var often carry literal "\n" escapes.
+	pemKey := strings.ReplaceAll(creds.PrivateKey, `\n`, "\n")
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(pemKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid coinbase private key: %w", err)
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"iss": "cdp",
+		"sub": creds.KeyName,
+		"nbf": now.Unix(),
+		"exp": now.Add(2 * time.Minute).Unix(),
+		"uri": method + " " + host + path,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = creds.KeyName
+	token.Header["nonce"] = hex.EncodeToString(nonce)
+	return token.SignedString(key)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestCoinbaseVenuePlaceOrderSignsRequest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	creds := coinbaseCredentials{
+		KeyName: "organizations/org/apiKeys/key",
+		// Stored the way it usually arrives through an env var.
+		PrivateKey: strings.ReplaceAll(pemKey, "\n", `\n`),
+	}
+
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		token, err := jwt.Parse(raw, func(tok *jwt.Token) (any, error) { return &key.PublicKey, nil },
+			jwt.WithValidMethods([]string{"ES256"}))
+		if err != nil {
+			t.Errorf("invalid token: %v", err)
+		} else {
+			claims := token.Claims.(jwt.MapClaims)
+			if want := "POST " + r.Host + "/api/v3/brokerage/orders"; claims["uri"] != want {
+				t.Errorf("expected uri %q, got %v", want, claims["uri"])
+			}
+			if claims["sub"] != creds.KeyName || token.Header["kid"] != creds.KeyName {
+				t.Errorf("expected key name in sub and kid, got %v / %v", claims["sub"], token.Header["kid"])
+			}
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"success": true, "success_response": {"order_id": "cb-1", "client_order_id": "o-1"}}`))
+	}))
+	defer srv.Close()
+
+	venue := newCoinbaseVenue(srv.URL, staticCoinbaseKeyring{creds: creds})
+	ack, err := venue.PlaceOrder(context.Background(), &pb.Order{
+		Id:                "o-1",
+		Symbol:            "BTC-USD",
+		Side:              pb.OrderSide_BUY,
+		Type:              pb.OrderType_LIMIT,
+		TimeInForce:       pb.TimeInForce_GTC,
+		QuantityRequested: floatToDecimal(0.5),
+		LimitPrice:        floatToDecimal(30000),
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if ack.VenueOrderID != "cb-1" || ack.Status != pb.OrderStatus_SUBMITTED {
+		t.Errorf("unexpected ack %+v", ack)
+	}
+	cfg, _ := body["order_configuration"].(map[string]any)
+	limit, ok := cfg["limit_limit_gtc"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected limit_limit_gtc configuration, got %v", body["order_configuration"])
+	}
+	if limit["base_size"] != "0.5" || limit["limit_price"] != "30000" {
+		t.Errorf("unexpected sizes %v", limit)
+	}
+	if body["client_order_id"] != "o-1" {
+		t.Errorf("expected order id as client_order_id, got %v", body["client_order_id"])
+	}
+}
+
+func TestPaperExecutionPrice(t *testing.T) {
+	buyLimit := &pb.Order{Side: pb.OrderSide_BUY, Type: pb.OrderType_LIMIT, LimitPrice: floatToDecimal(100)}
+	if _, ok := paperExecutionPrice(buyLimit, 101); ok {
+		t.Error("buy limit should not fill above its limit")
+	}
+	if px, ok := paperExecutionPrice(buyLimit, 99); !ok || px != 99 {
+		t.Errorf("buy limit should fill at 99, got %v %v", px, ok)
+	}
+	sellStop := &pb.Order{Side: pb.OrderSide_SELL, Type: pb.OrderType_STOP, StopPrice: floatToDecimal(90)}
+	if _, ok := paperExecutionPrice(sellStop, 95); ok {
+		t.Error("sell stop should not trigger above its stop")
+	}
+	if _, ok := paperExecutionPrice(sellStop, 89); !ok {
+		t.Error("sell stop should trigger below its stop")
+	}
+}
+
+type fakeCoinbaseKeyring struct {
+	creds []coinbaseCredentials
+	err   error
+}
+
+func (k fakeCoinbaseKeyring) forBot(context.Context, string) (coinbaseCredentials, error) {
+	return k.creds[0], nil
+}
+
+func (k fakeCoinbaseKeyring) accounts(context.Context) ([]coinbaseCredentials, error) {
+	return k.creds, k.err
+}
+
+func TestCoinbaseVenueSkipsFailingAccounts(t *testing.T) {
+	newCreds := func(user string) coinbaseCredentials {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return coinbaseCredentials{KeyName: "key-" + user, PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), UserID: user}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		token, _, err := jwt.NewParser().ParseUnverified(raw, jwt.MapClaims{})
+		if err != nil || token.Claims.(jwt.MapClaims)["sub"] == "key-revoked" {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v3/brokerage/orders/historical/fills":
+			_, _ = w.Write([]byte(`{"fills": [{"trade_id": "f1", "order_id": "cb-1", "product_id": "BTC-USD", "side": "BUY", "price": "100", "size": "1", "trade_time": "2024-03-01T00:00:00Z"}]}`))
+		case "/api/v3/brokerage/orders/historical/batch":
+			_, _ = w.Write([]byte(`{"orders": [{"order_id": "cb-1", "product_id": "BTC-USD", "side": "BUY"}]}`))
+		case "/api/v3/brokerage/accounts":
+			_, _ = w.Write([]byte(`{"accounts": [{"currency": "BTC", "available_balance": {"value": "1"}, "hold": {"value": "0"}}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	keys := fakeCoinbaseKeyring{creds: []coinbaseCredentials{newCreds("revoked"), newCreds("alice")}, err: errors.New("credential for bob: cipher: message authentication failed")}
+	venue := newCoinbaseVenue(srv.URL, keys)
+	fills, err := venue.Fills(ctx, time.Time{})
+	if err != nil || len(fills) != 1 {
+		t.Errorf("expected alice's fill despite the revoked key, got %v, %v", fills, err)
+	}
+	orders, err := venue.OpenOrders(ctx)
+	if err != nil || len(orders) != 1 || orders[0].Account != "alice" {
+		t.Errorf("expected alice's open order, got %v, %v", orders, err)
+	}
+	balances, err := venue.Balances(ctx)
+	if err != nil || len(balances) != 1 || balances[0].Account != "alice" {
+		t.Errorf("expected alice's balance, got %v, %v", balances, err)
+	}
+
+	// With no account left there is nothing to compare, so the failure is returned
+	venue = newCoinbaseVenue(srv.URL, fakeCoinbaseKeyring{creds: []coinbaseCredentials{newCreds("revoked")}})
+	if _, err := venue.OpenOrders(ctx); err == nil {
+		t.Error("expected an error when every account fails")
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
@@ -14,12 +16,55 @@ type AppConfig struct {
 	HTTPHealthAddr      string
 	AuthSecret          string
 	AuthPreviousSecret  string
+	AccessTokenTTL      time.Duration
+	RefreshTokenTTL     time.Duration // sliding: each refresh issues a token valid this long
 	Env                 string
 	PostgresDSN         string
+	MigrateOnStart      bool // apply pending migrations instead of refusing to start
 	LogLevel            string
 	ShutdownGracePeriod time.Duration
 	RequestTimeout      time.Duration
+	StreamMaxPerUser    int           // concurrent server streams per caller
+	StreamIdleTimeout   time.Duration // close streams that send nothing for this long
 	DefaultSymbols      []string
+	// Optional Ed25519 signing; public keys are published at /.well-known/jwks.json
+	AuthEd25519PrivateKey        string // PKCS#8 PEM; when set, tokens are signed with it
+	AuthEd25519PreviousPublicKey string // PKIX PEM of the key being rotated out
+	// Two-factor and login throttling
+	MFARequiredRoles []string      // roles that must log in with TOTP
+	LoginMaxFailures int           // failed logins before a username is locked out
+	LoginLockout     time.Duration // how long a lockout lasts
+	// Peers whose x-forwarded-for is used as the client IP when throttling logins
+	TrustedProxies []netip.Prefix
+	// Account email
+	SMTPAddr     string // host:port; empty writes mail to MailDir or the log instead
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+	MailDir      string // where the development mailer writes .eml files
+	AppBaseURL   string // frontend URL used in mailed links
+	// Orders
+	DaySession          sessionBoundary // when DAY orders expire
+	OrderExpiryInterval time.Duration   // how often the expirer sweeps open orders
+	OrderEventRetention time.Duration   // how long journaled order updates stay resumable
+	// Execution
+	LiveTradingEnabled       bool   // route is_live bots to the exchange
+	CoinbaseAPIBaseURL       string // Advanced Trade REST endpoint
+	CoinbaseAPIKeyName       string
+	CoinbaseAPIPrivateKey    string
+	CoinbaseFillPollInterval time.Duration
+	CredentialsMasterKey     []byte // 32-byte key sealing stored exchange credentials
+	// Reconciliation against the live venue
+	ReconcileInterval     time.Duration
+	ReconcileAutoCorrect  bool          // import fills missing from the trades table
+	ReconcileFillLookback time.Duration // how far back venue fills are compared
+	// Bots
+	BotStoreFile               string        // keep bots in this JSON file, copied to Postgres
+	StrategyCheckpointInterval time.Duration // how often running strategies' state is saved
+	// Performance history
+	PerformanceSnapshotInterval time.Duration // how often bots' equity is recorded
+	// Metrics
+	MetricsAddr string // serves /debug/vars; binds to loopback unless set
 }
 
 func loadConfig() (*AppConfig, error) {
@@ -30,6 +75,7 @@ func loadConfig() (*AppConfig, error) {
 		PostgresDSN:    os.Getenv("POSTGRES_DSN"),
 		LogLevel:       getEnv("LOG_LEVEL", "info"),
 	}
+	cfg.MigrateOnStart, _ = strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	// Timeouts
 	rtStr := getEnv("REQUEST_TIMEOUT_MS", "5000")
 	if ms, err := strconv.Atoi(rtStr); err == nil && ms > 0 {
@@ -37,6 +83,16 @@ func loadConfig() (*AppConfig, error) {
 	} else {
 		cfg.RequestTimeout = 5 * time.Second
 	}
+	if n, err := strconv.Atoi(getEnv("STREAM_MAX_PER_USER", "16")); err == nil && n > 0 {
+		cfg.StreamMaxPerUser = n
+	} else {
+		cfg.StreamMaxPerUser = 16
+	}
+	if sec, err := strconv.Atoi(getEnv("STREAM_IDLE_TIMEOUT_SECONDS", "600")); err == nil && sec > 0 {
+		cfg.StreamIdleTimeout = time.Duration(sec) * time.Second
+	} else {
+		cfg.StreamIdleTimeout = 10 * time.Minute
+	}
 	gpStr := getEnv("SHUTDOWN_GRACE_SECONDS", "15")
 	if sec, err := strconv.Atoi(gpStr); err == nil && sec > 0 {
 		cfg.ShutdownGracePeriod = time.Duration(sec) * time.Second
@@ -44,12 +100,116 @@ func loadConfig() (*AppConfig, error) {
 		cfg.ShutdownGracePeriod = 15 * time.Second
 	}
 
+	// Order expiry: DAY orders expire at ORDER_DAY_SESSION_CLOSE (HH:MM) in ORDER_SESSION_TZ
+	session, err := parseSessionBoundary(getEnv("ORDER_DAY_SESSION_CLOSE", "00:00"), getEnv("ORDER_SESSION_TZ", "UTC"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.DaySession = session
+	if ms, err := strconv.Atoi(getEnv("ORDER_EXPIRY_INTERVAL_MS", "1000")); err == nil && ms > 0 {
+		cfg.OrderExpiryInterval = time.Duration(ms) * time.Millisecond
+	} else {
+		cfg.OrderExpiryInterval = time.Second
+	}
+	if h, err := strconv.Atoi(getEnv("ORDER_EVENT_RETENTION_HOURS", "168")); err == nil && h > 0 {
+		cfg.OrderEventRetention = time.Duration(h) * time.Hour
+	} else {
+		cfg.OrderEventRetention = 7 * 24 * time.Hour
+	}
+
+	// Live execution is off unless explicitly enabled; paper bots never need it
+	cfg.LiveTradingEnabled, _ = strconv.ParseBool(getEnv("LIVE_TRADING_ENABLED", "false"))
+	cfg.CoinbaseAPIBaseURL = getEnv("COINBASE_API_BASE_URL", "https://api.coinbase.com")
+	cfg.CoinbaseAPIKeyName = os.Getenv("COINBASE_API_KEY_NAME")
+	cfg.CoinbaseAPIPrivateKey = os.Getenv("COINBASE_API_PRIVATE_KEY")
+	if ms, err := strconv.Atoi(getEnv("COINBASE_FILL_POLL_INTERVAL_MS", "2000")); err == nil && ms > 0 {
+		cfg.CoinbaseFillPollInterval = time.Duration(ms) * time.Millisecond
+	} else {
+		cfg.CoinbaseFillPollInterval = 2 * time.Second
+	}
+
+	if sec, err := strconv.Atoi(getEnv("RECONCILE_INTERVAL_SECONDS", "60")); err == nil && sec > 0 {
+		cfg.ReconcileInterval = time.Duration(sec) * time.Second
+	} else {
+		cfg.ReconcileInterval = time.Minute
+	}
+	cfg.ReconcileAutoCorrect, _ = strconv.ParseBool(getEnv("RECONCILE_AUTO_CORRECT", "false"))
+	if h, err := strconv.Atoi(getEnv("RECONCILE_FILL_LOOKBACK_HOURS", "24")); err == nil && h > 0 {
+		cfg.ReconcileFillLookback = time.Duration(h) * time.Hour
+	} else {
+		cfg.ReconcileFillLookback = 24 * time.Hour
+	}
+	// expvar includes the command line and memory stats, so it is not served publicly
+	cfg.MetricsAddr = getEnv("METRICS_ADDR", "127.0.0.1:8091")
+
+	// CREDENTIALS_MASTER_KEY is base64 (standard encoding) of 32 random bytes
+	if raw := os.Getenv("CREDENTIALS_MASTER_KEY"); raw != "" {
+		key, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("CREDENTIALS_MASTER_KEY must be base64 of 32 bytes")
+		}
+		cfg.CredentialsMasterKey = key
+	}
+
 	// Secrets
 	cfg.AuthSecret = os.Getenv("AUTH_SECRET")
 	if cfg.AuthSecret == "" && cfg.Env != "production" {
 		// ephemeral dev secret created in main if empty; validation deferred
 	}
 	cfg.AuthPreviousSecret = os.Getenv("AUTH_PREVIOUS_SECRET")
+	cfg.AuthEd25519PrivateKey = os.Getenv("AUTH_ED25519_PRIVATE_KEY")
+	cfg.AuthEd25519PreviousPublicKey = os.Getenv("AUTH_ED25519_PREVIOUS_PUBLIC_KEY")
+	// MFA_REQUIRED_ROLES is comma-separated; "none" requires two-factor of nobody
+	for _, r := range strings.Split(getEnv("MFA_REQUIRED_ROLES", roleAdmin), ",") {
+		if r = strings.TrimSpace(r); r != "" && r != "none" {
+			cfg.MFARequiredRoles = append(cfg.MFARequiredRoles, r)
+		}
+	}
+	if n, err := strconv.Atoi(getEnv("LOGIN_MAX_FAILURES", "10")); err == nil && n > 0 {
+		cfg.LoginMaxFailures = n
+	} else {
+		cfg.LoginMaxFailures = defaultLoginMaxFailures
+	}
+	if m, err := strconv.Atoi(getEnv("LOGIN_LOCKOUT_MINUTES", "15")); err == nil && m > 0 {
+		cfg.LoginLockout = time.Duration(m) * time.Minute
+	} else {
+		cfg.LoginLockout = defaultLoginLockout
+	}
+	// TRUSTED_PROXY_CIDRS is comma-separated; other peers' x-forwarded-for is ignored
+	if raw := os.Getenv("TRUSTED_PROXY_CIDRS"); raw != "" {
+		proxies, err := parseAllowedIPs(strings.Split(raw, ","))
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXY_CIDRS: %w", err)
+		}
+		cfg.TrustedProxies = proxies
+	}
+	cfg.SMTPAddr = os.Getenv("SMTP_ADDR")
+	cfg.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	cfg.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	cfg.MailFrom = getEnv("MAIL_FROM", "Aetherion <no-reply@aetherion.local>")
+	cfg.MailDir = os.Getenv("MAIL_DIR")
+	cfg.AppBaseURL = getEnv("APP_BASE_URL", "http://localhost:3000")
+	cfg.BotStoreFile = os.Getenv("BOT_STORE_FILE")
+	if sec, err := strconv.Atoi(getEnv("STRATEGY_CHECKPOINT_INTERVAL_SECONDS", "30")); err == nil && sec > 0 {
+		cfg.StrategyCheckpointInterval = time.Duration(sec) * time.Second
+	} else {
+		cfg.StrategyCheckpointInterval = 30 * time.Second
+	}
+	if sec, err := strconv.Atoi(getEnv("PERFORMANCE_SNAPSHOT_INTERVAL_SECONDS", "300")); err == nil && sec > 0 {
+		cfg.PerformanceSnapshotInterval = time.Duration(sec) * time.Second
+	} else {
+		cfg.PerformanceSnapshotInterval = 5 * time.Minute
+	}
+	if m, err := strconv.Atoi(getEnv("ACCESS_TOKEN_TTL_MINUTES", "60")); err == nil && m > 0 {
+		cfg.AccessTokenTTL = time.Duration(m) * time.Minute
+	} else {
+		cfg.AccessTokenTTL = defaultAccessTokenTTL
+	}
+	if h, err := strconv.Atoi(getEnv("REFRESH_TOKEN_TTL_HOURS", "720")); err == nil && h > 0 {
+		cfg.RefreshTokenTTL = time.Duration(h) * time.Hour
+	} else {
+		cfg.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
 
 	// Symbols (comma-separated)
 	rawSyms := os.Getenv("DEFAULT_SYMBOLS")
@@ -75,6 +235,10 @@ func (c *AppConfig) validate() error {
 			return fmt.Errorf("AUTH_SECRET must be set and >=32 chars in production")
 		}
 	}
+	hasPlatformKey := c.CoinbaseAPIKeyName != "" && c.CoinbaseAPIPrivateKey != ""
+	if c.LiveTradingEnabled && !hasPlatformKey && len(c.CredentialsMasterKey) == 0 {
+		return fmt.Errorf("LIVE_TRADING_ENABLED requires CREDENTIALS_MASTER_KEY or COINBASE_API_KEY_NAME and COINBASE_API_PRIVATE_KEY")
+	}
 	return nil
 }
 
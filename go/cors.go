@@ -54,6 +54,7 @@ func corsMiddleware() *cors.Cors {
 			"grpc-encoding",
 			"grpc-accept-encoding",
 			"grpc-message",
+			"Content-Disposition", // export download filenames
 		},
 	})
 }
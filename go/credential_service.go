@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	pb "aetherion/gen"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// credentialServiceServer manages the caller's exchange API keys. Secrets go in
+// through Add and Rotate and never come back out.
+type credentialServiceServer struct {
+	pb.UnimplementedCredentialServiceServer
+	vault *credentialVault // nil when no master key is configured
+}
+
+func newCredentialServiceServer(vault *credentialVault) *credentialServiceServer {
+	return &credentialServiceServer{vault: vault}
+}
+
+// validateVenueSecret checks a secret is usable with its venue before it is stored.
+func validateVenueSecret(venue string, secret venueSecret) error {
+	if secret.APIKey == "" || secret.APISecret == "" {
+		return errors.New("api_key and api_secret are required")
+	}
+	switch venue {
+	case "coinbase":
+		pemKey := strings.ReplaceAll(secret.APISecret, `\n`, "\n")
+		if _, err := jwt.ParseECPrivateKeyFromPEM([]byte(pemKey)); err != nil {
+			return errors.New("api_secret must be a PEM-encoded EC private key")
+		}
+		return nil
+	}
+	return errors.New("unsupported venue " + venue)
+}
+
+func (s *credentialServiceServer) caller(ctx context.Context) (string, error) {
+	if s.vault == nil {
+		return "", status.Error(codes.FailedPrecondition, "credential storage is not configured")
+	}
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return "", status.Error(codes.Unauthenticated, "auth required")
+	}
+	return c.UserID, nil
+}
+
+func credentialToProto(c *storedCredential) *pb.VenueCredential {
+	out := &pb.VenueCredential{
+		Id:           c.ID,
+		Venue:        c.Venue,
+		Label:        c.Label,
+		ApiKeyMasked: c.APIKeyMasked,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+	}
+	if c.RotatedAt != nil {
+		out.RotatedAt = timestamppb.New(*c.RotatedAt)
+	}
+	return out
+}
+
+func (s *credentialServiceServer) AddCredential(ctx context.Context, req *pb.AddCredentialRequest) (*pb.VenueCredential, error) {
+	userID, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret := venueSecret{APIKey: req.ApiKey, APISecret: req.ApiSecret}
+	if err := validateVenueSecret(req.Venue, secret); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	c, err := s.vault.Put(ctx, userID, req.Venue, req.Label, secret)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to store credential")
+	}
+	return credentialToProto(c), nil
+}
+
+func (s *credentialServiceServer) ListCredentials(ctx context.Context, req *pb.ListCredentialsRequest) (*pb.ListCredentialsResponse, error) {
+	userID, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.vault.db.ListCredentials(ctx, userID, req.Venue)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list credentials")
+	}
+	resp := &pb.ListCredentialsResponse{}
+	for _, c := range stored {
+		resp.Credentials = append(resp.Credentials, credentialToProto(c))
+	}
+	return resp, nil
+}
+
+func (s *credentialServiceServer) RotateCredential(ctx context.Context, req *pb.RotateCredentialRequest) (*pb.VenueCredential, error) {
+	userID, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.findCredential(ctx, userID, req.CredentialId)
+	if err != nil {
+		return nil, err
+	}
+	secret := venueSecret{APIKey: req.ApiKey, APISecret: req.ApiSecret}
+	if err := validateVenueSecret(existing.Venue, secret); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	c, err := s.vault.Rotate(ctx, userID, req.CredentialId, secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "credential not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to rotate credential")
+	}
+	return credentialToProto(c), nil
+}
+
+func (s *credentialServiceServer) DeleteCredential(ctx context.Context, req *pb.DeleteCredentialRequest) (*pb.StatusResponse, error) {
+	userID, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.vault.db.DeleteCredential(ctx, userID, req.CredentialId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "credential not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete credential")
+	}
+	return &pb.StatusResponse{Success: true, Message: "credential deleted", Id: req.CredentialId}, nil
+}
+
+func (s *credentialServiceServer) findCredential(ctx context.Context, userID, id string) (*storedCredential, error) {
+	stored, err := s.vault.db.ListCredentials(ctx, userID, "")
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to load credential")
+	}
+	for _, c := range stored {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "credential not found")
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// venueSecret is the decrypted form of a stored exchange credential.
+type venueSecret struct {
+	UserID    string `json:"-"`
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+}
+
+// credentialVault stores exchange credentials with envelope encryption. Each
+// credential is sealed with its own random data key; the data key is sealed with the
+// master key and stored next to it. Both layers use AES-256-GCM with the credential
+// and user ids as additional data, so a sealed secret cannot be moved to another row.
+type credentialVault struct {
+	db     *DBService
+	master cipher.AEAD
+}
+
+func newCredentialVault(db *DBService, masterKey []byte) (*credentialVault, error) {
+	master, err := newGCM(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("credential master key: %w", err)
+	}
+	return &credentialVault{db: db, master: master}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// gcmSeal encrypts plaintext and prepends the random nonce.
+func gcmSeal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func gcmOpen(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed value too short")
+	}
+	nonce, ct := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ct, aad)
+}
+
+func credentialAAD(id, userID string) []byte {
+	return []byte(id + "/" + userID)
+}
+
+// seal encrypts a secret for the credential row (id, userID).
+func (v *credentialVault) seal(id, userID string, secret venueSecret) (ciphertext, wrappedKey []byte, err error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := json.Marshal(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	aad := credentialAAD(id, userID)
+	if ciphertext, err = gcmSeal(aead, plaintext, aad); err != nil {
+		return nil, nil, err
+	}
+	if wrappedKey, err = gcmSeal(v.master, dataKey, aad); err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, wrappedKey, nil
+}
+
+// open decrypts a stored credential.
+func (v *credentialVault) open(c *storedCredential) (venueSecret, error) {
+	aad := credentialAAD(c.ID, c.UserID)
+	dataKey, err := gcmOpen(v.master, c.WrappedKey, aad)
+	if err != nil {
+		return venueSecret{}, fmt.Errorf("unwrap data key for credential %s: %w", c.ID, err)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return venueSecret{}, err
+	}
+	plaintext, err := gcmOpen(aead, c.Ciphertext, aad)
+	if err != nil {
+		return venueSecret{}, fmt.Errorf("decrypt credential %s: %w", c.ID, err)
+	}
+	var secret venueSecret
+	if err := json.Unmarshal(plaintext, &secret); err != nil {
+		return venueSecret{}, err
+	}
+	secret.UserID = c.UserID
+	return secret, nil
+}
+
+// Put seals and stores a new credential.
+func (v *credentialVault) Put(ctx context.Context, userID, venue, label string, secret venueSecret) (*storedCredential, error) {
+	c := &storedCredential{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Venue:        venue,
+		Label:        label,
+		APIKeyMasked: maskAPIKey(secret.APIKey),
+	}
+	var err error
+	if c.Ciphertext, c.WrappedKey, err = v.seal(c.ID, userID, secret); err != nil {
+		return nil, err
+	}
+	if err := v.db.InsertCredential(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Rotate replaces the secret of an existing credential, keeping its id.
+func (v *credentialVault) Rotate(ctx context.Context, userID, id string, secret venueSecret) (*storedCredential, error) {
+	ciphertext, wrappedKey, err := v.seal(id, userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	return v.db.RotateCredential(ctx, userID, id, maskAPIKey(secret.APIKey), ciphertext, wrappedKey)
+}
+
+// Decrypt returns the user's current secret for a venue. Only execution adapters call
+// this; no RPC returns decrypted secrets.
+func (v *credentialVault) Decrypt(ctx context.Context, userID, venue string) (venueSecret, error) {
+	c, err := v.db.LatestCredential(ctx, userID, venue)
+	if err != nil {
+		return venueSecret{}, err
+	}
+	return v.open(c)
+}
+
+// DecryptAll returns every user's current secret for a venue. Credentials that fail to
+// decrypt are skipped and reported in the returned error.
+func (v *credentialVault) DecryptAll(ctx context.Context, venue string) ([]venueSecret, error) {
+	stored, err := v.db.ListLatestCredentialsByVenue(ctx, venue)
+	if err != nil {
+		return nil, err
+	}
+	var out []venueSecret
+	var errs []error
+	for _, c := range stored {
+		secret, err := v.open(c)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out = append(out, secret)
+	}
+	return out, errors.Join(errs...)
+}
+
+// maskAPIKey keeps only the last four characters of a key for display.
+func maskAPIKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -2,213 +2,1486 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	pb "aetherion/gen"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// errDuplicateClientOrderID is returned when an order or trade reuses a client_order_id
+// that its bot has already submitted.
+var errDuplicateClientOrderID = errors.New("duplicate client_order_id")
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 // DBService provides methods for interacting with the PostgreSQL database.
 type DBService struct {
 	pool *pgxpool.Pool
 }
 
-// NewDBService creates a new DBService instance.
-func NewDBService(connStr string) (*DBService, error) {
-	config, err := pgxpool.ParseConfig(connStr)
+// NewDBService creates a new DBService instance.
+func NewDBService(connStr string) (*DBService, error) {
+	config, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to parse DB connection string")
+		return nil, fmt.Errorf("failed to parse DB connection string: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create DB connection pool")
+		return nil, fmt.Errorf("failed to create DB connection pool: %w", err)
+	}
+
+	// Ping the database to verify the connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	log.Info().Msg("Successfully connected to PostgreSQL database.")
+	return &DBService{pool: pool}, nil
+}
+
+// Close closes the database connection pool.
+func (s *DBService) Close() {
+	s.pool.Close()
+	log.Info().Msg("Database connection pool closed.")
+}
+
+// --------------------- //
+// - Order Management -- //
+// --------------------- //
+
+// CREATE TABLE IF NOT EXISTS orders (
+//
+//	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+//	bot_id UUID REFERENCES bots(id) ON DELETE CASCADE,
+//	symbol TEXT NOT NULL,
+//	side TEXT NOT NULL, -- Use 'BUY' or 'SELL'
+//	type TEXT NOT NULL, -- Use 'MARKET', 'LIMIT', 'STOP'
+//	status TEXT NOT NULL, -- Use 'NEW', 'SUBMITTED', etc.
+//	quantity_requested NUMERIC(20, 8) NOT NULL,
+//	quantity_filled NUMERIC(20, 8) DEFAULT 0,
+//	limit_price NUMERIC(20, 8),
+//	stop_price NUMERIC(20, 8),
+//	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+//	updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+//	time_in_force TEXT NOT NULL DEFAULT 'GTC', -- 002_order_time_in_force.sql
+//	expire_at TIMESTAMP WITH TIME ZONE,
+//	client_order_id TEXT, -- 003_client_order_ids.sql, unique per bot
+//	venue TEXT, -- 005_execution_venues.sql
+//	venue_order_id TEXT
+//
+// );
+//
+// CreateOrder returns errDuplicateClientOrderID if the bot already has an order with
+// the same client_order_id.
+func (s *DBService) CreateOrder(ctx context.Context, order *pb.Order) (string, error) {
+	var returnedId string
+	var expireAt *time.Time
+	if order.ExpireAt != nil {
+		t := order.ExpireAt.AsTime()
+		expireAt = &t
+	}
+	var clientOrderID *string
+	if order.ClientOrderId != "" {
+		clientOrderID = &order.ClientOrderId
+	}
+	query := `INSERT INTO orders (id, bot_id, symbol, side, type, status, quantity_requested, quantity_filled, limit_price, stop_price, time_in_force, expire_at, client_order_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+        RETURNING id`
+	err := s.pool.QueryRow(ctx, query,
+		order.Id,
+		order.BotId,
+		order.Symbol,
+		order.Side.String(),
+		order.Type.String(),
+		order.Status.String(),
+		decimalValueToNumeric(order.QuantityRequested),
+		decimalValueToNumeric(order.QuantityFilled),
+		decimalValueToNumeric(order.LimitPrice),
+		decimalValueToNumeric(order.StopPrice),
+		order.TimeInForce.String(),
+		expireAt,
+		clientOrderID,
+	).Scan(&returnedId)
+	if err != nil {
+		if isUniqueViolation(err) && clientOrderID != nil {
+			return "", errDuplicateClientOrderID
+		}
+		log.Error().Err(err).Msg("Failed to create order")
+		return "", fmt.Errorf("failed to create order: %w", err)
+	}
+	log.Info().Str("order_id", returnedId).Msg("Order created successfully")
+	return returnedId, nil
+}
+
+// orderColumns is the column list shared by every query that returns orders; keep it in sync with scanOrder.
+const orderColumns = `id, bot_id, symbol, side, type, status, quantity_requested::text, quantity_filled::text, limit_price::text, stop_price::text, time_in_force, expire_at, COALESCE(client_order_id, ''), COALESCE(venue, ''), COALESCE(venue_order_id, ''), created_at, updated_at`
+
+// scanOrder converts a row selected with orderColumns into a pb.Order.
+func scanOrder(row pgx.Row) (*pb.Order, error) {
+	var order pb.Order
+	var sideStr, typeStr, statusStr, tifStr string
+	var quantityRequestedStr, quantityFilledStr string
+	var limitPriceStr, stopPriceStr *string
+	var expireAt *time.Time
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(
+		&order.Id,
+		&order.BotId,
+		&order.Symbol,
+		&sideStr,
+		&typeStr,
+		&statusStr,
+		&quantityRequestedStr,
+		&quantityFilledStr,
+		&limitPriceStr,
+		&stopPriceStr,
+		&tifStr,
+		&expireAt,
+		&order.ClientOrderId,
+		&order.Venue,
+		&order.VenueOrderId,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	// Convert string to enum
+	order.Side = pb.OrderSide(pb.OrderSide_value[sideStr])
+	order.Type = pb.OrderType(pb.OrderType_value[typeStr])
+	order.Status = pb.OrderStatus(pb.OrderStatus_value[statusStr])
+	order.TimeInForce = pb.TimeInForce(pb.TimeInForce_value[tifStr])
+
+	// Convert numeric strings to DecimalValue
+	order.QuantityRequested = numericValueToDecimal(quantityRequestedStr)
+	order.QuantityFilled = numericValueToDecimal(quantityFilledStr)
+	if limitPriceStr != nil {
+		order.LimitPrice = numericValueToDecimal(*limitPriceStr)
+	}
+	if stopPriceStr != nil {
+		order.StopPrice = numericValueToDecimal(*stopPriceStr)
+	}
+
+	// Convert timestamps
+	if expireAt != nil {
+		order.ExpireAt = timestamppb.New(*expireAt)
+	}
+	order.CreatedAt = timestamppb.New(createdAt)
+	order.UpdatedAt = timestamppb.New(updatedAt)
+	return &order, nil
+}
+
+func (s *DBService) GetOrder(ctx context.Context, orderID string) (*pb.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+	order, err := scanOrder(s.pool.QueryRow(ctx, query, orderID))
+	if err != nil {
+		log.Error().Err(err).Str("order_id", orderID).Msg("Failed to get order")
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return order, nil
+}
+
+// GetOrderByClientOrderID looks up a bot's order by the client-assigned id. It returns
+// a wrapped pgx.ErrNoRows when there is no such order.
+func (s *DBService) GetOrderByClientOrderID(ctx context.Context, botID, clientOrderID string) (*pb.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE bot_id = $1 AND client_order_id = $2`
+	order, err := scanOrder(s.pool.QueryRow(ctx, query, botID, clientOrderID))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error().Err(err).Str("bot_id", botID).Str("client_order_id", clientOrderID).Msg("Failed to get order by client order id")
+		}
+		return nil, fmt.Errorf("failed to get order by client order id: %w", err)
+	}
+	return order, nil
+}
+
+func (s *DBService) ListOrders(ctx context.Context, botID string, limit, offset int32) ([]*pb.Order, error) {
+	// If botID == "" then return because it can't be empty.
+	if botID == "" {
+		return nil, fmt.Errorf("botID cannot be empty")
+	}
+	var orders []*pb.Order
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE bot_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	rows, err := s.pool.Query(ctx, query, botID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Str("bot_id", botID).Msg("Failed to list orders")
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan order")
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to iterate orders")
+		return nil, fmt.Errorf("failed to iterate orders: %w", err)
+	}
+	return orders, nil
+}
+
+// ListOrdersSince returns up to limit orders matching f by creation time, oldest first,
+// starting after the cursor when one is given.
+func (s *DBService) ListOrdersSince(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*pb.Order, error) {
+	query, args := keysetQuery(orderColumns, "orders", "created_at", f, after, false, limit)
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+	var orders []*pb.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// UpdateOrderStatus moves an open order to the given status. It returns pgx.ErrNoRows
+// (wrapped) when the order does not exist or has already reached a terminal status.
+func (s *DBService) UpdateOrderStatus(ctx context.Context, orderID string, status pb.OrderStatus) (*pb.Order, error) {
+	query := `UPDATE orders SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED')
+		RETURNING ` + orderColumns
+	order, err := scanOrder(s.pool.QueryRow(ctx, query, orderID, status.String()))
+	if err != nil {
+		log.Error().Err(err).Str("order_id", orderID).Msg("Failed to update order status")
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+	return order, nil
+}
+
+// SetOrderVenue records where an open order was routed and the status the venue
+// acknowledged. It returns pgx.ErrNoRows (wrapped) when the order does not exist or has
+// already reached a terminal status, e.g. it expired or was canceled while the venue
+// was acknowledging it.
+func (s *DBService) SetOrderVenue(ctx context.Context, orderID, venue, venueOrderID string, status pb.OrderStatus) (*pb.Order, error) {
+	query := `UPDATE orders SET venue = $2, venue_order_id = NULLIF($3, ''), status = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED')
+		RETURNING ` + orderColumns
+	order, err := scanOrder(s.pool.QueryRow(ctx, query, orderID, venue, venueOrderID, status.String()))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error().Err(err).Str("order_id", orderID).Msg("Failed to set order venue")
+		}
+		return nil, fmt.Errorf("failed to set order venue: %w", err)
+	}
+	return order, nil
+}
+
+// GetOrderByVenueOrderID looks up an order by the id its venue assigned. It returns a
+// wrapped pgx.ErrNoRows when there is no such order.
+func (s *DBService) GetOrderByVenueOrderID(ctx context.Context, venue, venueOrderID string) (*pb.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE venue = $1 AND venue_order_id = $2`
+	order, err := scanOrder(s.pool.QueryRow(ctx, query, venue, venueOrderID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order by venue order id: %w", err)
+	}
+	return order, nil
+}
+
+// errFillRecorded is returned by RecordFill for a trade that was already recorded.
+// The trade and its order update commit together, so the order already includes it.
+var errFillRecorded = errors.New("fill already recorded")
+
+// RecordFill records an execution of orderID as a trade and adds its quantity to the
+// order in one transaction. Open orders become FILLED or PARTIALLY_FILLED; an order
+// that already left the book keeps its status, since a venue can report fills that
+// raced a cancel or expiry. It returns errFillRecorded if the trade id exists.
+func (s *DBService) RecordFill(ctx context.Context, orderID string, trade *pb.Trade) (*pb.Order, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	executedAt, args := tradeInsertArgs(trade)
+	tag, err := tx.Exec(ctx, insertTradeQuery+` ON CONFLICT (id) DO NOTHING`, args...)
+	if err != nil {
+		log.Error().Err(err).Str("order_id", orderID).Msg("Failed to record fill")
+		return nil, fmt.Errorf("failed to record fill: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errFillRecorded
+	}
+	query := `UPDATE orders SET
+			quantity_filled = quantity_filled + $2,
+			status = CASE
+				WHEN status NOT IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED') THEN status
+				WHEN quantity_filled + $2 >= quantity_requested THEN 'FILLED'
+				ELSE 'PARTIALLY_FILLED'
+			END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 RETURNING ` + orderColumns
+	order, err := scanOrder(tx.QueryRow(ctx, query, orderID, trade.Quantity))
+	if err != nil {
+		log.Error().Err(err).Str("order_id", orderID).Msg("Failed to apply order fill")
+		return nil, fmt.Errorf("failed to apply order fill: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	trade.ExecutedAt = executedAt.UnixNano()
+	trade.ExecutedAtTimestamp = timestamppb.New(executedAt)
+	return order, nil
+}
+
+// ListOpenVenueOrders returns open orders that were routed to a venue.
+func (s *DBService) ListOpenVenueOrders(ctx context.Context, venue string) ([]*pb.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders
+		WHERE venue = $1 AND status IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED')`
+	rows, err := s.pool.Query(ctx, query, venue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open venue orders: %w", err)
+	}
+	defer rows.Close()
+	var orders []*pb.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+// ExpireOrders marks every open order whose expire_at is at or before asOf as EXPIRED
+// and returns the orders it transitioned.
+func (s *DBService) ExpireOrders(ctx context.Context, asOf time.Time) ([]*pb.Order, error) {
+	query := `UPDATE orders SET status = 'EXPIRED', updated_at = CURRENT_TIMESTAMP
+		WHERE status IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED') AND expire_at IS NOT NULL AND expire_at <= $1
+		RETURNING ` + orderColumns
+	rows, err := s.pool.Query(ctx, query, asOf)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to expire orders")
+		return nil, fmt.Errorf("failed to expire orders: %w", err)
+	}
+	defer rows.Close()
+	var orders []*pb.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan expired order")
+			return nil, fmt.Errorf("failed to scan expired order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expired orders: %w", err)
+	}
+	return orders, nil
+}
+
+// CREATE TABLE IF NOT EXISTS order_events ( -- 004_order_events.sql
+//
+//	sequence BIGSERIAL PRIMARY KEY,
+//	bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+//	order_id UUID NOT NULL,
+//	event_type TEXT NOT NULL,
+//	payload BYTEA NOT NULL, -- serialized pb.OrderUpdate
+//	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+//
+// );
+//
+// AppendOrderEvent journals an order update and returns its sequence number.
+func (s *DBService) AppendOrderEvent(ctx context.Context, update *pb.OrderUpdate) (int64, error) {
+	payload, err := proto.Marshal(update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal order event: %w", err)
+	}
+	var seq int64
+	query := `INSERT INTO order_events (bot_id, order_id, event_type, payload) VALUES ($1, $2, $3, $4) RETURNING sequence`
+	err = s.pool.QueryRow(ctx, query, update.Order.GetBotId(), update.Order.GetId(), update.Type.String(), payload).Scan(&seq)
+	if err != nil {
+		log.Error().Err(err).Str("order_id", update.Order.GetId()).Msg("Failed to append order event")
+		return 0, fmt.Errorf("failed to append order event: %w", err)
+	}
+	return seq, nil
+}
+
+// ListOrderEventsAfter returns up to limit journaled updates for a bot with a sequence
+// greater than afterSeq, oldest first.
+func (s *DBService) ListOrderEventsAfter(ctx context.Context, botID string, afterSeq int64, limit int) ([]*pb.OrderUpdate, error) {
+	query := `SELECT sequence, payload FROM order_events WHERE bot_id = $1 AND sequence > $2 ORDER BY sequence LIMIT $3`
+	rows, err := s.pool.Query(ctx, query, botID, afterSeq, limit)
+	if err != nil {
+		log.Error().Err(err).Str("bot_id", botID).Msg("Failed to list order events")
+		return nil, fmt.Errorf("failed to list order events: %w", err)
+	}
+	defer rows.Close()
+	var updates []*pb.OrderUpdate
+	for rows.Next() {
+		var seq int64
+		var payload []byte
+		if err := rows.Scan(&seq, &payload); err != nil {
+			return nil, fmt.Errorf("failed to scan order event: %w", err)
+		}
+		var update pb.OrderUpdate
+		if err := proto.Unmarshal(payload, &update); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal order event %d: %w", seq, err)
+		}
+		update.Sequence = seq
+		updates = append(updates, &update)
+	}
+	return updates, rows.Err()
+}
+
+// PruneOrderEvents deletes journaled updates created before the cutoff.
+func (s *DBService) PruneOrderEvents(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM order_events WHERE created_at < $1`, before)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to prune order events")
+		return 0, fmt.Errorf("failed to prune order events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ----------------------
+// --- Bot Management ---
+// ----------------------
+
+const botColumns = `id::text, user_id::text, name, symbol, strategy, parameters, is_active, created_at, updated_at,
+	COALESCE(account_value, 0)::float8, COALESCE(is_live, FALSE), description, strategy_status, status_reason`
+
+// ListBots returns every bot, oldest first. It is the BotRepository read the bot
+// registry loads at startup.
+func (s *DBService) ListBots(ctx context.Context) ([]*pb.Bot, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+botColumns+` FROM bots ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bots: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.Bot
+	for rows.Next() {
+		var b pb.Bot
+		var created, updated time.Time
+		if err := rows.Scan(&b.BotId, &b.UserId, &b.Name, &b.Symbol, &b.Strategy, &b.Parameters, &b.IsActive, &created, &updated,
+			&b.AccountValue, &b.IsLive, &b.Description, &b.StrategyStatus, &b.StatusReason); err != nil {
+			return nil, fmt.Errorf("failed to scan bot: %w", err)
+		}
+		b.CreatedAtUnixMs = created.UnixMilli()
+		b.CreatedAt = timestamppb.New(created)
+		b.UpdatedAt = timestamppb.New(updated)
+		out = append(out, &b)
+	}
+	return out, rows.Err()
+}
+
+func (s *DBService) CreateBot(ctx context.Context, bot *pb.Bot) error {
+	query := `INSERT INTO bots (id, user_id, name, symbol, strategy, parameters, is_active, account_value, is_live, description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING created_at`
+	var created time.Time
+	err := s.pool.QueryRow(ctx, query, bot.BotId, bot.UserId, bot.Name, bot.Symbol, bot.Strategy, bot.Parameters, bot.IsActive, bot.AccountValue, bot.IsLive, bot.Description).Scan(&created)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create bot")
+		return fmt.Errorf("failed to create bot: %w", err)
+	}
+	bot.CreatedAtUnixMs = created.UnixMilli()
+	bot.CreatedAt = timestamppb.New(created)
+	log.Info().Str("bot_id", bot.BotId).Msg("Bot created successfully")
+	return nil
+}
+
+// DeleteBot deletes a bot, or returns errBotNotFound.
+func (s *DBService) DeleteBot(ctx context.Context, botID string) error {
+	query := `DELETE FROM bots WHERE id = $1`
+	tag, err := s.pool.Exec(ctx, query, botID)
+	if err != nil {
+		log.Error().Err(err).Str("bot_id", botID).Msg("Failed to delete bot")
+		return fmt.Errorf("failed to delete bot: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errBotNotFound
+	}
+	log.Info().Str("bot_id", botID).Msg("Bot deleted successfully")
+	return nil
+}
+
+// UpdateBot persists a bot's editable fields and run state, or returns errBotNotFound.
+func (s *DBService) UpdateBot(ctx context.Context, bot *pb.Bot) error {
+	query := `UPDATE bots SET name = $2, description = $3, parameters = $4, is_active = $5, strategy_status = $6, status_reason = $7,
+		updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	tag, err := s.pool.Exec(ctx, query, bot.BotId, bot.Name, bot.Description, bot.Parameters, bot.IsActive, bot.StrategyStatus, bot.StatusReason)
+	if err != nil {
+		log.Error().Err(err).Str("bot_id", bot.BotId).Msg("Failed to update bot")
+		return fmt.Errorf("failed to update bot: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errBotNotFound
+	}
+	return nil
+}
+
+// UpsertBot stores a whole bot, inserting it or replacing the row with the same id.
+// mirroredBotRepository uses it to keep file-stored bots in the bots table.
+func (s *DBService) UpsertBot(ctx context.Context, bot *pb.Bot) error {
+	created := time.Now()
+	if bot.CreatedAt != nil {
+		created = bot.CreatedAt.AsTime()
+	}
+	params := bot.Parameters
+	if params == nil {
+		params = map[string]string{}
+	}
+	query := `INSERT INTO bots (id, user_id, name, symbol, strategy, parameters, is_active, account_value, is_live, description,
+			strategy_status, status_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id, name = EXCLUDED.name, symbol = EXCLUDED.symbol,
+			strategy = EXCLUDED.strategy, parameters = EXCLUDED.parameters, is_active = EXCLUDED.is_active,
+			account_value = EXCLUDED.account_value, is_live = EXCLUDED.is_live, description = EXCLUDED.description,
+			strategy_status = EXCLUDED.strategy_status, status_reason = EXCLUDED.status_reason, updated_at = CURRENT_TIMESTAMP`
+	_, err := s.pool.Exec(ctx, query, bot.BotId, bot.UserId, bot.Name, bot.Symbol, bot.Strategy, params, bot.IsActive,
+		bot.AccountValue, bot.IsLive, bot.Description, bot.StrategyStatus, bot.StatusReason, created)
+	if err != nil {
+		log.Error().Err(err).Str("bot_id", bot.BotId).Msg("Failed to upsert bot")
+		return fmt.Errorf("failed to upsert bot: %w", err)
+	}
+	return nil
+}
+
+// botPosition is a bot's net quantity of a symbol and the cash its trades moved.
+type botPosition struct {
+	BotID    string
+	Symbol   string
+	Quantity float64
+	Cash     float64 // negative for net buying
+}
+
+// ListBotPositions nets every bot's recorded trades per symbol.
+func (s *DBService) ListBotPositions(ctx context.Context) ([]botPosition, error) {
+	query := `SELECT bot_id::text, symbol,
+			SUM(CASE WHEN side = 'SELL' THEN -quantity ELSE quantity END)::float8,
+			SUM(CASE WHEN side = 'SELL' THEN quantity * price ELSE -quantity * price END)::float8
+		FROM trades WHERE bot_id IS NOT NULL GROUP BY bot_id, symbol`
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bot positions: %w", err)
+	}
+	defer rows.Close()
+	var out []botPosition
+	for rows.Next() {
+		var p botPosition
+		if err := rows.Scan(&p.BotID, &p.Symbol, &p.Quantity, &p.Cash); err != nil {
+			return nil, fmt.Errorf("failed to scan bot position: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// -----------------------
+// --- User Management ---
+// -----------------------
+
+var (
+	errUserExists   = errors.New("user exists")
+	errUserNotFound = errors.New("user not found")
+)
+
+// userAccount is a users row.
+type userAccount struct {
+	ID            string
+	Username      string
+	Email         string
+	PasswordHash  string
+	Role          string
+	CreatedAt     time.Time
+	EmailVerified bool
+}
+
+func (u *userAccount) info() *pb.UserInfo {
+	return &pb.UserInfo{
+		UserId:        u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		Role:          u.Role,
+		CreatedAtUnix: u.CreatedAt.Unix(),
+		EmailVerified: u.EmailVerified,
+	}
+}
+
+const userColumns = `id::text, username, COALESCE(email, ''), password_hash, role, created_at, email_verified_at IS NOT NULL`
+
+func scanUser(row pgx.Row) (*userAccount, error) {
+	var u userAccount
+	if err := row.Scan(&u.ID, &u.Username, &u.Email, &u.PasswordHash, &u.Role, &u.CreatedAt, &u.EmailVerified); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// CreateUser inserts u and sets its ID and CreatedAt. It returns errUserExists when the
+// username is taken.
+func (s *DBService) CreateUser(ctx context.Context, u *userAccount) error {
+	u.ID = uuid.New().String()
+	query := `INSERT INTO users (id, username, email, password_hash, role) VALUES ($1, $2, $3, $4, $5) RETURNING created_at`
+	err := s.pool.QueryRow(ctx, query, u.ID, u.Username, u.Email, u.PasswordHash, u.Role).Scan(&u.CreatedAt)
+	if isUniqueViolation(err) {
+		return errUserExists
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create user")
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
+}
+
+func (s *DBService) getUser(ctx context.Context, where string, arg any) (*userAccount, error) {
+	u, err := scanUser(s.pool.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE `+where, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return u, nil
+}
+
+// UserByUsername returns a user, or errUserNotFound.
+func (s *DBService) UserByUsername(ctx context.Context, username string) (*userAccount, error) {
+	return s.getUser(ctx, `username = $1`, username)
+}
+
+// UserByID returns a user, or errUserNotFound.
+func (s *DBService) UserByID(ctx context.Context, userID string) (*userAccount, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, errUserNotFound
+	}
+	return s.getUser(ctx, `id = $1`, userID)
+}
+
+// UserByEmail returns the oldest user with an email address, compared
+// case-insensitively, or errUserNotFound.
+func (s *DBService) UserByEmail(ctx context.Context, email string) (*userAccount, error) {
+	return s.getUser(ctx, `lower(email) = lower($1) ORDER BY created_at LIMIT 1`, email)
+}
+
+// UpdateUserEmail changes a user's email address. A changed address is no longer
+// verified.
+func (s *DBService) UpdateUserEmail(ctx context.Context, userID, email string) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE users SET email = $2,
+		email_verified_at = CASE WHEN email IS NOT DISTINCT FROM $2 THEN email_verified_at END
+		WHERE id = $1`, userID, email)
+	if err != nil {
+		return fmt.Errorf("failed to update email: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errUserNotFound
+	}
+	return nil
+}
+
+// UpdatePassword sets a user's password hash and ends their sessions other than
+// keepSession.
+func (s *DBService) UpdatePassword(ctx context.Context, userID, passwordHash, keepSession string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	tag, err := tx.Exec(ctx, `UPDATE users SET password_hash = $2 WHERE id = $1`, userID, passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errUserNotFound
+	}
+	if err := revokeUserSessions(ctx, tx, userID, keepSession); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// DeleteUser deletes a user and their bots, whose orders, trades and portfolios
+// cascade. Access tokens from the user's sessions are denied first, since the
+// refresh tokens that record them are deleted with the user.
+func (s *DBService) DeleteUser(ctx context.Context, userID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := revokeUserSessions(ctx, tx, userID, ""); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM bots WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete bots: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errUserNotFound
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// ListUsers returns users ordered by signup time.
+func (s *DBService) ListUsers(ctx context.Context, limit, offset int) ([]*userAccount, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+userColumns+` FROM users ORDER BY created_at, username LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+	var out []*userAccount
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// ---------------------------- //
+// --- Credential Management --- //
+// ---------------------------- //
+
+// storedCredential is a user_credentials row. The secret is only available sealed;
+// see credentialVault.
+type storedCredential struct {
+	ID           string
+	UserID       string
+	Venue        string
+	Label        string
+	APIKeyMasked string
+	Ciphertext   []byte
+	WrappedKey   []byte
+	CreatedAt    time.Time
+	RotatedAt    *time.Time
+}
+
+const credentialColumns = `id::text, user_id::text, venue, label, api_key_masked, ciphertext, wrapped_key, created_at, rotated_at`
+
+func scanCredential(row pgx.Row) (*storedCredential, error) {
+	var c storedCredential
+	if err := row.Scan(&c.ID, &c.UserID, &c.Venue, &c.Label, &c.APIKeyMasked, &c.Ciphertext, &c.WrappedKey, &c.CreatedAt, &c.RotatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *DBService) InsertCredential(ctx context.Context, c *storedCredential) error {
+	query := `INSERT INTO user_credentials (id, user_id, venue, label, api_key_masked, ciphertext, wrapped_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING created_at`
+	err := s.pool.QueryRow(ctx, query, c.ID, c.UserID, c.Venue, c.Label, c.APIKeyMasked, c.Ciphertext, c.WrappedKey).Scan(&c.CreatedAt)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", c.UserID).Msg("Failed to insert credential")
+		return fmt.Errorf("failed to insert credential: %w", err)
+	}
+	return nil
+}
+
+// ListCredentials returns a user's credentials, optionally for a single venue.
+func (s *DBService) ListCredentials(ctx context.Context, userID, venue string) ([]*storedCredential, error) {
+	query := `SELECT ` + credentialColumns + ` FROM user_credentials
+		WHERE user_id = $1 AND ($2 = '' OR venue = $2) ORDER BY created_at`
+	rows, err := s.pool.Query(ctx, query, userID, venue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+	defer rows.Close()
+	var out []*storedCredential
+	for rows.Next() {
+		c, err := scanCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credential: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// LatestCredential returns the user's most recently added or rotated credential for a
+// venue. It returns a wrapped pgx.ErrNoRows when the user has none.
+func (s *DBService) LatestCredential(ctx context.Context, userID, venue string) (*storedCredential, error) {
+	query := `SELECT ` + credentialColumns + ` FROM user_credentials
+		WHERE user_id = $1 AND venue = $2 ORDER BY COALESCE(rotated_at, created_at) DESC LIMIT 1`
+	c, err := scanCredential(s.pool.QueryRow(ctx, query, userID, venue))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+	return c, nil
+}
+
+// ListLatestCredentialsByVenue returns each user's most recent credential for a venue.
+func (s *DBService) ListLatestCredentialsByVenue(ctx context.Context, venue string) ([]*storedCredential, error) {
+	query := `SELECT DISTINCT ON (user_id) ` + credentialColumns + ` FROM user_credentials
+		WHERE venue = $1 ORDER BY user_id, COALESCE(rotated_at, created_at) DESC`
+	rows, err := s.pool.Query(ctx, query, venue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+	defer rows.Close()
+	var out []*storedCredential
+	for rows.Next() {
+		c, err := scanCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credential: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// RotateCredential replaces a credential's sealed secret. It returns a wrapped
+// pgx.ErrNoRows when the user has no such credential.
+func (s *DBService) RotateCredential(ctx context.Context, userID, id, apiKeyMasked string, ciphertext, wrappedKey []byte) (*storedCredential, error) {
+	query := `UPDATE user_credentials SET api_key_masked = $3, ciphertext = $4, wrapped_key = $5, rotated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 RETURNING ` + credentialColumns
+	c, err := scanCredential(s.pool.QueryRow(ctx, query, id, userID, apiKeyMasked, ciphertext, wrappedKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate credential: %w", err)
+	}
+	return c, nil
+}
+
+// DeleteCredential removes a user's credential. It returns a wrapped pgx.ErrNoRows
+// when the user has no such credential.
+func (s *DBService) DeleteCredential(ctx context.Context, userID, id string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM user_credentials WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to delete credential: %w", pgx.ErrNoRows)
+	}
+	return nil
+}
+
+// ------------------------- //
+// --- Session Management --- //
+// ------------------------- //
+
+// errRefreshTokenInvalid is returned for unknown, expired and revoked refresh tokens.
+var errRefreshTokenInvalid = errors.New("refresh token is invalid")
+
+// errRefreshTokenReused is returned when an already rotated refresh token is presented
+// again. Its family has been revoked by then.
+var errRefreshTokenReused = errors.New("refresh token reused")
+
+// refreshToken is a refresh_tokens row.
+type refreshToken struct {
+	ID              string
+	FamilyID        string
+	UserID          string
+	Username        string
+	TokenHash       []byte
+	AccessJTI       string
+	AccessExpiresAt time.Time
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+func (s *DBService) InsertRefreshToken(ctx context.Context, t *refreshToken) error {
+	query := `INSERT INTO refresh_tokens (id, family_id, user_id, username, token_hash, access_jti, access_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := s.pool.Exec(ctx, query, t.ID, t.FamilyID, t.UserID, t.Username, t.TokenHash, t.AccessJTI, t.AccessExpiresAt, t.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+	return nil
+}
+
+// RotateRefreshToken exchanges the refresh token with hash for next, which joins the
+// same family and user. It returns the rotated token. A token that was already rotated
+// revokes its family and returns errRefreshTokenReused.
+func (s *DBService) RotateRefreshToken(ctx context.Context, hash []byte, next *refreshToken) (*refreshToken, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var cur refreshToken
+	var usedAt, revokedAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT id::text, family_id::text, user_id::text, username, expires_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE`, hash).
+		Scan(&cur.ID, &cur.FamilyID, &cur.UserID, &cur.Username, &cur.ExpiresAt, &usedAt, &revokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errRefreshTokenInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	switch {
+	case revokedAt != nil:
+		return nil, errRefreshTokenInvalid
+	case usedAt != nil:
+		if _, err := revokeRefreshFamily(ctx, tx, cur.FamilyID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to commit: %w", err)
+		}
+		return nil, errRefreshTokenReused
+	case time.Now().After(cur.ExpiresAt):
+		return nil, errRefreshTokenInvalid
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP WHERE id = $1`, cur.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark refresh token used: %w", err)
+	}
+	next.FamilyID, next.UserID, next.Username = cur.FamilyID, cur.UserID, cur.Username
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (id, family_id, user_id, username, token_hash, access_jti, access_expires_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		next.ID, next.FamilyID, next.UserID, next.Username, next.TokenHash, next.AccessJTI, next.AccessExpiresAt, next.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return &cur, nil
+}
+
+// revokeRefreshFamily revokes a family's refresh tokens and denies the access tokens
+// issued with them that have not expired yet.
+func revokeRefreshFamily(ctx context.Context, tx pgx.Tx, familyID string) (int64, error) {
+	tag, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL`, familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO revoked_jtis (jti, expires_at)
+		SELECT access_jti, access_expires_at FROM refresh_tokens
+		WHERE family_id = $1 AND access_expires_at > CURRENT_TIMESTAMP
+		ON CONFLICT (jti) DO NOTHING`, familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deny access tokens: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// revokeUserSessions revokes every session of a user except keep.
+func revokeUserSessions(ctx context.Context, tx pgx.Tx, userID, keep string) error {
+	rows, err := tx.Query(ctx, `SELECT DISTINCT family_id::text FROM refresh_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND family_id::text <> $2`, userID, keep)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	families, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for _, f := range families {
+		if _, err := revokeRefreshFamily(ctx, tx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeRefreshFamily ends a session. An empty userID revokes any user's session. It
+// returns a wrapped pgx.ErrNoRows when the user has no such active session.
+func (s *DBService) RevokeRefreshFamily(ctx context.Context, userID, familyID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	var owner string
+	err = tx.QueryRow(ctx, `SELECT user_id::text FROM refresh_tokens WHERE family_id = $1 AND revoked_at IS NULL LIMIT 1`, familyID).Scan(&owner)
+	if err == nil && userID != "" && owner != userID {
+		err = pgx.ErrNoRows
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+	if _, err := revokeRefreshFamily(ctx, tx, familyID); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// RefreshTokenFamily returns the session and user of a refresh token.
+func (s *DBService) RefreshTokenFamily(ctx context.Context, hash []byte) (familyID, userID string, err error) {
+	err = s.pool.QueryRow(ctx, `SELECT family_id::text, user_id::text FROM refresh_tokens WHERE token_hash = $1`, hash).Scan(&familyID, &userID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	return familyID, userID, nil
+}
+
+// ListRefreshSessions returns a user's sessions that are neither revoked nor expired.
+func (s *DBService) ListRefreshSessions(ctx context.Context, userID string) ([]*pb.Session, error) {
+	query := `SELECT family_id::text, MIN(created_at), MAX(created_at), MAX(expires_at) FROM refresh_tokens
+		WHERE user_id = $1 GROUP BY family_id
+		HAVING bool_and(revoked_at IS NULL) AND MAX(expires_at) > CURRENT_TIMESTAMP
+		ORDER BY MAX(created_at) DESC`
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.Session
+	for rows.Next() {
+		var id string
+		var created, refreshed, expires time.Time
+		if err := rows.Scan(&id, &created, &refreshed, &expires); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		out = append(out, &pb.Session{
+			Id:              id,
+			CreatedAt:       timestamppb.New(created),
+			LastRefreshedAt: timestamppb.New(refreshed),
+			ExpiresAt:       timestamppb.New(expires),
+		})
+	}
+	return out, rows.Err()
+}
+
+// DenyJTI revokes a single access token until it expires.
+func (s *DBService) DenyJTI(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := s.pool.Exec(ctx, `INSERT INTO revoked_jtis (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`, jti, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to deny jti: %w", err)
+	}
+	return nil
+}
+
+// ListDeniedJTIs returns revoked access tokens that have not expired.
+func (s *DBService) ListDeniedJTIs(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := s.pool.Query(ctx, `SELECT jti, expires_at FROM revoked_jtis WHERE expires_at > CURRENT_TIMESTAMP`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list denied jtis: %w", err)
+	}
+	defer rows.Close()
+	out := make(map[string]time.Time)
+	for rows.Next() {
+		var jti string
+		var exp time.Time
+		if err := rows.Scan(&jti, &exp); err != nil {
+			return nil, fmt.Errorf("failed to scan denied jti: %w", err)
+		}
+		out[jti] = exp
+	}
+	return out, rows.Err()
+}
+
+// PurgeExpiredSessions deletes expired refresh tokens and denylist entries.
+func (s *DBService) PurgeExpiredSessions(ctx context.Context) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM revoked_jtis WHERE expires_at <= CURRENT_TIMESTAMP`); err != nil {
+		return fmt.Errorf("failed to purge denied jtis: %w", err)
+	}
+	if _, err := s.pool.Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at <= CURRENT_TIMESTAMP`); err != nil {
+		return fmt.Errorf("failed to purge refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// ---------------------- //
+// --- API Key Storage --- //
+// ---------------------- //
+
+// apiKey is an api_keys row. Role is the owner's current role.
+type apiKey struct {
+	ID         string
+	UserID     string
+	Role       string
+	Name       string
+	Prefix     string
+	KeyHash    []byte
+	Scopes     []string
+	AllowedIPs []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+}
+
+func (s *DBService) InsertAPIKey(ctx context.Context, k *apiKey) error {
+	query := `INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, allowed_ips, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING created_at`
+	err := s.pool.QueryRow(ctx, query, k.ID, k.UserID, k.Name, k.Prefix, k.KeyHash, k.Scopes, k.AllowedIPs, k.ExpiresAt).Scan(&k.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKeyByHash returns the unrevoked key with hash. It returns a wrapped
+// pgx.ErrNoRows for unknown and revoked keys; expiry is left to the caller.
+func (s *DBService) GetAPIKeyByHash(ctx context.Context, hash []byte) (*apiKey, error) {
+	query := `SELECT k.id::text, k.user_id::text, u.role, k.name, k.prefix, k.scopes, k.allowed_ips, k.created_at, k.expires_at
+		FROM api_keys k JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL`
+	k := &apiKey{KeyHash: hash}
+	err := s.pool.QueryRow(ctx, query, hash).
+		Scan(&k.ID, &k.UserID, &k.Role, &k.Name, &k.Prefix, &k.Scopes, &k.AllowedIPs, &k.CreatedAt, &k.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return k, nil
+}
+
+// ListAPIKeys returns a user's unrevoked keys, newest first.
+func (s *DBService) ListAPIKeys(ctx context.Context, userID string) ([]*pb.APIKey, error) {
+	query := `SELECT id::text, name, prefix, scopes, allowed_ips, created_at, last_used_at, expires_at
+		FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.APIKey
+	for rows.Next() {
+		var k pb.APIKey
+		var created time.Time
+		var lastUsed, expires *time.Time
+		if err := rows.Scan(&k.Id, &k.Name, &k.Prefix, &k.Scopes, &k.AllowedIps, &created, &lastUsed, &expires); err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		k.CreatedAt = timestamppb.New(created)
+		if lastUsed != nil {
+			k.LastUsedAt = timestamppb.New(*lastUsed)
+		}
+		if expires != nil {
+			k.ExpiresAt = timestamppb.New(*expires)
+		}
+		out = append(out, &k)
+	}
+	return out, rows.Err()
+}
+
+// RevokeAPIKey revokes one of a user's keys. An empty userID revokes any user's key. It
+// returns a wrapped pgx.ErrNoRows when there is no such unrevoked key.
+func (s *DBService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND ($2 = '' OR user_id::text = $2) AND revoked_at IS NULL`, keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to revoke api key: %w", pgx.ErrNoRows)
+	}
+	return nil
+}
+
+// TouchAPIKey records that a key was used.
+func (s *DBService) TouchAPIKey(ctx context.Context, keyID string) error {
+	if _, err := s.pool.Exec(ctx, `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1`, keyID); err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+	return nil
+}
+
+// ------------------------- //
+// --- Two-Factor Storage --- //
+// ------------------------- //
+
+// errTOTPEnabled is returned when enrolling a user who already has 2FA enabled.
+var errTOTPEnabled = errors.New("two-factor authentication is already enabled")
+
+// userTOTP is a user_totp row.
+type userTOTP struct {
+	SecretSealed []byte
+	Enabled      bool
+	LastUsedStep int64
+}
+
+// GetTOTP returns a user's TOTP enrollment, or a wrapped pgx.ErrNoRows.
+func (s *DBService) GetTOTP(ctx context.Context, userID string) (*userTOTP, error) {
+	var t userTOTP
+	var enabledAt *time.Time
+	err := s.pool.QueryRow(ctx, `SELECT secret_sealed, enabled_at, last_used_step FROM user_totp WHERE user_id = $1`, userID).
+		Scan(&t.SecretSealed, &enabledAt, &t.LastUsedStep)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get totp: %w", err)
+	}
+	t.Enabled = enabledAt != nil
+	return &t, nil
+}
+
+// PutPendingTOTP starts or restarts an enrollment. It returns errTOTPEnabled when 2FA
+// is already enabled.
+func (s *DBService) PutPendingTOTP(ctx context.Context, userID string, sealed []byte) error {
+	tag, err := s.pool.Exec(ctx, `INSERT INTO user_totp (user_id, secret_sealed) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret_sealed = EXCLUDED.secret_sealed, last_used_step = 0, created_at = CURRENT_TIMESTAMP
+		WHERE user_totp.enabled_at IS NULL`, userID, sealed)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to parse DB connection string")
-		return nil, fmt.Errorf("failed to parse DB connection string: %w", err)
+		return fmt.Errorf("failed to store totp: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return errTOTPEnabled
+	}
+	return nil
+}
 
-	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+// EnableTOTP completes an enrollment with the time step of its first code and replaces
+// the user's recovery codes.
+func (s *DBService) EnableTOTP(ctx context.Context, userID string, step int64, recoveryHashes [][]byte) error {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create DB connection pool")
-		return nil, fmt.Errorf("failed to create DB connection pool: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	tag, err := tx.Exec(ctx, `UPDATE user_totp SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2
+		WHERE user_id = $1 AND enabled_at IS NULL`, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to enable totp: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return errTOTPEnabled
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to clear recovery codes: %w", err)
+	}
+	for _, h := range recoveryHashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO totp_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, h); err != nil {
+			return fmt.Errorf("failed to insert recovery code: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
 
-	// Ping the database to verify the connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := pool.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+// UseTOTPStep records that the code for step was used. It reports false when that
+// step or a later one was already used, so each code works once.
+func (s *DBService) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to record totp use: %w", err)
 	}
+	return tag.RowsAffected() == 1, nil
+}
 
-	log.Info().Msg("Successfully connected to PostgreSQL database.")
-	return &DBService{pool: pool}, nil
+// UseRecoveryCode consumes a recovery code, reporting false for unknown or used codes.
+func (s *DBService) UseRecoveryCode(ctx context.Context, userID string, hash []byte) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `UPDATE totp_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`, userID, hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
 }
 
-// Close closes the database connection pool.
-func (s *DBService) Close() {
-	s.pool.Close()
-	log.Info().Msg("Database connection pool closed.")
+// DeleteTOTP disables 2FA and removes the user's recovery codes.
+func (s *DBService) DeleteTOTP(ctx context.Context, userID string) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := s.pool.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+	return nil
 }
 
-// --------------------- //
-// - Order Management -- //
-// --------------------- //
+// ----------------------------- //
+// --- Login Attempt Tracking --- //
+// ----------------------------- //
 
-// CREATE TABLE IF NOT EXISTS orders (
-//
-//	id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-//	bot_id UUID REFERENCES bots(id) ON DELETE CASCADE,
-//	symbol TEXT NOT NULL,
-//	side TEXT NOT NULL, -- Use 'BUY' or 'SELL'
-//	type TEXT NOT NULL, -- Use 'MARKET', 'LIMIT', 'STOP'
-//	status TEXT NOT NULL, -- Use 'NEW', 'SUBMITTED', etc.
-//	quantity_requested NUMERIC(20, 8) NOT NULL,
-//	quantity_filled NUMERIC(20, 8) DEFAULT 0,
-//	limit_price NUMERIC(20, 8),
-//	stop_price NUMERIC(20, 8),
-//	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-//	updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
-//
-// );
-func (s *DBService) CreateOrder(
-	ctx context.Context,
-	id string,
-	botId string,
-	symbol string,
-	side string,
-	orderType string,
-	status pb.OrderStatus,
-	quantityRequested string,
-	quantityFilled string,
-	limitPrice string,
-	stopPrice string,
-) (string, error) {
-	var returnedId string
-	query := `INSERT INTO orders (id, bot_id, symbol, side, type, status, quantity_requested, quantity_filled, limit_price, stop_price, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-        RETURNING id`
-	err := s.pool.QueryRow(ctx, query, id, botId, symbol, side, orderType, status.String(), quantityRequested, quantityFilled, limitPrice, stopPrice).Scan(&returnedId)
+// LoginCounter returns the failed-login counter for key; unknown keys have none.
+func (s *DBService) LoginCounter(ctx context.Context, key string) (loginCounter, error) {
+	var c loginCounter
+	err := s.pool.QueryRow(ctx, `SELECT failures, last_failure_at FROM login_counters WHERE key = $1`, key).Scan(&c.Failures, &c.LastFailure)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return loginCounter{}, nil
+	}
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create order")
-		return "", fmt.Errorf("failed to create order: %w", err)
+		return loginCounter{}, fmt.Errorf("failed to get login counter: %w", err)
 	}
-	log.Info().Str("order_id", returnedId).Msg("Order created successfully")
-	return returnedId, nil
+	return c, nil
 }
 
-func (s *DBService) GetOrder(ctx context.Context, orderID string) (*pb.Order, error) {
-	var order pb.Order
-	query := `SELECT id, bot_id, symbol, side, type, status, quantity_requested, quantity_filled, limit_price, stop_price, created_at, updated_at
-		FROM orders WHERE id = $1`
-	err := s.pool.QueryRow(ctx, query, orderID).Scan(&order.Id, &order.BotId, &order.Symbol, &order.Side, &order.Type, &order.Status, &order.QuantityRequested, &order.QuantityFilled, &order.LimitPrice, &order.StopPrice, &order.CreatedAt, &order.UpdatedAt)
+// RecordLoginFailure counts a failed login for key. Failures before window restart
+// the count.
+func (s *DBService) RecordLoginFailure(ctx context.Context, key string, at time.Time, window time.Duration) (loginCounter, error) {
+	var c loginCounter
+	err := s.pool.QueryRow(ctx, `INSERT INTO login_counters (key, failures, last_failure_at) VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_counters.last_failure_at < $3 THEN 1 ELSE login_counters.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures, last_failure_at`, key, at, at.Add(-window)).Scan(&c.Failures, &c.LastFailure)
 	if err != nil {
-		log.Error().Err(err).Str("order_id", orderID).Msg("Failed to get order")
-		return nil, fmt.Errorf("failed to get order: %w", err)
+		return loginCounter{}, fmt.Errorf("failed to record login failure: %w", err)
 	}
-	return &order, nil
+	return c, nil
 }
 
-func (s *DBService) ListOrders(ctx context.Context, botID string, limit, offset int32) ([]*pb.Order, error) {
-	// If botID == "" then return because it can't be empty.
-	if botID == "" {
-		return nil, fmt.Errorf("botID cannot be empty")
+func (s *DBService) ResetLoginCounter(ctx context.Context, key string) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM login_counters WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to reset login counter: %w", err)
 	}
-	var orders []*pb.Order
-	query := `SELECT id, bot_id, symbol, side, type, status, quantity_requested, quantity_filled, limit_price, stop_price, created_at, updated_at
-		FROM orders WHERE bot_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-	rows, err := s.pool.Query(ctx, query, botID, limit, offset)
+	return nil
+}
+
+func (s *DBService) RecordLoginAttempt(ctx context.Context, a loginAttempt) error {
+	_, err := s.pool.Exec(ctx, `INSERT INTO login_attempts (username, ip, success, reason, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		a.Username, a.IP, a.Success, a.Reason, a.At)
 	if err != nil {
-		log.Error().Err(err).Str("bot_id", botID).Msg("Failed to list orders")
-		return nil, fmt.Errorf("failed to list orders: %w", err)
+		return fmt.Errorf("failed to record login attempt: %w", err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var order pb.Order
-		var sideStr, typeStr, statusStr string
-		var quantityRequestedStr, quantityFilledStr, limitPriceStr, stopPriceStr string
-		var createdAt, updatedAt time.Time
-
-		if err := rows.Scan(
-			&order.Id,
-			&order.BotId,
-			&order.Symbol,
-			&sideStr,
-			&typeStr,
-			&statusStr,
-			&quantityRequestedStr,
-			&quantityFilledStr,
-			&limitPriceStr,
-			&stopPriceStr,
-			&createdAt,
-			&updatedAt,
-		); err != nil {
-			log.Error().Err(err).Msg("Failed to scan order")
-			return nil, fmt.Errorf("failed to scan order: %w", err)
-		}
+	return nil
+}
+
+// PurgeLoginState deletes counters last failed before countersBefore and audit
+// records older than attemptsBefore.
+func (s *DBService) PurgeLoginState(ctx context.Context, countersBefore, attemptsBefore time.Time) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM login_counters WHERE last_failure_at < $1`, countersBefore); err != nil {
+		return fmt.Errorf("failed to purge login counters: %w", err)
+	}
+	if _, err := s.pool.Exec(ctx, `DELETE FROM login_attempts WHERE created_at < $1`, attemptsBefore); err != nil {
+		return fmt.Errorf("failed to purge login attempts: %w", err)
+	}
+	return nil
+}
+
+// ---------------------- //
+// --- Account Tokens --- //
+// ---------------------- //
+
+// Account token purposes.
+const (
+	tokenPasswordReset     = "password_reset"
+	tokenEmailVerification = "email_verification"
+)
 
-		// Convert string to enum
-		order.Side = pb.OrderSide(pb.OrderSide_value[sideStr])
-		order.Type = pb.OrderType(pb.OrderType_value[typeStr])
-		order.Status = pb.OrderStatus(pb.OrderStatus_value[statusStr])
+// errAccountTokenInvalid is returned for unknown, used and expired account tokens.
+var errAccountTokenInvalid = errors.New("token is invalid or expired")
 
-		// Convert numeric strings to DecimalValue
-		order.QuantityRequested = numericValueToDecimal(quantityRequestedStr)
-		order.QuantityFilled = numericValueToDecimal(quantityFilledStr)
-		order.LimitPrice = numericValueToDecimal(limitPriceStr)
-		order.StopPrice = numericValueToDecimal(stopPriceStr)
+// errAccountTokenThrottled is returned when a token of the same purpose was issued to
+// the user too recently.
+var errAccountTokenThrottled = errors.New("token requested too recently")
 
-		// Convert timestamps
-		order.CreatedAt = timestamppb.New(createdAt)
-		order.UpdatedAt = timestamppb.New(updatedAt)
+// accountToken is an account_tokens row.
+type accountToken struct {
+	TokenHash []byte
+	UserID    string
+	Purpose   string
+	Email     string
+	ExpiresAt time.Time
+}
 
-		orders = append(orders, &order)
+// InsertAccountToken stores a token and voids the user's earlier unused tokens of the
+// same purpose. It returns errAccountTokenThrottled when one was issued within minGap.
+func (s *DBService) InsertAccountToken(ctx context.Context, t *accountToken, minGap time.Duration) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	if err := rows.Err(); err != nil {
-		log.Error().Err(err).Msg("Failed to iterate orders")
-		return nil, fmt.Errorf("failed to iterate orders: %w", err)
+	defer tx.Rollback(ctx)
+	var recent bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM account_tokens WHERE user_id = $1 AND purpose = $2 AND created_at > $3)`,
+		t.UserID, t.Purpose, time.Now().Add(-minGap)).Scan(&recent)
+	if err != nil {
+		return fmt.Errorf("failed to check account tokens: %w", err)
 	}
-	return orders, nil
+	if recent {
+		return errAccountTokenThrottled
+	}
+	if _, err := tx.Exec(ctx, `UPDATE account_tokens SET used_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`, t.UserID, t.Purpose); err != nil {
+		return fmt.Errorf("failed to void account tokens: %w", err)
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO account_tokens (token_hash, user_id, purpose, email, expires_at) VALUES ($1, $2, $3, $4, $5)`,
+		t.TokenHash, t.UserID, t.Purpose, t.Email, t.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert account token: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
 }
 
-// ----------------------
-// --- Bot Management ---
-// ----------------------
-
-func (s *DBService) CreateBot(ctx context.Context, bot *pb.Bot) (string, error) {
-	var id string
-	query := `INSERT INTO bots (id, user_id, name, symbol, strategy, parameters, is_active, account_value) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
-	err := s.pool.QueryRow(ctx, query, bot.BotId, bot.UserId, bot.Name, bot.Symbol, bot.Strategy, bot.Parameters, bot.IsActive, bot.AccountValue).Scan(&id)
+// consumeAccountToken marks a token used and returns it, or errAccountTokenInvalid.
+func consumeAccountToken(ctx context.Context, tx pgx.Tx, hash []byte, purpose string) (*accountToken, error) {
+	t := &accountToken{TokenHash: hash, Purpose: purpose}
+	err := tx.QueryRow(ctx, `UPDATE account_tokens SET used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id::text, email, expires_at`, hash, purpose).Scan(&t.UserID, &t.Email, &t.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errAccountTokenInvalid
+	}
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create bot")
-		return "", fmt.Errorf("failed to create bot: %w", err)
+		return nil, fmt.Errorf("failed to consume account token: %w", err)
 	}
-	log.Info().Str("bot_id", id).Msg("Bot created successfully")
-	return id, nil
+	return t, nil
 }
 
-func (s *DBService) DeleteBot(ctx context.Context, botID string) error {
-	query := `DELETE FROM bots WHERE id = $1`
-	_, err := s.pool.Exec(ctx, query, botID)
+// ResetPassword consumes a password reset token, sets the new password hash and ends
+// all of the user's sessions. It returns the user's id.
+func (s *DBService) ResetPassword(ctx context.Context, hash []byte, passwordHash string) (string, error) {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		log.Error().Err(err).Str("bot_id", botID).Msg("Failed to delete bot")
-		return fmt.Errorf("failed to delete bot: %w", err)
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	log.Info().Str("bot_id", botID).Msg("Bot deleted successfully")
-	return nil
+	defer tx.Rollback(ctx)
+	t, err := consumeAccountToken(ctx, tx, hash, tokenPasswordReset)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET password_hash = $2 WHERE id = $1`, t.UserID, passwordHash); err != nil {
+		return "", fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := revokeUserSessions(ctx, tx, t.UserID, ""); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+	return t.UserID, nil
 }
 
-// -----------------------
-// --- User Management ---
-// -----------------------
-
-func (s *DBService) CreateUser(ctx context.Context, username, passwordHash string) (string, error) {
-	id := uuid.New().String()
-	query := `INSERT INTO users (id, username, password_hash) VALUES ($1, $2, $3) RETURNING id`
-	err := s.pool.QueryRow(ctx, query, id, username, passwordHash).Scan(&id)
+// VerifyEmail consumes an email verification token and marks the address verified,
+// provided it is still the user's address. It returns the user's id.
+func (s *DBService) VerifyEmail(ctx context.Context, hash []byte) (string, error) {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create user")
-		return "", fmt.Errorf("failed to create user: %w", err)
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	return id, nil
+	defer tx.Rollback(ctx)
+	t, err := consumeAccountToken(ctx, tx, hash, tokenEmailVerification)
+	if err != nil {
+		return "", err
+	}
+	tag, err := tx.Exec(ctx, `UPDATE users SET email_verified_at = CURRENT_TIMESTAMP WHERE id = $1 AND email = $2`, t.UserID, t.Email)
+	if err != nil {
+		return "", fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", errAccountTokenInvalid
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+	return t.UserID, nil
 }
 
 // ---------------------------- //
@@ -256,6 +1529,56 @@ func (s *DBService) CreateUser(ctx context.Context, username, passwordHash strin
 // 	return nil
 // }
 
+// performanceSnapshot is a bot's marked equity at one time, stored in
+// bot_performance_snapshots (017_bot_performance_snapshots.up.sql).
+type performanceSnapshot struct {
+	ID    string
+	BotID string
+	*pb.BotPerformanceSnapshot
+}
+
+// RecordPerformanceSnapshots inserts snapshots in one round trip.
+func (s *DBService) RecordPerformanceSnapshots(ctx context.Context, snaps []*performanceSnapshot) error {
+	batch := &pgx.Batch{}
+	for _, snap := range snaps {
+		batch.Queue(`INSERT INTO bot_performance_snapshots (bot_id, snapshot_time, equity_value, cash_balance, pnl)
+			VALUES ($1, $2, $3, $4, $5)`,
+			snap.BotID, snap.SnapshotTime.AsTime(), decimalValueToNumeric(snap.EquityValue),
+			decimalValueToNumeric(snap.CashBalance), decimalValueToNumeric(snap.Pnl))
+	}
+	if err := s.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to record performance snapshots: %w", err)
+	}
+	return nil
+}
+
+// ListPerformanceSnapshots returns up to limit snapshots matching f, oldest first,
+// starting after the cursor when one is given. Symbol and Side must be empty.
+func (s *DBService) ListPerformanceSnapshots(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*performanceSnapshot, error) {
+	const columns = `id::text, bot_id::text, snapshot_time, equity_value::text, cash_balance::text, pnl::text`
+	query, args := keysetQuery(columns, "bot_performance_snapshots", "snapshot_time", f, after, false, limit)
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list performance snapshots: %w", err)
+	}
+	defer rows.Close()
+	var out []*performanceSnapshot
+	for rows.Next() {
+		var at time.Time
+		var equity, cash, pnl string
+		snap := &performanceSnapshot{BotPerformanceSnapshot: &pb.BotPerformanceSnapshot{}}
+		if err := rows.Scan(&snap.ID, &snap.BotID, &at, &equity, &cash, &pnl); err != nil {
+			return nil, fmt.Errorf("failed to scan performance snapshot: %w", err)
+		}
+		snap.SnapshotTime = timestamppb.New(at)
+		snap.EquityValue = numericValueToDecimal(equity)
+		snap.CashBalance = numericValueToDecimal(cash)
+		snap.Pnl = numericValueToDecimal(pnl)
+		out = append(out, snap)
+	}
+	return out, rows.Err()
+}
+
 // --------------------------- //
 // --- Strategy Management --- //
 // --------------------------- //
@@ -316,51 +1639,336 @@ func (s *DBService) GetStrategiesByUserID(ctx context.Context, userID string) ([
 	return strategies, nil
 }
 
+// strategyCheckpoint is a strategy_state row: the saved state of a bot's strategy.
+type strategyCheckpoint struct {
+	BotID        string
+	StrategyType string
+	Version      int    // the strategy's state format
+	State        []byte // JSON
+	UpdatedAt    time.Time
+}
+
+// SaveStrategyState replaces the bot's checkpoint.
+func (s *DBService) SaveStrategyState(ctx context.Context, cp *strategyCheckpoint) error {
+	query := `INSERT INTO strategy_state (bot_id, strategy_type, version, state, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (bot_id) DO UPDATE
+		SET strategy_type = EXCLUDED.strategy_type,
+			version = EXCLUDED.version,
+			state = EXCLUDED.state,
+			updated_at = CURRENT_TIMESTAMP`
+	if _, err := s.pool.Exec(ctx, query, cp.BotID, cp.StrategyType, cp.Version, cp.State); err != nil {
+		return fmt.Errorf("failed to save strategy state: %w", err)
+	}
+	return nil
+}
+
+// LoadStrategyState returns the bot's checkpoint. It returns a wrapped pgx.ErrNoRows
+// when none was saved.
+func (s *DBService) LoadStrategyState(ctx context.Context, botID string) (*strategyCheckpoint, error) {
+	cp := &strategyCheckpoint{BotID: botID}
+	query := `SELECT strategy_type, version, state, updated_at FROM strategy_state WHERE bot_id = $1`
+	err := s.pool.QueryRow(ctx, query, botID).Scan(&cp.StrategyType, &cp.Version, &cp.State, &cp.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load strategy state: %w", err)
+	}
+	return cp, nil
+}
+
 // --------------------- //
 // --- Trade History --- //
 // --------------------- //
 
-// 	CREATE TABLE IF NOT EXISTS trades (
-//     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-//     bot_id UUID REFERENCES bots(id) ON DELETE CASCADE,
-//     symbol TEXT NOT NULL,
-//     side TEXT NOT NULL,
-//     quantity NUMERIC(20, 8) NOT NULL,
-//     price NUMERIC(20, 8) NOT NULL,
-//     executed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-// );
-// message Trade {
-//     string trade_id = 1;
-//     string symbol = 2;
-//     string side = 3;
-//     double quantity = 4;
-//     double price = 5;
-//     int64 executed_at = 6;
-//     string strategy_id = 7;
-//     string bot_id = 8;
-// }
+// tradeColumns selects every pb.Trade field, in scanTrade's order.
+const tradeColumns = `id::text, COALESCE(bot_id::text, ''), symbol, side, quantity::float8, price::float8, executed_at,
+	COALESCE(client_order_id, ''), COALESCE(order_id::text, ''), COALESCE(strategy_id, ''), COALESCE(liquidity, ''),
+	commission::text, realized_pnl::text, unrealized_pnl::text`
 
-// RecordTrade inserts a new trade record.
-func (s *DBService) RecordTrade(ctx context.Context, trade *pb.Trade) error {
-	query := `
-		INSERT INTO trades (id, bot_id, symbol, side, quantity, price, executed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
+// scanTrade converts a row selected with tradeColumns into a pb.Trade.
+func scanTrade(row pgx.Row) (*pb.Trade, error) {
+	var t pb.Trade
+	var executedAt time.Time
+	var commission, realized, unrealized *string
+	if err := row.Scan(&t.TradeId, &t.BotId, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &executedAt,
+		&t.ClientOrderId, &t.OrderId, &t.StrategyId, &t.Liquidity, &commission, &realized, &unrealized); err != nil {
+		return nil, err
+	}
+	t.ExecutedAt = executedAt.UnixNano()
+	t.ExecutedAtTimestamp = timestamppb.New(executedAt)
+	if commission != nil {
+		t.Commission = numericValueToDecimal(*commission)
+	}
+	if realized != nil {
+		t.PnlRealized = numericValueToDecimal(*realized)
+	}
+	if unrealized != nil {
+		t.PnlUnrealized = numericValueToDecimal(*unrealized)
+	}
+	return &t, nil
+}
+
+// optionalText is nil for an empty string, so it is stored as NULL.
+func optionalText(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalNumeric is nil for an unset decimal, so it is stored as NULL.
+func optionalNumeric(dv *pb.DecimalValue) *string {
+	if dv == nil {
+		return nil
+	}
+	n := decimalValueToNumeric(dv)
+	return &n
+}
+
+const insertTradeQuery = `
+		INSERT INTO trades (id, bot_id, symbol, side, quantity, price, executed_at, client_order_id,
+			order_id, strategy_id, liquidity, commission, realized_pnl, unrealized_pnl)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+// tradeInsertArgs returns the trade's execution time and its arguments for insertTradeQuery.
+func tradeInsertArgs(trade *pb.Trade) (time.Time, []any) {
+	executedAt := time.Now()
+	switch {
+	case trade.ExecutedAtTimestamp != nil:
+		executedAt = trade.ExecutedAtTimestamp.AsTime()
+	case trade.ExecutedAt != 0:
+		executedAt = time.Unix(0, trade.ExecutedAt)
+	}
+	return executedAt, []any{
+		trade.TradeId,
+		optionalText(trade.BotId),
+		trade.Symbol,
+		trade.Side,
+		trade.Quantity,
+		trade.Price,
+		executedAt,
+		optionalText(trade.ClientOrderId),
+		optionalText(trade.OrderId),
+		optionalText(trade.StrategyId),
+		optionalText(trade.Liquidity),
+		optionalNumeric(trade.Commission),
+		optionalNumeric(trade.PnlRealized),
+		optionalNumeric(trade.PnlUnrealized),
+	}
+}
 
-	_, err := s.pool.Exec(ctx, query, trade.TradeId, trade.Symbol, trade.Side, trade.Quantity, trade.Price, trade.ExecutedAt, trade.StrategyId, trade.BotId)
+// RecordTrade inserts a new trade record. The execution time is taken from
+// executed_at_timestamp, else executed_at (Unix nanoseconds), else now, and both
+// fields are set to it. It returns errDuplicateClientOrderID if the bot already
+// recorded a trade with the same client_order_id.
+func (s *DBService) RecordTrade(ctx context.Context, trade *pb.Trade) error {
+	executedAt, args := tradeInsertArgs(trade)
+	_, err := s.pool.Exec(ctx, insertTradeQuery, args...)
 	if err != nil {
+		if isUniqueViolation(err) && trade.ClientOrderId != "" {
+			return errDuplicateClientOrderID
+		}
 		log.Error().Err(err).Msg("Failed to record trade")
 		return fmt.Errorf("failed to record trade: %w", err)
 	}
+	trade.ExecutedAt = executedAt.UnixNano()
+	trade.ExecutedAtTimestamp = timestamppb.New(executedAt)
 	return nil
 }
 
+// GetTrade returns a trade by id. It returns a wrapped pgx.ErrNoRows when there is no
+// such trade.
+func (s *DBService) GetTrade(ctx context.Context, tradeID string) (*pb.Trade, error) {
+	t, err := scanTrade(s.pool.QueryRow(ctx, `SELECT `+tradeColumns+` FROM trades WHERE id = $1`, tradeID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade: %w", err)
+	}
+	return t, nil
+}
+
+// GetTradeByClientOrderID looks up a bot's trade by the client-assigned id. It returns
+// a wrapped pgx.ErrNoRows when there is no such trade.
+func (s *DBService) GetTradeByClientOrderID(ctx context.Context, botID, clientOrderID string) (*pb.Trade, error) {
+	query := `SELECT ` + tradeColumns + ` FROM trades WHERE bot_id = $1 AND client_order_id = $2`
+	t, err := scanTrade(s.pool.QueryRow(ctx, query, botID, clientOrderID))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Error().Err(err).Str("bot_id", botID).Str("client_order_id", clientOrderID).Msg("Failed to get trade by client order id")
+		}
+		return nil, fmt.Errorf("failed to get trade by client order id: %w", err)
+	}
+	return t, nil
+}
+
+// historyFilter selects trades, orders or performance snapshots. Empty fields match
+// everything; End is exclusive.
+type historyFilter struct {
+	UserID string
+	BotID  string
+	Symbol string
+	Side   string
+	Start  time.Time
+	End    time.Time
+}
+
+// where returns the filter as a WHERE clause on a table aliased t, with its arguments.
+// Start and End apply to timeColumn.
+func (f historyFilter) where(timeColumn string) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.UserID != "" {
+		add(`t.bot_id IN (SELECT id FROM bots WHERE user_id = $%d)`, f.UserID)
+	}
+	if f.BotID != "" {
+		add(`t.bot_id = $%d`, f.BotID)
+	}
+	if f.Symbol != "" {
+		add(`t.symbol = $%d`, f.Symbol)
+	}
+	if f.Side != "" {
+		add(`t.side = $%d`, f.Side)
+	}
+	if !f.Start.IsZero() {
+		add(`t.`+timeColumn+` >= $%d`, f.Start)
+	}
+	if !f.End.IsZero() {
+		add(`t.`+timeColumn+` < $%d`, f.End)
+	}
+	if len(conds) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// keysetCursor is the position of the last row on a page: its time and id.
+type keysetCursor struct {
+	At time.Time
+	ID string
+}
+
+// keysetQuery selects up to limit rows of table matching f, ordered by timeColumn and
+// id, starting after the cursor when one is given.
+func keysetQuery(columns, table, timeColumn string, f historyFilter, after *keysetCursor, newestFirst bool, limit int) (string, []any) {
+	where, args := f.where(timeColumn)
+	cmp, dir := ">", "ASC"
+	if newestFirst {
+		cmp, dir = "<", "DESC"
+	}
+	if after != nil {
+		args = append(args, after.At, after.ID)
+		where += fmt.Sprintf(` AND (t.%s, t.id) %s ($%d, $%d::uuid)`, timeColumn, cmp, len(args)-1, len(args))
+	}
+	args = append(args, limit)
+	return fmt.Sprintf(`SELECT %s FROM %s t WHERE %s ORDER BY t.%s %s, t.id %s LIMIT $%d`,
+		columns, table, where, timeColumn, dir, dir, len(args)), args
+}
+
+// ListTrades returns up to limit trades matching f, newest first, starting after the
+// cursor when one is given.
+func (s *DBService) ListTrades(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*pb.Trade, error) {
+	return s.listTrades(ctx, f, after, true, limit)
+}
+
+// ListTradesSince is ListTrades oldest first.
+func (s *DBService) ListTradesSince(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*pb.Trade, error) {
+	return s.listTrades(ctx, f, after, false, limit)
+}
+
+func (s *DBService) listTrades(ctx context.Context, f historyFilter, after *keysetCursor, newestFirst bool, limit int) ([]*pb.Trade, error) {
+	query, args := keysetQuery(tradeColumns, "trades", "executed_at", f, after, newestFirst, limit)
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trades: %w", err)
+	}
+	defer rows.Close()
+	var trades []*pb.Trade
+	for rows.Next() {
+		t, err := scanTrade(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade row: %w", err)
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// SummarizeTrades totals the trades matching f by symbol.
+func (s *DBService) SummarizeTrades(ctx context.Context, f historyFilter) ([]*pb.TradeSymbolSummary, error) {
+	where, args := f.where("executed_at")
+	query := `SELECT t.symbol, COUNT(*), SUM(t.quantity)::text, SUM(t.quantity * t.price)::text,
+			COALESCE(SUM(t.commission), 0)::text, COALESCE(SUM(t.realized_pnl), 0)::text
+		FROM trades t WHERE ` + where + ` GROUP BY t.symbol ORDER BY t.symbol`
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize trades: %w", err)
+	}
+	defer rows.Close()
+	var out []*pb.TradeSymbolSummary
+	for rows.Next() {
+		var sum pb.TradeSymbolSummary
+		var volume, notional, fees, pnl string
+		if err := rows.Scan(&sum.Symbol, &sum.TradeCount, &volume, &notional, &fees, &pnl); err != nil {
+			return nil, fmt.Errorf("failed to scan trade summary: %w", err)
+		}
+		sum.Volume = numericValueToDecimal(volume)
+		sum.Notional = numericValueToDecimal(notional)
+		sum.Fees = numericValueToDecimal(fees)
+		sum.RealizedPnl = numericValueToDecimal(pnl)
+		out = append(out, &sum)
+	}
+	return out, rows.Err()
+}
+
+// TradeExists reports whether a trade with the given id was recorded.
+func (s *DBService) TradeExists(ctx context.Context, tradeID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM trades WHERE id = $1)`, tradeID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check trade: %w", err)
+	}
+	return exists, nil
+}
+
+// livePosition is a user's net traded quantity of a symbol across their live bots.
+type livePosition struct {
+	UserID   string
+	Symbol   string
+	Quantity float64
+}
+
+// ListLivePositions nets recorded trades of live bots per owner and symbol.
+func (s *DBService) ListLivePositions(ctx context.Context) ([]livePosition, error) {
+	query := `SELECT b.user_id::text, t.symbol,
+			SUM(CASE WHEN t.side = 'SELL' THEN -t.quantity ELSE t.quantity END)::float8
+		FROM trades t JOIN bots b ON b.id = t.bot_id
+		WHERE b.is_live
+		GROUP BY b.user_id, t.symbol`
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list live positions: %w", err)
+	}
+	defer rows.Close()
+	var out []livePosition
+	for rows.Next() {
+		var p livePosition
+		if err := rows.Scan(&p.UserID, &p.Symbol, &p.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan live position: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// GetTradesByBotID returns a bot's trades, newest first.
 func (s *DBService) GetTradesByBotID(ctx context.Context, botID string) ([]*pb.Trade, error) {
 	if botID == "" {
 		return nil, fmt.Errorf("botID cannot be empty")
 	}
 	var trades []*pb.Trade
-	query := `SELECT id, bot_id, symbol, side, quantity, price, executed_at FROM trades WHERE bot_id = $1 ORDER BY executed_at DESC`
+	query := `SELECT ` + tradeColumns + ` FROM trades WHERE bot_id = $1 ORDER BY executed_at DESC, id`
 	rows, err := s.pool.Query(ctx, query, botID)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get trades by bot ID")
@@ -369,12 +1977,12 @@ func (s *DBService) GetTradesByBotID(ctx context.Context, botID string) ([]*pb.T
 	defer rows.Close()
 
 	for rows.Next() {
-		var t pb.Trade
-		if err := rows.Scan(&t.TradeId, &t.BotId, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &t.ExecutedAt); err != nil {
+		t, err := scanTrade(rows)
+		if err != nil {
 			log.Error().Err(err).Msg("Failed to scan trade row")
 			return nil, fmt.Errorf("failed to scan trade row: %w", err)
 		}
-		trades = append(trades, &t)
+		trades = append(trades, t)
 	}
-	return trades, nil
+	return trades, rows.Err()
 }
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS trades;
+DROP TABLE IF EXISTS strategies;
+DROP TABLE IF EXISTS portfolios;
+DROP TABLE IF EXISTS bots;
+ALTER TABLE users DROP COLUMN IF EXISTS subscription_id;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,7 @@
+-- Time-in-force and expiry for orders (see order_expiry.go)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS time_in_force TEXT NOT NULL DEFAULT 'GTC'; -- 'GTC', 'IOC', 'FOK', 'GTD', 'DAY'
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expire_at TIMESTAMP WITH TIME ZONE;
+
+-- The expirer only ever scans open orders that carry an expiry
+CREATE INDEX IF NOT EXISTS idx_orders_open_expire_at ON orders (expire_at)
+    WHERE expire_at IS NOT NULL AND status IN ('NEW', 'SUBMITTED', 'PARTIALLY_FILLED');
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_trades_bot_client_order_id;
+ALTER TABLE trades DROP COLUMN IF EXISTS client_order_id;
+
+DROP INDEX IF EXISTS idx_orders_bot_client_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS client_order_id;
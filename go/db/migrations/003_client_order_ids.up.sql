@@ -0,0 +1,8 @@
+-- Client-assigned ids make order and trade submission idempotent per bot
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS client_order_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_bot_client_order_id ON orders (bot_id, client_order_id)
+    WHERE client_order_id IS NOT NULL;
+
+ALTER TABLE trades ADD COLUMN IF NOT EXISTS client_order_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trades_bot_client_order_id ON trades (bot_id, client_order_id)
+    WHERE client_order_id IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Journal of order status transitions and fills backing OrderService.StreamOrderUpdates
+CREATE TABLE IF NOT EXISTS order_events (
+    sequence BIGSERIAL PRIMARY KEY,
+    bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL,
+    event_type TEXT NOT NULL, -- 'ORDER_UPDATE_STATUS' or 'ORDER_UPDATE_FILL'
+    payload BYTEA NOT NULL,   -- serialized trading.OrderUpdate
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_events_bot_id_sequence ON order_events (bot_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_order_events_created_at ON order_events (created_at);
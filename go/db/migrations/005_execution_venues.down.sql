@@ -0,0 +1,5 @@
+ALTER TABLE bots DROP COLUMN IF EXISTS is_live;
+
+DROP INDEX IF EXISTS idx_orders_venue_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS venue_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS venue;
@@ -0,0 +1,7 @@
+-- Where each order was routed, and whether a bot trades on the live exchange
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS venue TEXT;            -- 'paper' or 'coinbase'
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS venue_order_id TEXT;   -- id assigned by the venue
+
+CREATE INDEX IF NOT EXISTS idx_orders_venue_order_id ON orders (venue, venue_order_id) WHERE venue_order_id IS NOT NULL;
+
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS is_live BOOLEAN DEFAULT FALSE;
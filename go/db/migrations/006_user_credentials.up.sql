@@ -0,0 +1,15 @@
+-- Exchange API keys per user, envelope-encrypted: each row has its own data key
+-- (wrapped_key, sealed with the master key) that seals the secret (ciphertext).
+CREATE TABLE IF NOT EXISTS user_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    venue TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    api_key_masked TEXT NOT NULL,
+    ciphertext BYTEA NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    rotated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_credentials_user_id_venue ON user_credentials (user_id, venue);
@@ -0,0 +1 @@
+ALTER TABLE bots DROP COLUMN IF EXISTS description;
@@ -0,0 +1,2 @@
+-- BotService.UpdateBot persists a free-form description
+ALTER TABLE bots ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
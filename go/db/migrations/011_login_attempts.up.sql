@@ -0,0 +1,20 @@
+-- Consecutive failed logins per key ("user:<name>" or "ip:<address>"), used for
+-- backoff and lockout. A successful login clears the user's counter.
+CREATE TABLE IF NOT EXISTS login_counters (
+    key TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Audit trail of login attempts.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    username TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    success BOOLEAN NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_username ON login_attempts (username, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts (ip, created_at);
@@ -0,0 +1 @@
+ALTER TABLE bots ALTER COLUMN account_value SET DEFAULT 1000003;
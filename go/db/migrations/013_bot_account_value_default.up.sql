@@ -0,0 +1,4 @@
+-- Bots created without an account value start with the same balance as the
+-- orchestrator's INITIAL_ACCOUNT_VALUE default. 001 and the bot registry's own
+-- CREATE TABLE disagreed (1000003 vs 1000008).
+ALTER TABLE bots ALTER COLUMN account_value SET DEFAULT 1000000;
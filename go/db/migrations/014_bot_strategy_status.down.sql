@@ -0,0 +1,3 @@
+ALTER TABLE bots
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS strategy_status;
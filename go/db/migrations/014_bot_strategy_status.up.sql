@@ -0,0 +1,5 @@
+-- A bot's strategy status survives restarts so bots that could not be resumed stay
+-- FAILED, with the reason, until they are started again.
+ALTER TABLE bots
+    ADD COLUMN IF NOT EXISTS strategy_status TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
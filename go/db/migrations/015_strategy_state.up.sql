@@ -0,0 +1,9 @@
+-- The latest checkpoint of each bot's strategy, restored when the bot starts again.
+-- version is the strategy's own state format, so newer code can read older state.
+CREATE TABLE IF NOT EXISTS strategy_state (
+    bot_id UUID PRIMARY KEY REFERENCES bots(id) ON DELETE CASCADE,
+    strategy_type TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    state JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
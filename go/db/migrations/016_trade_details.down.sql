@@ -0,0 +1,9 @@
+ALTER TABLE trades ALTER COLUMN executed_at DROP NOT NULL;
+DROP INDEX IF EXISTS idx_trades_order_id;
+ALTER TABLE trades
+    DROP COLUMN IF EXISTS liquidity,
+    DROP COLUMN IF EXISTS unrealized_pnl,
+    DROP COLUMN IF EXISTS realized_pnl,
+    DROP COLUMN IF EXISTS commission,
+    DROP COLUMN IF EXISTS strategy_id,
+    DROP COLUMN IF EXISTS order_id;
@@ -0,0 +1,14 @@
+-- Trades record the order they filled, the strategy that placed them, their fee,
+-- profit and loss, and whether they added or took liquidity.
+ALTER TABLE trades
+    ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS strategy_id TEXT,
+    ADD COLUMN IF NOT EXISTS commission NUMERIC(20, 8),
+    ADD COLUMN IF NOT EXISTS realized_pnl NUMERIC(20, 8),
+    ADD COLUMN IF NOT EXISTS unrealized_pnl NUMERIC(20, 8),
+    ADD COLUMN IF NOT EXISTS liquidity TEXT CHECK (liquidity IN ('MAKER', 'TAKER'));
+CREATE INDEX IF NOT EXISTS idx_trades_order_id ON trades (order_id);
+-- Every trade has an execution time; rows from before it was always set get their
+-- insertion default.
+UPDATE trades SET executed_at = CURRENT_TIMESTAMP WHERE executed_at IS NULL;
+ALTER TABLE trades ALTER COLUMN executed_at SET NOT NULL;
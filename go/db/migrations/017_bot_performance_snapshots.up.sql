@@ -0,0 +1,11 @@
+-- Bots' marked equity, cash and profit over time, recorded periodically by the bot
+-- status feed for performance history and exports.
+CREATE TABLE IF NOT EXISTS bot_performance_snapshots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bot_id UUID NOT NULL REFERENCES bots(id) ON DELETE CASCADE,
+    snapshot_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    equity_value NUMERIC(20, 8) NOT NULL,
+    cash_balance NUMERIC(20, 8) NOT NULL,
+    pnl NUMERIC(20, 8) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_bot_performance_snapshots_bot_time ON bot_performance_snapshots (bot_id, snapshot_time);
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExecutionVenue places and manages orders at an execution destination: the paper
+// simulator or a real exchange.
+type ExecutionVenue interface {
+	// Name identifies the venue in orders.venue, e.g. "paper" or "coinbase".
+	Name() string
+	// PlaceOrder submits an order. The order id is sent as the venue's client order id.
+	PlaceOrder(ctx context.Context, order *pb.Order) (*VenueOrderAck, error)
+	// CancelOrder cancels an order previously acknowledged by PlaceOrder.
+	CancelOrder(ctx context.Context, order *pb.Order) error
+	// Fills returns executions reported by the venue at or after since, oldest first.
+	Fills(ctx context.Context, since time.Time) ([]VenueFill, error)
+}
+
+// VenueOrderAck is a venue's response to a placed order.
+type VenueOrderAck struct {
+	VenueOrderID string
+	Status       pb.OrderStatus
+	Fills        []VenueFill // executions that happened while placing the order
+}
+
+// VenueFill is one execution reported by a venue.
+type VenueFill struct {
+	Venue        string
+	TradeID      string // the venue's execution id
+	VenueOrderID string
+	OrderID      string // internal order id when the venue reports it, otherwise resolved by VenueOrderID
+	Symbol       string
+	Side         pb.OrderSide
+	Quantity     float64
+	Price        float64
+	Commission   float64
+	Liquidity    string // "MAKER" or "TAKER" when known
+	Ts           time.Time
+}
+
+// fillTradeNamespace derives stable trade ids from venue execution ids, so a fill seen
+// twice (replayed by a poller, or reported both synchronously and asynchronously) is
+// recorded once.
+var fillTradeNamespace = uuid.MustParse("6f1d8a52-3c0e-4b7a-9d2e-5a8c1b4e7f30")
+
+func fillTradeID(venue, venueTradeID string) string {
+	return uuid.NewSHA1(fillTradeNamespace, []byte(venue+":"+venueTradeID)).String()
+}
+
+// errLiveTradingDisabled is returned when a live bot submits an order but no live venue is configured.
+var errLiveTradingDisabled = errors.New("live trading is not enabled")
+
+// venueRouter picks the venue for a bot's orders: the exchange for live bots and the
+// simulator for paper bots.
+type venueRouter struct {
+	paper *paperVenue
+	live  ExecutionVenue // nil when live trading is disabled
+}
+
+func newVenueRouter(paper *paperVenue, live ExecutionVenue) *venueRouter {
+	return &venueRouter{paper: paper, live: live}
+}
+
+func (r *venueRouter) route(bot *pb.Bot) (ExecutionVenue, error) {
+	if bot != nil && bot.IsLive {
+		if r.live == nil {
+			return nil, errLiveTradingDisabled
+		}
+		return r.live, nil
+	}
+	return r.paper, nil
+}
+
+// byName returns the venue an existing order was routed to.
+func (r *venueRouter) byName(name string) (ExecutionVenue, bool) {
+	switch {
+	case name == r.paper.Name():
+		return r.paper, true
+	case r.live != nil && name == r.live.Name():
+		return r.live, true
+	}
+	return nil, false
+}
+
+// submitToVenue routes a newly created order and records the venue's acknowledgement.
+// Orders are only created in the database before routing, so a rejected order is kept
+// with status REJECTED rather than disappearing.
+func (s *OrderServiceServer) submitToVenue(ctx context.Context, order *pb.Order) (*pb.Order, error) {
+	if s.venues == nil {
+		return order, nil
+	}
+	var bot *pb.Bot
+	if s.bots != nil {
+		bot, _ = s.bots(order.BotId)
+	}
+	venue, err := s.venues.route(bot)
+	if err != nil {
+		rejected, uerr := s.dbclient.SetOrderVenue(ctx, order.Id, "", "", pb.OrderStatus_REJECTED)
+		if uerr == nil {
+			publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: rejected})
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "order rejected: %v", err)
+	}
+
+	ack, err := venue.PlaceOrder(ctx, order)
+	if err != nil {
+		log.Warn().Err(err).Str("order_id", order.Id).Str("venue", venue.Name()).Msg("venue rejected order")
+		rejected, uerr := s.dbclient.SetOrderVenue(ctx, order.Id, venue.Name(), "", pb.OrderStatus_REJECTED)
+		if uerr == nil {
+			publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: rejected})
+		}
+		return nil, status.Errorf(codes.Aborted, "order rejected by %s: %v", venue.Name(), err)
+	}
+	updated, err := s.dbclient.SetOrderVenue(ctx, order.Id, venue.Name(), ack.VenueOrderID, ack.Status)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// The order expired or was canceled while it was being placed, so take back
+		// whatever the venue accepted. Fills it already made are still recorded below.
+		placed := proto.Clone(order).(*pb.Order)
+		placed.Venue, placed.VenueOrderId = venue.Name(), ack.VenueOrderID
+		if err := venue.CancelOrder(ctx, placed); err != nil {
+			log.Error().Err(err).Str("order_id", order.Id).Str("venue", venue.Name()).Msg("failed to cancel order that closed while it was placed")
+		}
+		if updated, err = s.dbclient.GetOrder(ctx, order.Id); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: updated})
+	}
+
+	for _, fill := range ack.Fills {
+		if fill.OrderID == "" {
+			fill.OrderID = order.Id
+		}
+		trade, filled, err := s.applyFill(ctx, fill)
+		if err != nil {
+			log.Error().Err(err).Str("order_id", order.Id).Msg("failed to apply synchronous fill")
+			continue
+		}
+		if filled != nil {
+			updated = filled
+		}
+		if trade != nil {
+			updated.Trades = append(updated.Trades, trade)
+		}
+	}
+	return updated, nil
+}
+
+// orderForFill finds the order a venue fill executed, by our id when the venue echoed
+// it and otherwise by the venue's order id. Fills of orders placed outside the platform
+// return a wrapped pgx.ErrNoRows.
+func (s *OrderServiceServer) orderForFill(ctx context.Context, fill VenueFill) (*pb.Order, error) {
+	if fill.OrderID != "" {
+		return s.dbclient.GetOrder(ctx, fill.OrderID)
+	}
+	return s.dbclient.GetOrderByVenueOrderID(ctx, fill.Venue, fill.VenueOrderID)
+}
+
+// applyFill records a venue execution as a trade, adds it to the order's filled
+// quantity and publishes the fill. Fills that were already recorded, together with
+// their order update, return nil, nil, nil.
+func (s *OrderServiceServer) applyFill(ctx context.Context, fill VenueFill) (*pb.Trade, *pb.Order, error) {
+	order, err := s.orderForFill(ctx, fill)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, fmt.Errorf("fill %s/%s does not match a known order", fill.Venue, fill.TradeID)
+		}
+		return nil, nil, err
+	}
+
+	ts := fill.Ts
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	trade := &pb.Trade{
+		TradeId:             fillTradeID(fill.Venue, fill.TradeID),
+		Symbol:              order.Symbol,
+		Side:                order.Side.String(),
+		Quantity:            fill.Quantity,
+		Price:               fill.Price,
+		ExecutedAt:          ts.UnixNano(),
+		ExecutedAtTimestamp: timestamppb.New(ts),
+		BotId:               order.BotId,
+		OrderId:             order.Id,
+	}
+	if fill.Commission != 0 {
+		trade.Commission = floatToDecimal(fill.Commission)
+	}
+	if fill.Liquidity == "MAKER" || fill.Liquidity == "TAKER" {
+		trade.Liquidity = fill.Liquidity
+	}
+	updated, err := s.dbclient.RecordFill(ctx, order.Id, trade)
+	if err != nil {
+		if errors.Is(err, errFillRecorded) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: updated, Fill: trade, Ts: ts})
+	return trade, updated, nil
+}
+
+// cancelAtVenue cancels an order at the venue it was routed to, if any.
+func (s *OrderServiceServer) cancelAtVenue(ctx context.Context, order *pb.Order) error {
+	if s.venues == nil || order.Venue == "" {
+		return nil
+	}
+	venue, ok := s.venues.byName(order.Venue)
+	if !ok {
+		return fmt.Errorf("order was routed to unknown venue %q", order.Venue)
+	}
+	return venue.CancelOrder(ctx, order)
+}
+
+// runVenueExpiry cancels expired orders at their venue. The expirer only changes the
+// order's status in the database, so without this an expired GTD or DAY order would
+// keep working on the exchange.
+func (s *OrderServiceServer) runVenueExpiry(ctx context.Context) {
+	id, ch := s.bus.Subscribe(1024)
+	defer s.bus.Unsubscribe(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			if evt.Type != EventOrderUpdate {
+				continue
+			}
+			oe := evt.Data.(OrderEvent)
+			if oe.Fill != nil || oe.Order.GetStatus() != pb.OrderStatus_EXPIRED || oe.Order.GetVenue() == "" {
+				continue
+			}
+			if err := s.cancelAtVenue(ctx, oe.Order); err != nil {
+				log.Warn().Err(err).Str("order_id", oe.Order.Id).Str("venue", oe.Order.Venue).Msg("failed to cancel expired order at venue")
+			}
+		}
+	}
+}
+
+// fillPoller pulls executions from a venue that does not push them and applies each
+// one through OrderService.
+type fillPoller struct {
+	venue    ExecutionVenue
+	orders   *OrderServiceServer
+	interval time.Duration
+	since    time.Time
+	seen     map[string]time.Time // venue trade id -> execution time, pruned as since advances
+}
+
+func newFillPoller(venue ExecutionVenue, orders *OrderServiceServer, interval time.Duration) *fillPoller {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return &fillPoller{venue: venue, orders: orders, interval: interval, since: time.Now().Add(-time.Minute), seen: make(map[string]time.Time)}
+}
+
+func (p *fillPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.poll(ctx)
+		}
+	}
+}
+
+func (p *fillPoller) poll(ctx context.Context) {
+	fills, err := p.venue.Fills(ctx, p.since)
+	if err != nil {
+		log.Warn().Err(err).Str("venue", p.venue.Name()).Msg("fill poll failed")
+		return
+	}
+	for _, fill := range fills {
+		if _, dup := p.seen[fill.TradeID]; dup {
+			continue
+		}
+		if _, _, err := p.orders.applyFill(ctx, fill); err != nil {
+			log.Warn().Err(err).Str("venue", p.venue.Name()).Str("venue_trade_id", fill.TradeID).Msg("failed to apply polled fill")
+			continue
+		}
+		p.seen[fill.TradeID] = fill.Ts
+		if fill.Ts.After(p.since) {
+			p.since = fill.Ts
+		}
+	}
+	// Fills at exactly p.since are requested again next time, so keep those ids.
+	for id, ts := range p.seen {
+		if ts.Before(p.since) {
+			delete(p.seen, id)
+		}
+	}
+}
+
+// formatDecimal renders a DecimalValue as a plain decimal string without trailing zeros.
+func formatDecimal(dv *pb.DecimalValue) string {
+	s := decimalValueToNumeric(dv)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
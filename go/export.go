@@ -0,0 +1,577 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// exportPageSize is how many rows an export reads at a time. Each page is encoded
+	// and sent before the next is read, and is one Parquet row group.
+	exportPageSize = 1000
+	// exportChunkSize is the most data sent in one ExportChunk or HTTP write.
+	exportChunkSize = 64 << 10
+)
+
+// What can be exported; also the HTTP path under /export/.
+const (
+	exportTrades      = "trades"
+	exportOrders      = "orders"
+	exportPerformance = "performance"
+)
+
+// exportStore reads history a page at a time, oldest first. DBService implements it.
+type exportStore interface {
+	ListTradesSince(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*pb.Trade, error)
+	ListOrdersSince(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*pb.Order, error)
+	ListPerformanceSnapshots(ctx context.Context, f historyFilter, after *keysetCursor, limit int) ([]*performanceSnapshot, error)
+}
+
+type exportServiceServer struct {
+	pb.UnimplementedExportServiceServer
+	store exportStore
+	bots  botLookup // bot ownership for authorization (injected)
+	// authHTTP authenticates downloads as the named ExportService method (injected)
+	authHTTP func(r *http.Request, fullMethod string) (context.Context, error)
+}
+
+func newExportServiceServer(store exportStore) *exportServiceServer {
+	return &exportServiceServer{store: store}
+}
+
+// exportRow is one exported row. Its fields carry json and parquet tags; CSV columns
+// come from csvHeader and csvRecord, which must agree.
+type exportRow interface {
+	csvHeader() []string
+	csvRecord() []string
+}
+
+type tradeExportRow struct {
+	TradeID       string    `json:"trade_id" parquet:"trade_id"`
+	BotID         string    `json:"bot_id" parquet:"bot_id"`
+	OrderID       string    `json:"order_id" parquet:"order_id"`
+	StrategyID    string    `json:"strategy_id" parquet:"strategy_id"`
+	ClientOrderID string    `json:"client_order_id" parquet:"client_order_id"`
+	Symbol        string    `json:"symbol" parquet:"symbol"`
+	Side          string    `json:"side" parquet:"side"`
+	Quantity      float64   `json:"quantity" parquet:"quantity"`
+	Price         float64   `json:"price" parquet:"price"`
+	Commission    *float64  `json:"commission" parquet:"commission,optional"`
+	RealizedPnL   *float64  `json:"realized_pnl" parquet:"realized_pnl,optional"`
+	UnrealizedPnL *float64  `json:"unrealized_pnl" parquet:"unrealized_pnl,optional"`
+	Liquidity     string    `json:"liquidity" parquet:"liquidity"`
+	ExecutedAt    time.Time `json:"executed_at" parquet:"executed_at"`
+}
+
+func newTradeExportRow(t *pb.Trade) tradeExportRow {
+	return tradeExportRow{
+		TradeID:       t.TradeId,
+		BotID:         t.BotId,
+		OrderID:       t.OrderId,
+		StrategyID:    t.StrategyId,
+		ClientOrderID: t.ClientOrderId,
+		Symbol:        t.Symbol,
+		Side:          t.Side,
+		Quantity:      t.Quantity,
+		Price:         t.Price,
+		Commission:    optionalFloat(t.Commission),
+		RealizedPnL:   optionalFloat(t.PnlRealized),
+		UnrealizedPnL: optionalFloat(t.PnlUnrealized),
+		Liquidity:     t.Liquidity,
+		ExecutedAt:    t.ExecutedAtTimestamp.AsTime(),
+	}
+}
+
+func (tradeExportRow) csvHeader() []string {
+	return []string{"trade_id", "bot_id", "order_id", "strategy_id", "client_order_id", "symbol", "side",
+		"quantity", "price", "commission", "realized_pnl", "unrealized_pnl", "liquidity", "executed_at"}
+}
+
+func (r tradeExportRow) csvRecord() []string {
+	return []string{r.TradeID, r.BotID, r.OrderID, r.StrategyID, r.ClientOrderID, r.Symbol, r.Side,
+		formatFloat(r.Quantity), formatFloat(r.Price), formatOptional(r.Commission), formatOptional(r.RealizedPnL),
+		formatOptional(r.UnrealizedPnL), r.Liquidity, formatTime(r.ExecutedAt)}
+}
+
+type orderExportRow struct {
+	OrderID           string     `json:"order_id" parquet:"order_id"`
+	BotID             string     `json:"bot_id" parquet:"bot_id"`
+	ClientOrderID     string     `json:"client_order_id" parquet:"client_order_id"`
+	Symbol            string     `json:"symbol" parquet:"symbol"`
+	Side              string     `json:"side" parquet:"side"`
+	Type              string     `json:"type" parquet:"type"`
+	Status            string     `json:"status" parquet:"status"`
+	TimeInForce       string     `json:"time_in_force" parquet:"time_in_force"`
+	QuantityRequested float64    `json:"quantity_requested" parquet:"quantity_requested"`
+	QuantityFilled    float64    `json:"quantity_filled" parquet:"quantity_filled"`
+	LimitPrice        *float64   `json:"limit_price" parquet:"limit_price,optional"`
+	StopPrice         *float64   `json:"stop_price" parquet:"stop_price,optional"`
+	Venue             string     `json:"venue" parquet:"venue"`
+	VenueOrderID      string     `json:"venue_order_id" parquet:"venue_order_id"`
+	CreatedAt         time.Time  `json:"created_at" parquet:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" parquet:"updated_at"`
+	ExpireAt          *time.Time `json:"expire_at" parquet:"expire_at,optional"`
+}
+
+func newOrderExportRow(o *pb.Order) orderExportRow {
+	row := orderExportRow{
+		OrderID:           o.Id,
+		BotID:             o.BotId,
+		ClientOrderID:     o.ClientOrderId,
+		Symbol:            o.Symbol,
+		Side:              o.Side.String(),
+		Type:              o.Type.String(),
+		Status:            o.Status.String(),
+		TimeInForce:       o.TimeInForce.String(),
+		QuantityRequested: decimalToFloat(o.QuantityRequested),
+		QuantityFilled:    decimalToFloat(o.QuantityFilled),
+		LimitPrice:        optionalFloat(o.LimitPrice),
+		StopPrice:         optionalFloat(o.StopPrice),
+		Venue:             o.Venue,
+		VenueOrderID:      o.VenueOrderId,
+		CreatedAt:         o.CreatedAt.AsTime(),
+		UpdatedAt:         o.UpdatedAt.AsTime(),
+	}
+	if o.ExpireAt != nil {
+		t := o.ExpireAt.AsTime()
+		row.ExpireAt = &t
+	}
+	return row
+}
+
+func (orderExportRow) csvHeader() []string {
+	return []string{"order_id", "bot_id", "client_order_id", "symbol", "side", "type", "status", "time_in_force",
+		"quantity_requested", "quantity_filled", "limit_price", "stop_price", "venue", "venue_order_id",
+		"created_at", "updated_at", "expire_at"}
+}
+
+func (r orderExportRow) csvRecord() []string {
+	expireAt := ""
+	if r.ExpireAt != nil {
+		expireAt = formatTime(*r.ExpireAt)
+	}
+	return []string{r.OrderID, r.BotID, r.ClientOrderID, r.Symbol, r.Side, r.Type, r.Status, r.TimeInForce,
+		formatFloat(r.QuantityRequested), formatFloat(r.QuantityFilled), formatOptional(r.LimitPrice),
+		formatOptional(r.StopPrice), r.Venue, r.VenueOrderID, formatTime(r.CreatedAt), formatTime(r.UpdatedAt), expireAt}
+}
+
+type performanceExportRow struct {
+	BotID        string    `json:"bot_id" parquet:"bot_id"`
+	SnapshotTime time.Time `json:"snapshot_time" parquet:"snapshot_time"`
+	EquityValue  float64   `json:"equity_value" parquet:"equity_value"`
+	CashBalance  float64   `json:"cash_balance" parquet:"cash_balance"`
+	PnL          float64   `json:"pnl" parquet:"pnl"`
+}
+
+func newPerformanceExportRow(s *performanceSnapshot) performanceExportRow {
+	return performanceExportRow{
+		BotID:        s.BotID,
+		SnapshotTime: s.SnapshotTime.AsTime(),
+		EquityValue:  decimalToFloat(s.EquityValue),
+		CashBalance:  decimalToFloat(s.CashBalance),
+		PnL:          decimalToFloat(s.Pnl),
+	}
+}
+
+func (performanceExportRow) csvHeader() []string {
+	return []string{"bot_id", "snapshot_time", "equity_value", "cash_balance", "pnl"}
+}
+
+func (r performanceExportRow) csvRecord() []string {
+	return []string{r.BotID, formatTime(r.SnapshotTime), formatFloat(r.EquityValue), formatFloat(r.CashBalance), formatFloat(r.PnL)}
+}
+
+// optionalFloat is nil for an unset decimal, so it is exported as empty or null.
+func optionalFloat(dv *pb.DecimalValue) *float64 {
+	if dv == nil {
+		return nil
+	}
+	f := decimalToFloat(dv)
+	return &f
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatOptional(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// pageRows returns a function that yields the rows fetch selects a page at a time and
+// an empty page when there are no more. key is the cursor position of a fetched item.
+func pageRows[S any, T exportRow](fetch func(after *keysetCursor, limit int) ([]S, error), key func(S) keysetCursor, row func(S) T) func() ([]T, error) {
+	var after *keysetCursor
+	done := false
+	return func() ([]T, error) {
+		if done {
+			return nil, nil
+		}
+		items, err := fetch(after, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		done = len(items) < exportPageSize
+		if len(items) > 0 {
+			c := key(items[len(items)-1])
+			after = &c
+		}
+		rows := make([]T, len(items))
+		for i, item := range items {
+			rows[i] = row(item)
+		}
+		return rows, nil
+	}
+}
+
+// writeExport encodes the rows next yields to w in format until it yields an empty page.
+func writeExport[T exportRow](w io.Writer, format pb.ExportFormat, next func() ([]T, error)) error {
+	switch format {
+	case pb.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, pb.ExportFormat_CSV:
+		cw := csv.NewWriter(w)
+		var zero T
+		if err := cw.Write(zero.csvHeader()); err != nil {
+			return err
+		}
+		for {
+			rows, err := next()
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				cw.Flush()
+				return cw.Error()
+			}
+			for _, r := range rows {
+				if err := cw.Write(r.csvRecord()); err != nil {
+					return err
+				}
+			}
+		}
+	case pb.ExportFormat_JSONL:
+		enc := json.NewEncoder(w)
+		for {
+			rows, err := next()
+			if err != nil || len(rows) == 0 {
+				return err
+			}
+			for _, r := range rows {
+				if err := enc.Encode(r); err != nil {
+					return err
+				}
+			}
+		}
+	case pb.ExportFormat_PARQUET:
+		pw := parquet.NewGenericWriter[T](w)
+		for {
+			rows, err := next()
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return pw.Close()
+			}
+			if _, err := pw.Write(rows); err != nil {
+				return err
+			}
+			// One row group per page keeps memory bounded
+			if err := pw.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("unsupported export format %v", format)
+}
+
+// exportFileType returns the file extension and content type of an export format.
+func exportFileType(format pb.ExportFormat) (ext, contentType string) {
+	switch format {
+	case pb.ExportFormat_JSONL:
+		return "jsonl", "application/x-ndjson"
+	case pb.ExportFormat_PARQUET:
+		return "parquet", "application/vnd.apache.parquet"
+	}
+	return "csv", "text/csv"
+}
+
+// exportFilterFromRequest validates an export request. Without user_id or bot_id it
+// exports the caller's own history.
+func exportFilterFromRequest(ctx context.Context, kind string, req *pb.ExportRequest) (historyFilter, error) {
+	f := historyFilter{UserID: req.UserId, BotID: req.BotId, Symbol: req.Symbol}
+	if _, ok := pb.ExportFormat_name[int32(req.Format)]; !ok {
+		return f, status.Error(codes.InvalidArgument, "unknown format")
+	}
+	if kind == exportPerformance && f.Symbol != "" {
+		return f, status.Error(codes.InvalidArgument, "symbol does not apply to performance exports")
+	}
+	for _, id := range []string{f.UserID, f.BotID} {
+		if _, err := uuid.Parse(id); id != "" && err != nil {
+			return f, status.Error(codes.InvalidArgument, "user_id and bot_id must be UUIDs")
+		}
+	}
+	if req.StartTime != nil {
+		f.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		f.End = req.EndTime.AsTime()
+	}
+	if !f.Start.IsZero() && !f.End.IsZero() && !f.Start.Before(f.End) {
+		return f, status.Error(codes.InvalidArgument, "start_time must be before end_time")
+	}
+	defaultToOwnHistory(ctx, &f)
+	return f, nil
+}
+
+// export writes the kind of history f selects to w in format.
+func (s *exportServiceServer) export(ctx context.Context, kind string, f historyFilter, format pb.ExportFormat, w io.Writer) error {
+	switch kind {
+	case exportTrades:
+		return writeExport(w, format, pageRows(
+			func(after *keysetCursor, limit int) ([]*pb.Trade, error) {
+				return s.store.ListTradesSince(ctx, f, after, limit)
+			},
+			func(t *pb.Trade) keysetCursor { return keysetCursor{At: t.ExecutedAtTimestamp.AsTime(), ID: t.TradeId} },
+			newTradeExportRow))
+	case exportOrders:
+		return writeExport(w, format, pageRows(
+			func(after *keysetCursor, limit int) ([]*pb.Order, error) {
+				return s.store.ListOrdersSince(ctx, f, after, limit)
+			},
+			func(o *pb.Order) keysetCursor { return keysetCursor{At: o.CreatedAt.AsTime(), ID: o.Id} },
+			newOrderExportRow))
+	case exportPerformance:
+		return writeExport(w, format, pageRows(
+			func(after *keysetCursor, limit int) ([]*performanceSnapshot, error) {
+				return s.store.ListPerformanceSnapshots(ctx, f, after, limit)
+			},
+			func(p *performanceSnapshot) keysetCursor { return keysetCursor{At: p.SnapshotTime.AsTime(), ID: p.ID} },
+			newPerformanceExportRow))
+	}
+	return status.Errorf(codes.NotFound, "unknown export %q", kind)
+}
+
+// chunkWriter buffers writes and passes them to send exportChunkSize at a time.
+type chunkWriter struct {
+	send func([]byte) error
+	buf  []byte
+	sent bool
+}
+
+func newChunkWriter(send func([]byte) error) *chunkWriter {
+	return &chunkWriter{send: send, buf: make([]byte, 0, exportChunkSize)}
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		k := min(len(p), exportChunkSize-len(w.buf))
+		w.buf = append(w.buf, p[:k]...)
+		p = p[k:]
+		if len(w.buf) == exportChunkSize {
+			if err := w.flush(); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (w *chunkWriter) flush() error {
+	w.sent = true
+	err := w.send(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+// Close sends what is buffered. An export that wrote nothing still sends one empty
+// chunk, so the receiver gets its filename.
+func (w *chunkWriter) Close() error {
+	if len(w.buf) == 0 && w.sent {
+		return nil
+	}
+	return w.flush()
+}
+
+func exportFilename(kind string, format pb.ExportFormat) string {
+	ext, _ := exportFileType(format)
+	return fmt.Sprintf("%s-%s.%s", kind, time.Now().UTC().Format("20060102T150405Z"), ext)
+}
+
+// stream runs an export RPC.
+func (s *exportServiceServer) stream(kind string, req *pb.ExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	ctx := stream.Context()
+	f, err := exportFilterFromRequest(ctx, kind, req)
+	if err != nil {
+		return err
+	}
+	if err := authorizeHistoryFilter(ctx, s.bots, f); err != nil {
+		return err
+	}
+	first := &pb.ExportChunk{Filename: exportFilename(kind, req.Format)}
+	_, first.ContentType = exportFileType(req.Format)
+	w := newChunkWriter(func(b []byte) error {
+		chunk := &pb.ExportChunk{Data: b}
+		if first != nil {
+			chunk.Filename, chunk.ContentType = first.Filename, first.ContentType
+			first = nil
+		}
+		return stream.Send(chunk)
+	})
+	if err := s.export(ctx, kind, f, req.Format, w); err != nil {
+		return status.Errorf(codes.Internal, "failed to export %s: %v", kind, err)
+	}
+	return w.Close()
+}
+
+func (s *exportServiceServer) ExportTrades(req *pb.ExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	return s.stream(exportTrades, req, stream)
+}
+
+func (s *exportServiceServer) ExportOrders(req *pb.ExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	return s.stream(exportOrders, req, stream)
+}
+
+func (s *exportServiceServer) ExportPerformance(req *pb.ExportRequest, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	return s.stream(exportPerformance, req, stream)
+}
+
+// exportMethods is the ExportService method whose access policy each download follows.
+var exportMethods = map[string]string{
+	exportTrades:      "/trading.ExportService/ExportTrades",
+	exportOrders:      "/trading.ExportService/ExportOrders",
+	exportPerformance: "/trading.ExportService/ExportPerformance",
+}
+
+// exportRequestFromQuery reads an ExportRequest from download query parameters.
+func exportRequestFromQuery(q map[string][]string) (*pb.ExportRequest, error) {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	req := &pb.ExportRequest{UserId: get("user_id"), BotId: get("bot_id"), Symbol: get("symbol")}
+	if v := get("format"); v != "" {
+		format, ok := pb.ExportFormat_value[strings.ToUpper(v)]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "format must be csv, jsonl or parquet")
+		}
+		req.Format = pb.ExportFormat(format)
+	}
+	for _, p := range []struct {
+		name string
+		dst  **timestamppb.Timestamp
+	}{{"start", &req.StartTime}, {"end", &req.EndTime}} {
+		if v := get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 time", p.name)
+			}
+			*p.dst = timestamppb.New(t)
+		}
+	}
+	return req, nil
+}
+
+// ServeHTTP serves downloads at /export/{trades,orders,performance}. Query parameters
+// are the ExportRequest fields: format, user_id, bot_id, symbol, and start and end as
+// RFC 3339 times. Callers authenticate with the same headers as over gRPC.
+func (s *exportServiceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	kind := strings.TrimPrefix(r.URL.Path, "/export/")
+	method, ok := exportMethods[kind]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	ctx, err := s.authHTTP(r, method)
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	req, err := exportRequestFromQuery(r.URL.Query())
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+	f, err := exportFilterFromRequest(ctx, kind, req)
+	if err == nil {
+		err = authorizeHistoryFilter(ctx, s.bots, f)
+	}
+	if err != nil {
+		writeStatusError(w, err)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	cw := newChunkWriter(func(b []byte) error {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	_, contentType := exportFileType(req.Format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFilename(kind, req.Format)))
+	err = s.export(ctx, kind, f, req.Format, cw)
+	if err == nil {
+		err = cw.Close()
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("export", kind).Msg("export download failed")
+		if !cw.sent {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, "export failed", http.StatusInternalServerError)
+			return
+		}
+		// Part of the file is already sent; drop the connection so it is not taken as complete
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// writeStatusError writes a gRPC status error as the matching HTTP error.
+func writeStatusError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	}
+	http.Error(w, st.Message(), code)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memExportStore serves trades a page at a time like DBService, ignoring filters.
+type memExportStore struct {
+	trades []*pb.Trade // oldest first
+	pages  int
+}
+
+func newMemExportStore(n int) *memExportStore {
+	s := &memExportStore{}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		at := start.Add(time.Duration(i/2) * time.Minute) // pairs share a time
+		s.trades = append(s.trades, &pb.Trade{
+			TradeId: uuid.NewString(), BotId: "bot", Symbol: "BTC-USD", Side: "BUY", Quantity: 0.5, Price: 100 + float64(i),
+			ExecutedAtTimestamp: timestamppb.New(at),
+		})
+	}
+	sort.Slice(s.trades, func(i, j int) bool {
+		a, b := s.trades[i], s.trades[j]
+		if !a.ExecutedAtTimestamp.AsTime().Equal(b.ExecutedAtTimestamp.AsTime()) {
+			return a.ExecutedAtTimestamp.AsTime().Before(b.ExecutedAtTimestamp.AsTime())
+		}
+		return a.TradeId < b.TradeId
+	})
+	s.trades[0].Commission = floatToDecimal(0.25)
+	return s
+}
+
+func (s *memExportStore) ListTradesSince(_ context.Context, _ historyFilter, after *keysetCursor, limit int) ([]*pb.Trade, error) {
+	s.pages++
+	i := 0
+	if after != nil {
+		i = sort.Search(len(s.trades), func(i int) bool {
+			at := s.trades[i].ExecutedAtTimestamp.AsTime()
+			return at.After(after.At) || (at.Equal(after.At) && s.trades[i].TradeId > after.ID)
+		})
+	}
+	return s.trades[i:min(i+limit, len(s.trades))], nil
+}
+
+func (s *memExportStore) ListOrdersSince(context.Context, historyFilter, *keysetCursor, int) ([]*pb.Order, error) {
+	return nil, nil
+}
+
+func (s *memExportStore) ListPerformanceSnapshots(context.Context, historyFilter, *keysetCursor, int) ([]*performanceSnapshot, error) {
+	return nil, nil
+}
+
+func TestExportFormats(t *testing.T) {
+	ctx := context.Background()
+	n := 2*exportPageSize + 5
+	store := newMemExportStore(n)
+	s := newExportServiceServer(store)
+
+	var buf bytes.Buffer
+	if err := s.export(ctx, exportTrades, historyFilter{}, pb.ExportFormat_CSV, &buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != n+1 || records[0][0] != "trade_id" || records[1][0] != store.trades[0].TradeId {
+		t.Fatalf("expected a header and %d trades oldest first, got %d records", n, len(records))
+	}
+	if records[1][9] != "0.25" || records[2][9] != "" {
+		t.Errorf("expected commission 0.25 then empty, got %q and %q", records[1][9], records[2][9])
+	}
+	if store.pages != 3 {
+		t.Errorf("expected 3 pages read, got %d", store.pages)
+	}
+
+	buf.Reset()
+	if err := s.export(ctx, exportTrades, historyFilter{}, pb.ExportFormat_JSONL, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := 0
+	for sc := bufio.NewScanner(&buf); sc.Scan(); lines++ {
+		var row tradeExportRow
+		if err := json.Unmarshal(sc.Bytes(), &row); err != nil {
+			t.Fatal(err)
+		}
+		if row.TradeID != store.trades[lines].TradeId {
+			t.Fatalf("line %d: expected trade %s, got %s", lines, store.trades[lines].TradeId, row.TradeID)
+		}
+	}
+	if lines != n {
+		t.Errorf("expected %d lines, got %d", n, lines)
+	}
+
+	buf.Reset()
+	if err := s.export(ctx, exportTrades, historyFilter{}, pb.ExportFormat_PARQUET, &buf); err != nil {
+		t.Fatal(err)
+	}
+	file, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(file.RowGroups()); got != 3 {
+		t.Errorf("expected one row group per page, got %d", got)
+	}
+	rows, err := parquet.Read[tradeExportRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != n || rows[n-1].TradeID != store.trades[n-1].TradeId || !rows[0].ExecutedAt.Equal(store.trades[0].ExecutedAtTimestamp.AsTime()) {
+		t.Errorf("parquet rows do not match the trades")
+	}
+	if rows[0].Commission == nil || *rows[0].Commission != 0.25 || rows[1].Commission != nil {
+		t.Errorf("expected commission 0.25 then null, got %v and %v", rows[0].Commission, rows[1].Commission)
+	}
+}
+
+type exportStream struct {
+	fakeServerStream
+	chunks []*pb.ExportChunk
+}
+
+func (s *exportStream) Send(c *pb.ExportChunk) error {
+	c.Data = append([]byte(nil), c.Data...)
+	s.chunks = append(s.chunks, c)
+	return nil
+}
+
+func TestExportStreamChunks(t *testing.T) {
+	bots := func(id string) (*pb.Bot, bool) { return &pb.Bot{BotId: id, UserId: "alice"}, true }
+	s := newExportServiceServer(newMemExportStore(3 * exportPageSize))
+	s.bots = bots
+	alice := withCaller(context.Background(), caller{UserID: "alice", Role: roleUser})
+	botID := uuid.NewString()
+
+	stream := &exportStream{fakeServerStream: fakeServerStream{ctx: alice}}
+	if err := s.ExportTrades(&pb.ExportRequest{BotId: botID, Format: pb.ExportFormat_JSONL}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.chunks) < 2 {
+		t.Fatalf("expected the export in several chunks, got %d", len(stream.chunks))
+	}
+	var data []byte
+	for i, c := range stream.chunks {
+		if len(c.Data) > exportChunkSize {
+			t.Errorf("chunk %d is %d bytes", i, len(c.Data))
+		}
+		if named := c.Filename != ""; named != (i == 0) {
+			t.Errorf("chunk %d: unexpected filename %q", i, c.Filename)
+		}
+		data = append(data, c.Data...)
+	}
+	if first := stream.chunks[0]; first.ContentType != "application/x-ndjson" || !bytes.HasSuffix([]byte(first.Filename), []byte(".jsonl")) {
+		t.Errorf("unexpected first chunk metadata %q %q", first.Filename, first.ContentType)
+	}
+	if got := bytes.Count(data, []byte("\n")); got != 3*exportPageSize {
+		t.Errorf("expected %d lines, got %d", 3*exportPageSize, got)
+	}
+
+	// An empty export still names the file
+	stream = &exportStream{fakeServerStream: fakeServerStream{ctx: alice}}
+	if err := s.ExportOrders(&pb.ExportRequest{BotId: botID, Format: pb.ExportFormat_JSONL}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.chunks) != 1 || stream.chunks[0].Filename == "" || len(stream.chunks[0].Data) != 0 {
+		t.Errorf("expected one empty named chunk, got %v", stream.chunks)
+	}
+
+	bob := withCaller(context.Background(), caller{UserID: "bob", Role: roleUser})
+	for _, tc := range []struct {
+		req  *pb.ExportRequest
+		code codes.Code
+	}{
+		{&pb.ExportRequest{BotId: botID}, codes.PermissionDenied},
+		{&pb.ExportRequest{UserId: uuid.NewString()}, codes.PermissionDenied},
+		{&pb.ExportRequest{BotId: "nope"}, codes.InvalidArgument},
+		{&pb.ExportRequest{Format: pb.ExportFormat(9)}, codes.InvalidArgument},
+		{&pb.ExportRequest{StartTime: timestamppb.Now(), EndTime: timestamppb.New(time.Now().Add(-time.Hour))}, codes.InvalidArgument},
+	} {
+		err := s.ExportTrades(tc.req, &exportStream{fakeServerStream: fakeServerStream{ctx: bob}})
+		if status.Code(err) != tc.code {
+			t.Errorf("%v: expected %v, got %v", tc.req, tc.code, err)
+		}
+	}
+	err := s.ExportPerformance(&pb.ExportRequest{Symbol: "BTC-USD"}, &exportStream{fakeServerStream: fakeServerStream{ctx: bob}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a symbol filter on performance to be rejected, got %v", err)
+	}
+	// Without user_id or bot_id a user exports their own history
+	f, err := exportFilterFromRequest(bob, exportTrades, &pb.ExportRequest{})
+	if err != nil || f.UserID != "bob" {
+		t.Errorf("expected the caller's own history, got %+v %v", f, err)
+	}
+}
+
+func TestExportHTTP(t *testing.T) {
+	s := newExportServiceServer(newMemExportStore(10))
+	s.bots = func(id string) (*pb.Bot, bool) { return &pb.Bot{BotId: id, UserId: "alice"}, true }
+	s.authHTTP = func(r *http.Request, fullMethod string) (context.Context, error) {
+		if fullMethod != "/trading.ExportService/ExportTrades" && fullMethod != "/trading.ExportService/ExportOrders" {
+			t.Errorf("unexpected method %s", fullMethod)
+		}
+		if r.Header.Get("Authorization") != "Bearer alice" {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		}
+		return withCaller(r.Context(), caller{UserID: "alice", Role: roleUser}), nil
+	}
+	get := func(target string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if auth {
+			req.Header.Set("Authorization", "Bearer alice")
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec
+	}
+
+	botID := uuid.NewString()
+	rec := get("/export/trades?bot_id="+botID+"&start=2024-01-01T00:00:00Z&end=2025-01-01T00:00:00Z", true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("expected text/csv, got %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !bytes.HasPrefix([]byte(cd), []byte(`attachment; filename="trades-`)) {
+		t.Errorf("unexpected Content-Disposition %q", cd)
+	}
+	if lines := bytes.Count(rec.Body.Bytes(), []byte("\n")); lines != 11 {
+		t.Errorf("expected a header and 10 rows, got %d lines", lines)
+	}
+	if rec := get("/export/orders?format=parquet", true); rec.Code != http.StatusOK || !bytes.HasPrefix(rec.Body.Bytes(), []byte("PAR1")) {
+		t.Errorf("expected a parquet file, got %d", rec.Code)
+	}
+
+	for target, code := range map[string]int{
+		"/export/trades?format=xlsx":                 http.StatusBadRequest,
+		"/export/trades?start=tomorrow":              http.StatusBadRequest,
+		"/export/positions":                          http.StatusNotFound,
+		"/export/trades?user_id=" + uuid.NewString(): http.StatusForbidden,
+	} {
+		if rec := get(target, true); rec.Code != code {
+			t.Errorf("%s: expected %d, got %d", target, code, rec.Code)
+		}
+	}
+	if rec := get("/export/trades", false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", rec.Code)
+	}
+}
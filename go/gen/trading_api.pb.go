@@ -133,6 +133,7 @@ const (
 	OrderStatus_FILLED                   OrderStatus = 4
 	OrderStatus_CANCELED                 OrderStatus = 5
 	OrderStatus_REJECTED                 OrderStatus = 6
+	OrderStatus_EXPIRED                  OrderStatus = 7
 )
 
 // Enum value maps for OrderStatus.
@@ -145,6 +146,7 @@ var (
 		4: "FILLED",
 		5: "CANCELED",
 		6: "REJECTED",
+		7: "EXPIRED",
 	}
 	OrderStatus_value = map[string]int32{
 		"ORDER_STATUS_UNSPECIFIED": 0,
@@ -154,6 +156,7 @@ var (
 		"FILLED":                   4,
 		"CANCELED":                 5,
 		"REJECTED":                 6,
+		"EXPIRED":                  7,
 	}
 )
 
@@ -184,6 +187,330 @@ func (OrderStatus) EnumDescriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{2}
 }
 
+type TimeInForce int32
+
+const (
+	TimeInForce_TIME_IN_FORCE_UNSPECIFIED TimeInForce = 0 // treated as GTC
+	TimeInForce_GTC                       TimeInForce = 1 // good-til-canceled
+	TimeInForce_IOC                       TimeInForce = 2 // immediate-or-cancel: unfilled remainder expires at once
+	TimeInForce_FOK                       TimeInForce = 3 // fill-or-kill: expires at once unless fully filled
+	TimeInForce_GTD                       TimeInForce = 4 // good-til-date: expires at expire_at
+	TimeInForce_DAY                       TimeInForce = 5 // expires at the next trading session boundary
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "TIME_IN_FORCE_UNSPECIFIED",
+		1: "GTC",
+		2: "IOC",
+		3: "FOK",
+		4: "GTD",
+		5: "DAY",
+	}
+	TimeInForce_value = map[string]int32{
+		"TIME_IN_FORCE_UNSPECIFIED": 0,
+		"GTC":                       1,
+		"IOC":                       2,
+		"FOK":                       3,
+		"GTD":                       4,
+		"DAY":                       5,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[3].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[3]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{3}
+}
+
+type AlgoType int32
+
+const (
+	AlgoType_ALGO_TYPE_UNSPECIFIED AlgoType = 0
+	AlgoType_TWAP                  AlgoType = 1 // equal slices over the horizon
+	AlgoType_VWAP                  AlgoType = 2 // slices weighted by the traded-volume profile
+	AlgoType_ICEBERG               AlgoType = 3 // one displayed clip at a time
+)
+
+// Enum value maps for AlgoType.
+var (
+	AlgoType_name = map[int32]string{
+		0: "ALGO_TYPE_UNSPECIFIED",
+		1: "TWAP",
+		2: "VWAP",
+		3: "ICEBERG",
+	}
+	AlgoType_value = map[string]int32{
+		"ALGO_TYPE_UNSPECIFIED": 0,
+		"TWAP":                  1,
+		"VWAP":                  2,
+		"ICEBERG":               3,
+	}
+)
+
+func (x AlgoType) Enum() *AlgoType {
+	p := new(AlgoType)
+	*p = x
+	return p
+}
+
+func (x AlgoType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlgoType) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[4].Descriptor()
+}
+
+func (AlgoType) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[4]
+}
+
+func (x AlgoType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlgoType.Descriptor instead.
+func (AlgoType) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{4}
+}
+
+type AlgoStatus int32
+
+const (
+	AlgoStatus_ALGO_STATUS_UNSPECIFIED AlgoStatus = 0
+	AlgoStatus_ALGO_WORKING            AlgoStatus = 1
+	AlgoStatus_ALGO_COMPLETED          AlgoStatus = 2
+	AlgoStatus_ALGO_CANCELED           AlgoStatus = 3
+	AlgoStatus_ALGO_FAILED             AlgoStatus = 4
+)
+
+// Enum value maps for AlgoStatus.
+var (
+	AlgoStatus_name = map[int32]string{
+		0: "ALGO_STATUS_UNSPECIFIED",
+		1: "ALGO_WORKING",
+		2: "ALGO_COMPLETED",
+		3: "ALGO_CANCELED",
+		4: "ALGO_FAILED",
+	}
+	AlgoStatus_value = map[string]int32{
+		"ALGO_STATUS_UNSPECIFIED": 0,
+		"ALGO_WORKING":            1,
+		"ALGO_COMPLETED":          2,
+		"ALGO_CANCELED":           3,
+		"ALGO_FAILED":             4,
+	}
+)
+
+func (x AlgoStatus) Enum() *AlgoStatus {
+	p := new(AlgoStatus)
+	*p = x
+	return p
+}
+
+func (x AlgoStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlgoStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[5].Descriptor()
+}
+
+func (AlgoStatus) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[5]
+}
+
+func (x AlgoStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlgoStatus.Descriptor instead.
+func (AlgoStatus) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{5}
+}
+
+type OrderUpdateType int32
+
+const (
+	OrderUpdateType_ORDER_UPDATE_TYPE_UNSPECIFIED OrderUpdateType = 0
+	OrderUpdateType_ORDER_UPDATE_STATUS           OrderUpdateType = 1 // order was created or changed status
+	OrderUpdateType_ORDER_UPDATE_FILL             OrderUpdateType = 2 // order received an execution
+)
+
+// Enum value maps for OrderUpdateType.
+var (
+	OrderUpdateType_name = map[int32]string{
+		0: "ORDER_UPDATE_TYPE_UNSPECIFIED",
+		1: "ORDER_UPDATE_STATUS",
+		2: "ORDER_UPDATE_FILL",
+	}
+	OrderUpdateType_value = map[string]int32{
+		"ORDER_UPDATE_TYPE_UNSPECIFIED": 0,
+		"ORDER_UPDATE_STATUS":           1,
+		"ORDER_UPDATE_FILL":             2,
+	}
+)
+
+func (x OrderUpdateType) Enum() *OrderUpdateType {
+	p := new(OrderUpdateType)
+	*p = x
+	return p
+}
+
+func (x OrderUpdateType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderUpdateType) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[6].Descriptor()
+}
+
+func (OrderUpdateType) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[6]
+}
+
+func (x OrderUpdateType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderUpdateType.Descriptor instead.
+func (OrderUpdateType) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{6}
+}
+
+type DiscrepancyType int32
+
+const (
+	DiscrepancyType_DISCREPANCY_TYPE_UNSPECIFIED DiscrepancyType = 0
+	DiscrepancyType_ORDER_MISSING_AT_VENUE       DiscrepancyType = 1 // open internally, not open at the venue
+	DiscrepancyType_ORDER_UNKNOWN_INTERNALLY     DiscrepancyType = 2 // open at the venue, no matching internal order
+	DiscrepancyType_ORDER_CLOSED_INTERNALLY      DiscrepancyType = 3 // open at the venue, already terminal internally
+	DiscrepancyType_FILL_MISSING                 DiscrepancyType = 4 // executed at the venue, no internal trade
+	DiscrepancyType_BALANCE_SHORTFALL            DiscrepancyType = 5 // internal position exceeds the venue balance
+)
+
+// Enum value maps for DiscrepancyType.
+var (
+	DiscrepancyType_name = map[int32]string{
+		0: "DISCREPANCY_TYPE_UNSPECIFIED",
+		1: "ORDER_MISSING_AT_VENUE",
+		2: "ORDER_UNKNOWN_INTERNALLY",
+		3: "ORDER_CLOSED_INTERNALLY",
+		4: "FILL_MISSING",
+		5: "BALANCE_SHORTFALL",
+	}
+	DiscrepancyType_value = map[string]int32{
+		"DISCREPANCY_TYPE_UNSPECIFIED": 0,
+		"ORDER_MISSING_AT_VENUE":       1,
+		"ORDER_UNKNOWN_INTERNALLY":     2,
+		"ORDER_CLOSED_INTERNALLY":      3,
+		"FILL_MISSING":                 4,
+		"BALANCE_SHORTFALL":            5,
+	}
+)
+
+func (x DiscrepancyType) Enum() *DiscrepancyType {
+	p := new(DiscrepancyType)
+	*p = x
+	return p
+}
+
+func (x DiscrepancyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiscrepancyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[7].Descriptor()
+}
+
+func (DiscrepancyType) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[7]
+}
+
+func (x DiscrepancyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiscrepancyType.Descriptor instead.
+func (DiscrepancyType) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{7}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0 // treated as CSV
+	ExportFormat_CSV                       ExportFormat = 1
+	ExportFormat_JSONL                     ExportFormat = 2 // one JSON object per line
+	ExportFormat_PARQUET                   ExportFormat = 3
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "CSV",
+		2: "JSONL",
+		3: "PARQUET",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"CSV":                       1,
+		"JSONL":                     2,
+		"PARQUET":                   3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_trading_api_proto_enumTypes[8].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_trading_api_proto_enumTypes[8]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{8}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -888,6 +1215,11 @@ type Order struct {
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Trades            []*Trade               `protobuf:"bytes,13,rep,name=trades,proto3" json:"trades,omitempty"`
+	TimeInForce       TimeInForce            `protobuf:"varint,14,opt,name=time_in_force,json=timeInForce,proto3,enum=trading.TimeInForce" json:"time_in_force,omitempty"`
+	ExpireAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"` // unset for GTC orders
+	ClientOrderId     string                 `protobuf:"bytes,16,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Venue             string                 `protobuf:"bytes,17,opt,name=venue,proto3" json:"venue,omitempty"`                                     // execution venue the order was routed to ("paper", "coinbase")
+	VenueOrderId      string                 `protobuf:"bytes,18,opt,name=venue_order_id,json=venueOrderId,proto3" json:"venue_order_id,omitempty"` // the venue's id for the order
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -1013,6 +1345,41 @@ func (x *Order) GetTrades() []*Trade {
 	return nil
 }
 
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *Order) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *Order) GetVenueOrderId() string {
+	if x != nil {
+		return x.VenueOrderId
+	}
+	return ""
+}
+
 type CreateOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
@@ -1022,6 +1389,9 @@ type CreateOrderRequest struct {
 	Quantity      *DecimalValue          `protobuf:"bytes,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	LimitPrice    *DecimalValue          `protobuf:"bytes,6,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`
 	StopPrice     *DecimalValue          `protobuf:"bytes,7,opt,name=stop_price,json=stopPrice,proto3,oneof" json:"stop_price,omitempty"`
+	TimeInForce   TimeInForce            `protobuf:"varint,8,opt,name=time_in_force,json=timeInForce,proto3,enum=trading.TimeInForce" json:"time_in_force,omitempty"`
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`                   // required for GTD, ignored otherwise
+	ClientOrderId string                 `protobuf:"bytes,10,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // optional, unique per bot; resubmitting returns the original order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1105,6 +1475,27 @@ func (x *CreateOrderRequest) GetStopPrice() *DecimalValue {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_TIME_IN_FORCE_UNSPECIFIED
+}
+
+func (x *CreateOrderRequest) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
 type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
@@ -1160,6 +1551,8 @@ func (x *CancelOrderRequest) GetBotId() string {
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // alternative to order_id, requires bot_id
+	BotId         string                 `protobuf:"bytes,3,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1201,29 +1594,42 @@ func (x *GetOrderRequest) GetOrderId() string {
 	return ""
 }
 
-type OrderBook struct {
+func (x *GetOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+type StreamOrderUpdatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bids          []*OrderBookEntry      `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
-	Asks          []*OrderBookEntry      `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
-	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	FromSequence  int64                  `protobuf:"varint,2,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"` // replay updates after this sequence before going live; 0 streams live updates only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderBook) Reset() {
-	*x = OrderBook{}
+func (x *StreamOrderUpdatesRequest) Reset() {
+	*x = StreamOrderUpdatesRequest{}
 	mi := &file_trading_api_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBook) String() string {
+func (x *StreamOrderUpdatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBook) ProtoMessage() {}
+func (*StreamOrderUpdatesRequest) ProtoMessage() {}
 
-func (x *OrderBook) ProtoReflect() protoreflect.Message {
+func (x *StreamOrderUpdatesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1235,55 +1641,50 @@ func (x *OrderBook) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
-func (*OrderBook) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamOrderUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrderUpdatesRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *OrderBook) GetBids() []*OrderBookEntry {
-	if x != nil {
-		return x.Bids
-	}
-	return nil
-}
-
-func (x *OrderBook) GetAsks() []*OrderBookEntry {
+func (x *StreamOrderUpdatesRequest) GetBotId() string {
 	if x != nil {
-		return x.Asks
+		return x.BotId
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderBook) GetSymbol() string {
+func (x *StreamOrderUpdatesRequest) GetFromSequence() int64 {
 	if x != nil {
-		return x.Symbol
+		return x.FromSequence
 	}
-	return ""
+	return 0
 }
 
-type OrderBookEntry struct {
+type OrderUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
-	Size          float64                `protobuf:"fixed64,2,opt,name=size,proto3" json:"size,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix epoch milliseconds
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // increasing per server; resume with the last sequence received
+	Type          OrderUpdateType        `protobuf:"varint,2,opt,name=type,proto3,enum=trading.OrderUpdateType" json:"type,omitempty"`
+	Order         *Order                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"` // order state after the update
+	Fill          *Trade                 `protobuf:"bytes,4,opt,name=fill,proto3" json:"fill,omitempty"`   // set for ORDER_UPDATE_FILL
+	EventTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderBookEntry) Reset() {
-	*x = OrderBookEntry{}
+func (x *OrderUpdate) Reset() {
+	*x = OrderUpdate{}
 	mi := &file_trading_api_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBookEntry) String() string {
+func (x *OrderUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBookEntry) ProtoMessage() {}
+func (*OrderUpdate) ProtoMessage() {}
 
-func (x *OrderBookEntry) ProtoReflect() protoreflect.Message {
+func (x *OrderUpdate) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1295,53 +1696,75 @@ func (x *OrderBookEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBookEntry.ProtoReflect.Descriptor instead.
-func (*OrderBookEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderUpdate.ProtoReflect.Descriptor instead.
+func (*OrderUpdate) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *OrderBookEntry) GetPrice() float64 {
+func (x *OrderUpdate) GetSequence() int64 {
 	if x != nil {
-		return x.Price
+		return x.Sequence
 	}
 	return 0
 }
 
-func (x *OrderBookEntry) GetSize() float64 {
+func (x *OrderUpdate) GetType() OrderUpdateType {
 	if x != nil {
-		return x.Size
+		return x.Type
 	}
-	return 0
+	return OrderUpdateType_ORDER_UPDATE_TYPE_UNSPECIFIED
 }
 
-func (x *OrderBookEntry) GetTimestamp() int64 {
+func (x *OrderUpdate) GetOrder() *Order {
 	if x != nil {
-		return x.Timestamp
+		return x.Order
 	}
-	return 0
-}
-
-type OrderBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *OrderBookRequest) Reset() {
-	*x = OrderBookRequest{}
+func (x *OrderUpdate) GetFill() *Trade {
+	if x != nil {
+		return x.Fill
+	}
+	return nil
+}
+
+func (x *OrderUpdate) GetEventTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EventTime
+	}
+	return nil
+}
+
+type CreateAlgoOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BotId           string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol          string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side            OrderSide              `protobuf:"varint,3,opt,name=side,proto3,enum=trading.OrderSide" json:"side,omitempty"`
+	Algo            AlgoType               `protobuf:"varint,4,opt,name=algo,proto3,enum=trading.AlgoType" json:"algo,omitempty"`
+	Quantity        *DecimalValue          `protobuf:"bytes,5,opt,name=quantity,proto3" json:"quantity,omitempty"`                                       // total parent quantity
+	LimitPrice      *DecimalValue          `protobuf:"bytes,6,opt,name=limit_price,json=limitPrice,proto3,oneof" json:"limit_price,omitempty"`           // child orders are LIMIT when set, MARKET otherwise
+	DurationMinutes int32                  `protobuf:"varint,7,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"` // TWAP/VWAP horizon
+	NumSlices       int32                  `protobuf:"varint,8,opt,name=num_slices,json=numSlices,proto3" json:"num_slices,omitempty"`                   // TWAP/VWAP slice count (default: one per minute)
+	DisplayQuantity *DecimalValue          `protobuf:"bytes,9,opt,name=display_quantity,json=displayQuantity,proto3" json:"display_quantity,omitempty"`  // ICEBERG clip size
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateAlgoOrderRequest) Reset() {
+	*x = CreateAlgoOrderRequest{}
 	mi := &file_trading_api_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderBookRequest) String() string {
+func (x *CreateAlgoOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBookRequest) ProtoMessage() {}
+func (*CreateAlgoOrderRequest) ProtoMessage() {}
 
-func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAlgoOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1353,50 +1776,95 @@ func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
-func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAlgoOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlgoOrderRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *OrderBookRequest) GetSymbol() string {
+func (x *CreateAlgoOrderRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *CreateAlgoOrderRequest) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-type Trade struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	TradeId             string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
-	Symbol              string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Side                string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
-	Quantity            float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price               float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	ExecutedAt          int64                  `protobuf:"varint,6,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
-	StrategyId          string                 `protobuf:"bytes,7,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
-	BotId               string                 `protobuf:"bytes,8,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
-	Commission          *DecimalValue          `protobuf:"bytes,9,opt,name=commission,proto3,oneof" json:"commission,omitempty"`
-	ExecutedAtTimestamp *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=executed_at_timestamp,json=executedAtTimestamp,proto3" json:"executed_at_timestamp,omitempty"`
-	PnlRealized         *DecimalValue          `protobuf:"bytes,11,opt,name=pnl_realized,json=pnlRealized,proto3,oneof" json:"pnl_realized,omitempty"`
-	PnlUnrealized       *DecimalValue          `protobuf:"bytes,12,opt,name=pnl_unrealized,json=pnlUnrealized,proto3,oneof" json:"pnl_unrealized,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *CreateAlgoOrderRequest) GetSide() OrderSide {
+	if x != nil {
+		return x.Side
+	}
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
 }
 
-func (x *Trade) Reset() {
-	*x = Trade{}
+func (x *CreateAlgoOrderRequest) GetAlgo() AlgoType {
+	if x != nil {
+		return x.Algo
+	}
+	return AlgoType_ALGO_TYPE_UNSPECIFIED
+}
+
+func (x *CreateAlgoOrderRequest) GetQuantity() *DecimalValue {
+	if x != nil {
+		return x.Quantity
+	}
+	return nil
+}
+
+func (x *CreateAlgoOrderRequest) GetLimitPrice() *DecimalValue {
+	if x != nil {
+		return x.LimitPrice
+	}
+	return nil
+}
+
+func (x *CreateAlgoOrderRequest) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *CreateAlgoOrderRequest) GetNumSlices() int32 {
+	if x != nil {
+		return x.NumSlices
+	}
+	return 0
+}
+
+func (x *CreateAlgoOrderRequest) GetDisplayQuantity() *DecimalValue {
+	if x != nil {
+		return x.DisplayQuantity
+	}
+	return nil
+}
+
+type AlgoOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgoOrderId   string                 `protobuf:"bytes,1,opt,name=algo_order_id,json=algoOrderId,proto3" json:"algo_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlgoOrderRequest) Reset() {
+	*x = AlgoOrderRequest{}
 	mi := &file_trading_api_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Trade) String() string {
+func (x *AlgoOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Trade) ProtoMessage() {}
+func (*AlgoOrderRequest) ProtoMessage() {}
 
-func (x *Trade) ProtoReflect() protoreflect.Message {
+func (x *AlgoOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1408,123 +1876,227 @@ func (x *Trade) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
-func (*Trade) Descriptor() ([]byte, []int) {
+// Deprecated: Use AlgoOrderRequest.ProtoReflect.Descriptor instead.
+func (*AlgoOrderRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Trade) GetTradeId() string {
+func (x *AlgoOrderRequest) GetAlgoOrderId() string {
 	if x != nil {
-		return x.TradeId
+		return x.AlgoOrderId
 	}
 	return ""
 }
 
-func (x *Trade) GetSymbol() string {
+type AlgoOrder struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BotId            string                 `protobuf:"bytes,2,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol           string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side             OrderSide              `protobuf:"varint,4,opt,name=side,proto3,enum=trading.OrderSide" json:"side,omitempty"`
+	Algo             AlgoType               `protobuf:"varint,5,opt,name=algo,proto3,enum=trading.AlgoType" json:"algo,omitempty"`
+	Status           AlgoStatus             `protobuf:"varint,6,opt,name=status,proto3,enum=trading.AlgoStatus" json:"status,omitempty"`
+	Quantity         *DecimalValue          `protobuf:"bytes,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	QuantitySent     *DecimalValue          `protobuf:"bytes,8,opt,name=quantity_sent,json=quantitySent,proto3" json:"quantity_sent,omitempty"` // total quantity of child orders placed
+	QuantityFilled   *DecimalValue          `protobuf:"bytes,9,opt,name=quantity_filled,json=quantityFilled,proto3" json:"quantity_filled,omitempty"`
+	SlicesTotal      int32                  `protobuf:"varint,10,opt,name=slices_total,json=slicesTotal,proto3" json:"slices_total,omitempty"` // 0 for ICEBERG, which clips until filled
+	SlicesSent       int32                  `protobuf:"varint,11,opt,name=slices_sent,json=slicesSent,proto3" json:"slices_sent,omitempty"`
+	ArrivalPrice     float64                `protobuf:"fixed64,12,opt,name=arrival_price,json=arrivalPrice,proto3" json:"arrival_price,omitempty"` // reference price when the algo started
+	AverageFillPrice float64                `protobuf:"fixed64,13,opt,name=average_fill_price,json=averageFillPrice,proto3" json:"average_fill_price,omitempty"`
+	SlippageBps      float64                `protobuf:"fixed64,14,opt,name=slippage_bps,json=slippageBps,proto3" json:"slippage_bps,omitempty"` // cost versus arrival price; positive is worse
+	ChildOrderIds    []string               `protobuf:"bytes,15,rep,name=child_order_ids,json=childOrderIds,proto3" json:"child_order_ids,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Message          string                 `protobuf:"bytes,18,opt,name=message,proto3" json:"message,omitempty"` // reason for FAILED/CANCELED
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AlgoOrder) Reset() {
+	*x = AlgoOrder{}
+	mi := &file_trading_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlgoOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlgoOrder) ProtoMessage() {}
+
+func (x *AlgoOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlgoOrder.ProtoReflect.Descriptor instead.
+func (*AlgoOrder) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AlgoOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *Trade) GetSide() string {
+func (x *AlgoOrder) GetSide() OrderSide {
 	if x != nil {
 		return x.Side
 	}
-	return ""
+	return OrderSide_ORDER_SIDE_UNSPECIFIED
 }
 
-func (x *Trade) GetQuantity() float64 {
+func (x *AlgoOrder) GetAlgo() AlgoType {
+	if x != nil {
+		return x.Algo
+	}
+	return AlgoType_ALGO_TYPE_UNSPECIFIED
+}
+
+func (x *AlgoOrder) GetStatus() AlgoStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AlgoStatus_ALGO_STATUS_UNSPECIFIED
+}
+
+func (x *AlgoOrder) GetQuantity() *DecimalValue {
 	if x != nil {
 		return x.Quantity
 	}
+	return nil
+}
+
+func (x *AlgoOrder) GetQuantitySent() *DecimalValue {
+	if x != nil {
+		return x.QuantitySent
+	}
+	return nil
+}
+
+func (x *AlgoOrder) GetQuantityFilled() *DecimalValue {
+	if x != nil {
+		return x.QuantityFilled
+	}
+	return nil
+}
+
+func (x *AlgoOrder) GetSlicesTotal() int32 {
+	if x != nil {
+		return x.SlicesTotal
+	}
 	return 0
 }
 
-func (x *Trade) GetPrice() float64 {
+func (x *AlgoOrder) GetSlicesSent() int32 {
 	if x != nil {
-		return x.Price
+		return x.SlicesSent
 	}
 	return 0
 }
 
-func (x *Trade) GetExecutedAt() int64 {
+func (x *AlgoOrder) GetArrivalPrice() float64 {
 	if x != nil {
-		return x.ExecutedAt
+		return x.ArrivalPrice
 	}
 	return 0
 }
 
-func (x *Trade) GetStrategyId() string {
+func (x *AlgoOrder) GetAverageFillPrice() float64 {
 	if x != nil {
-		return x.StrategyId
+		return x.AverageFillPrice
 	}
-	return ""
+	return 0
 }
 
-func (x *Trade) GetBotId() string {
+func (x *AlgoOrder) GetSlippageBps() float64 {
 	if x != nil {
-		return x.BotId
+		return x.SlippageBps
 	}
-	return ""
+	return 0
 }
 
-func (x *Trade) GetCommission() *DecimalValue {
+func (x *AlgoOrder) GetChildOrderIds() []string {
 	if x != nil {
-		return x.Commission
+		return x.ChildOrderIds
 	}
 	return nil
 }
 
-func (x *Trade) GetExecutedAtTimestamp() *timestamppb.Timestamp {
+func (x *AlgoOrder) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ExecutedAtTimestamp
+		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *Trade) GetPnlRealized() *DecimalValue {
+func (x *AlgoOrder) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PnlRealized
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-func (x *Trade) GetPnlUnrealized() *DecimalValue {
+func (x *AlgoOrder) GetMessage() string {
 	if x != nil {
-		return x.PnlUnrealized
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type TradeRequest struct {
+type Discrepancy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
-	Size          float64                `protobuf:"fixed64,3,opt,name=size,proto3" json:"size,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	StrategyId    string                 `protobuf:"bytes,5,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BotId         string                 `protobuf:"bytes,7,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Type          DiscrepancyType        `protobuf:"varint,1,opt,name=type,proto3,enum=trading.DiscrepancyType" json:"type,omitempty"`
+	Venue         string                 `protobuf:"bytes,2,opt,name=venue,proto3" json:"venue,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"` // owning user id; empty for the platform account
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	VenueOrderId  string                 `protobuf:"bytes,5,opt,name=venue_order_id,json=venueOrderId,proto3" json:"venue_order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"` // product for orders and fills, currency for balances
+	Detail        string                 `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`
+	Corrected     bool                   `protobuf:"varint,8,opt,name=corrected,proto3" json:"corrected,omitempty"` // true when auto-correct resolved it (imported the fill)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TradeRequest) Reset() {
-	*x = TradeRequest{}
-	mi := &file_trading_api_proto_msgTypes[20]
+func (x *Discrepancy) Reset() {
+	*x = Discrepancy{}
+	mi := &file_trading_api_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TradeRequest) String() string {
+func (x *Discrepancy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TradeRequest) ProtoMessage() {}
+func (*Discrepancy) ProtoMessage() {}
 
-func (x *TradeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[20]
+func (x *Discrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1535,85 +2107,93 @@ func (x *TradeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TradeRequest.ProtoReflect.Descriptor instead.
-func (*TradeRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use Discrepancy.ProtoReflect.Descriptor instead.
+func (*Discrepancy) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *TradeRequest) GetSymbol() string {
+func (x *Discrepancy) GetType() DiscrepancyType {
 	if x != nil {
-		return x.Symbol
+		return x.Type
 	}
-	return ""
+	return DiscrepancyType_DISCREPANCY_TYPE_UNSPECIFIED
 }
 
-func (x *TradeRequest) GetSide() string {
+func (x *Discrepancy) GetVenue() string {
 	if x != nil {
-		return x.Side
+		return x.Venue
 	}
 	return ""
 }
 
-func (x *TradeRequest) GetSize() float64 {
+func (x *Discrepancy) GetAccount() string {
 	if x != nil {
-		return x.Size
+		return x.Account
 	}
-	return 0
+	return ""
 }
 
-func (x *TradeRequest) GetPrice() float64 {
+func (x *Discrepancy) GetOrderId() string {
 	if x != nil {
-		return x.Price
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (x *TradeRequest) GetStrategyId() string {
+func (x *Discrepancy) GetVenueOrderId() string {
 	if x != nil {
-		return x.StrategyId
+		return x.VenueOrderId
 	}
 	return ""
 }
 
-func (x *TradeRequest) GetUserId() string {
+func (x *Discrepancy) GetSymbol() string {
 	if x != nil {
-		return x.UserId
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *TradeRequest) GetBotId() string {
+func (x *Discrepancy) GetDetail() string {
 	if x != nil {
-		return x.BotId
+		return x.Detail
 	}
 	return ""
 }
 
-type TradeResponse struct {
+func (x *Discrepancy) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+type ReconciliationReport struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ExecutedPrice float64                `protobuf:"fixed64,3,opt,name=executed_price,json=executedPrice,proto3" json:"executed_price,omitempty"`
-	Pnl           float64                `protobuf:"fixed64,4,opt,name=pnl,proto3" json:"pnl,omitempty"` // realized PnL for closing trades (simplified)
+	RunAt         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Venue         string                 `protobuf:"bytes,2,opt,name=venue,proto3" json:"venue,omitempty"`
+	Discrepancies []*Discrepancy         `protobuf:"bytes,3,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	FillsImported int32                  `protobuf:"varint,4,opt,name=fills_imported,json=fillsImported,proto3" json:"fills_imported,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"` // set when the run could not complete
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TradeResponse) Reset() {
-	*x = TradeResponse{}
-	mi := &file_trading_api_proto_msgTypes[21]
+func (x *ReconciliationReport) Reset() {
+	*x = ReconciliationReport{}
+	mi := &file_trading_api_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TradeResponse) String() string {
+func (x *ReconciliationReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TradeResponse) ProtoMessage() {}
+func (*ReconciliationReport) ProtoMessage() {}
 
-func (x *TradeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[21]
+func (x *ReconciliationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1624,113 +2204,67 @@ func (x *TradeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TradeResponse.ProtoReflect.Descriptor instead.
-func (*TradeResponse) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ReconciliationReport.ProtoReflect.Descriptor instead.
+func (*ReconciliationReport) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *TradeResponse) GetAccepted() bool {
+func (x *ReconciliationReport) GetRunAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Accepted
+		return x.RunAt
 	}
-	return false
+	return nil
 }
 
-func (x *TradeResponse) GetMessage() string {
+func (x *ReconciliationReport) GetVenue() string {
 	if x != nil {
-		return x.Message
+		return x.Venue
 	}
 	return ""
 }
 
-func (x *TradeResponse) GetExecutedPrice() float64 {
+func (x *ReconciliationReport) GetDiscrepancies() []*Discrepancy {
 	if x != nil {
-		return x.ExecutedPrice
+		return x.Discrepancies
 	}
-	return 0
+	return nil
 }
 
-func (x *TradeResponse) GetPnl() float64 {
+func (x *ReconciliationReport) GetFillsImported() int32 {
 	if x != nil {
-		return x.Pnl
+		return x.FillsImported
 	}
 	return 0
 }
 
-type TradeHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BotId         string                 `protobuf:"bytes,2,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *TradeHistoryRequest) Reset() {
-	*x = TradeHistoryRequest{}
-	mi := &file_trading_api_proto_msgTypes[22]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *TradeHistoryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*TradeHistoryRequest) ProtoMessage() {}
-
-func (x *TradeHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[22]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use TradeHistoryRequest.ProtoReflect.Descriptor instead.
-func (*TradeHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *TradeHistoryRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *TradeHistoryRequest) GetBotId() string {
+func (x *ReconciliationReport) GetError() string {
 	if x != nil {
-		return x.BotId
+		return x.Error
 	}
 	return ""
 }
 
-type TradeHistoryResponse struct {
+type RunReconciliationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	AutoCorrect   bool                   `protobuf:"varint,1,opt,name=auto_correct,json=autoCorrect,proto3" json:"auto_correct,omitempty"` // import missing fills
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TradeHistoryResponse) Reset() {
-	*x = TradeHistoryResponse{}
+func (x *RunReconciliationRequest) Reset() {
+	*x = RunReconciliationRequest{}
 	mi := &file_trading_api_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TradeHistoryResponse) String() string {
+func (x *RunReconciliationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TradeHistoryResponse) ProtoMessage() {}
+func (*RunReconciliationRequest) ProtoMessage() {}
 
-func (x *TradeHistoryResponse) ProtoReflect() protoreflect.Message {
+func (x *RunReconciliationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1742,47 +2276,41 @@ func (x *TradeHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TradeHistoryResponse.ProtoReflect.Descriptor instead.
-func (*TradeHistoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*RunReconciliationRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *TradeHistoryResponse) GetTrades() []*Trade {
-	if x != nil {
-		return x.Trades
-	}
-	return nil
-}
-
-func (x *TradeHistoryResponse) GetTotalCount() int32 {
+func (x *RunReconciliationRequest) GetAutoCorrect() bool {
 	if x != nil {
-		return x.TotalCount
+		return x.AutoCorrect
 	}
-	return 0
+	return false
 }
 
-type AuthRequest struct {
+type OrderBook struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Bids          []*OrderBookEntry      `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*OrderBookEntry      `protobuf:"bytes,2,rep,name=asks,proto3" json:"asks,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuthRequest) Reset() {
-	*x = AuthRequest{}
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
 	mi := &file_trading_api_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuthRequest) String() string {
+func (x *OrderBook) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthRequest) ProtoMessage() {}
+func (*OrderBook) ProtoMessage() {}
 
-func (x *AuthRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1794,52 +2322,55 @@ func (x *AuthRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthRequest.ProtoReflect.Descriptor instead.
-func (*AuthRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *AuthRequest) GetUsername() string {
+func (x *OrderBook) GetBids() []*OrderBookEntry {
 	if x != nil {
-		return x.Username
+		return x.Bids
 	}
-	return ""
+	return nil
 }
 
-func (x *AuthRequest) GetPassword() string {
+func (x *OrderBook) GetAsks() []*OrderBookEntry {
 	if x != nil {
-		return x.Password
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBook) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
 	}
 	return ""
 }
 
-type AuthResponse struct {
+type OrderBookEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	ExpiresAtUnix int64                  `protobuf:"varint,4,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
-	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
-	Username      string                 `protobuf:"bytes,6,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Size          float64                `protobuf:"fixed64,2,opt,name=size,proto3" json:"size,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix epoch milliseconds
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuthResponse) Reset() {
-	*x = AuthResponse{}
+func (x *OrderBookEntry) Reset() {
+	*x = OrderBookEntry{}
 	mi := &file_trading_api_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuthResponse) String() string {
+func (x *OrderBookEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthResponse) ProtoMessage() {}
+func (*OrderBookEntry) ProtoMessage() {}
 
-func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+func (x *OrderBookEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1851,81 +2382,53 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
-func (*AuthResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderBookEntry.ProtoReflect.Descriptor instead.
+func (*OrderBookEntry) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *AuthResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *AuthResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *AuthResponse) GetToken() string {
-	if x != nil {
-		return x.Token
-	}
-	return ""
-}
-
-func (x *AuthResponse) GetExpiresAtUnix() int64 {
+func (x *OrderBookEntry) GetPrice() float64 {
 	if x != nil {
-		return x.ExpiresAtUnix
+		return x.Price
 	}
 	return 0
 }
 
-func (x *AuthResponse) GetRole() string {
-	if x != nil {
-		return x.Role
-	}
-	return ""
-}
-
-func (x *AuthResponse) GetUsername() string {
+func (x *OrderBookEntry) GetSize() float64 {
 	if x != nil {
-		return x.Username
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *AuthResponse) GetEmail() string {
+func (x *OrderBookEntry) GetTimestamp() int64 {
 	if x != nil {
-		return x.Email
+		return x.Timestamp
 	}
-	return ""
+	return 0
 }
 
-type GetUserRequest struct {
+type OrderBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
+func (x *OrderBookRequest) Reset() {
+	*x = OrderBookRequest{}
 	mi := &file_trading_api_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserRequest) String() string {
+func (x *OrderBookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserRequest) ProtoMessage() {}
+func (*OrderBookRequest) ProtoMessage() {}
 
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1937,41 +2440,53 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
+func (*OrderBookRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetUserRequest) GetUsername() string {
+func (x *OrderBookRequest) GetSymbol() string {
 	if x != nil {
-		return x.Username
+		return x.Symbol
 	}
 	return ""
 }
 
-type RegisterRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // Plaintext over TLS; will be hashed server-side
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type Trade struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TradeId             string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Symbol              string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side                string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	Quantity            float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price               float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	ExecutedAt          int64                  `protobuf:"varint,6,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	StrategyId          string                 `protobuf:"bytes,7,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	BotId               string                 `protobuf:"bytes,8,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Commission          *DecimalValue          `protobuf:"bytes,9,opt,name=commission,proto3,oneof" json:"commission,omitempty"`
+	ExecutedAtTimestamp *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=executed_at_timestamp,json=executedAtTimestamp,proto3" json:"executed_at_timestamp,omitempty"`
+	PnlRealized         *DecimalValue          `protobuf:"bytes,11,opt,name=pnl_realized,json=pnlRealized,proto3,oneof" json:"pnl_realized,omitempty"`
+	PnlUnrealized       *DecimalValue          `protobuf:"bytes,12,opt,name=pnl_unrealized,json=pnlUnrealized,proto3,oneof" json:"pnl_unrealized,omitempty"`
+	ClientOrderId       string                 `protobuf:"bytes,13,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	OrderId             string                 `protobuf:"bytes,14,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // the order this trade filled, if any
+	Liquidity           string                 `protobuf:"bytes,15,opt,name=liquidity,proto3" json:"liquidity,omitempty"`            // MAKER or TAKER when the venue reports it
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *RegisterRequest) Reset() {
-	*x = RegisterRequest{}
+func (x *Trade) Reset() {
+	*x = Trade{}
 	mi := &file_trading_api_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterRequest) String() string {
+func (x *Trade) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterRequest) ProtoMessage() {}
+func (*Trade) ProtoMessage() {}
 
-func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+func (x *Trade) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1983,55 +2498,144 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
-func (*RegisterRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *RegisterRequest) GetUsername() string {
+func (x *Trade) GetTradeId() string {
 	if x != nil {
-		return x.Username
+		return x.TradeId
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetPassword() string {
+func (x *Trade) GetSymbol() string {
 	if x != nil {
-		return x.Password
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *RegisterRequest) GetEmail() string {
+func (x *Trade) GetSide() string {
 	if x != nil {
-		return x.Email
+		return x.Side
 	}
 	return ""
 }
 
-type UserInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	CreatedAtUnix int64                  `protobuf:"varint,3,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetExecutedAt() int64 {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return 0
+}
+
+func (x *Trade) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *Trade) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *Trade) GetCommission() *DecimalValue {
+	if x != nil {
+		return x.Commission
+	}
+	return nil
+}
+
+func (x *Trade) GetExecutedAtTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAtTimestamp
+	}
+	return nil
+}
+
+func (x *Trade) GetPnlRealized() *DecimalValue {
+	if x != nil {
+		return x.PnlRealized
+	}
+	return nil
+}
+
+func (x *Trade) GetPnlUnrealized() *DecimalValue {
+	if x != nil {
+		return x.PnlUnrealized
+	}
+	return nil
+}
+
+func (x *Trade) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetLiquidity() string {
+	if x != nil {
+		return x.Liquidity
+	}
+	return ""
+}
+
+type TradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	Size          float64                `protobuf:"fixed64,3,opt,name=size,proto3" json:"size,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	StrategyId    string                 `protobuf:"bytes,5,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BotId         string                 `protobuf:"bytes,7,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // optional, unique per bot; resubmitting returns the original execution
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserInfo) Reset() {
-	*x = UserInfo{}
+func (x *TradeRequest) Reset() {
+	*x = TradeRequest{}
 	mi := &file_trading_api_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserInfo) String() string {
+func (x *TradeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserInfo) ProtoMessage() {}
+func (*TradeRequest) ProtoMessage() {}
 
-func (x *UserInfo) ProtoReflect() protoreflect.Message {
+func (x *TradeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2043,53 +2647,92 @@ func (x *UserInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserInfo.ProtoReflect.Descriptor instead.
-func (*UserInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use TradeRequest.ProtoReflect.Descriptor instead.
+func (*TradeRequest) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *UserInfo) GetUsername() string {
+func (x *TradeRequest) GetSymbol() string {
 	if x != nil {
-		return x.Username
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *UserInfo) GetEmail() string {
+func (x *TradeRequest) GetSide() string {
 	if x != nil {
-		return x.Email
+		return x.Side
 	}
 	return ""
 }
 
-func (x *UserInfo) GetCreatedAtUnix() int64 {
+func (x *TradeRequest) GetSize() float64 {
 	if x != nil {
-		return x.CreatedAtUnix
+		return x.Size
 	}
 	return 0
 }
 
-type RefreshTokenRequest struct {
+func (x *TradeRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *TradeRequest) GetStrategyId() string {
+	if x != nil {
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *TradeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TradeRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *TradeRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type TradeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ExecutedPrice float64                `protobuf:"fixed64,3,opt,name=executed_price,json=executedPrice,proto3" json:"executed_price,omitempty"`
+	Pnl           float64                `protobuf:"fixed64,4,opt,name=pnl,proto3" json:"pnl,omitempty"` // realized PnL for closing trades (simplified)
+	TradeId       string                 `protobuf:"bytes,5,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
+func (x *TradeResponse) Reset() {
+	*x = TradeResponse{}
 	mi := &file_trading_api_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *TradeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*TradeResponse) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *TradeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2101,54 +2744,2465 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TradeResponse.ProtoReflect.Descriptor instead.
+func (*TradeResponse) Descriptor() ([]byte, []int) {
 	return file_trading_api_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *TradeResponse) GetAccepted() bool {
 	if x != nil {
-		return x.RefreshToken
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *TradeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-type Bot struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	BotId               string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
-	Symbol              string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Strategy            string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
-	Parameters          map[string]string      `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	IsActive            bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	CreatedAtUnixMs     int64                  `protobuf:"varint,6,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
-	Name                string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
-	UserId              string                 `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AccountValue        float64                `protobuf:"fixed64,9,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
-	Description         string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
-	InitialAccountValue *DecimalValue          `protobuf:"bytes,11,opt,name=initial_account_value,json=initialAccountValue,proto3" json:"initial_account_value,omitempty"`
-	CurrentAccountValue *DecimalValue          `protobuf:"bytes,12,opt,name=current_account_value,json=currentAccountValue,proto3" json:"current_account_value,omitempty"`
-	IsLive              bool                   `protobuf:"varint,13,opt,name=is_live,json=isLive,proto3" json:"is_live,omitempty"`
-	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *TradeResponse) GetExecutedPrice() float64 {
+	if x != nil {
+		return x.ExecutedPrice
+	}
+	return 0
 }
 
-func (x *Bot) Reset() {
-	*x = Bot{}
+func (x *TradeResponse) GetPnl() float64 {
+	if x != nil {
+		return x.Pnl
+	}
+	return 0
+}
+
+func (x *TradeResponse) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+type TradeHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // trades of all of the user's bots
+	BotId         string                 `protobuf:"bytes,2,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,4,opt,name=side,proto3" json:"side,omitempty"`                            // BUY or SELL
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // executed at or after
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // executed before
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 100, at most 1000
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeHistoryRequest) Reset() {
+	*x = TradeHistoryRequest{}
+	mi := &file_trading_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeHistoryRequest) ProtoMessage() {}
+
+func (x *TradeHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_trading_api_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TradeHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TradeHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TradeHistoryRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *TradeHistoryRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TradeHistoryRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *TradeHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TradeHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TradeHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *TradeHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type TradeHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`                                      // newest first
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // trades matching the filters, on all pages
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	Summaries     []*TradeSymbolSummary  `protobuf:"bytes,4,rep,name=summaries,proto3" json:"summaries,omitempty"`                                // totals of all matching trades by symbol
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeHistoryResponse) Reset() {
+	*x = TradeHistoryResponse{}
+	mi := &file_trading_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeHistoryResponse) ProtoMessage() {}
+
+func (x *TradeHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeHistoryResponse.ProtoReflect.Descriptor instead.
+func (*TradeHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TradeHistoryResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *TradeHistoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *TradeHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *TradeHistoryResponse) GetSummaries() []*TradeSymbolSummary {
+	if x != nil {
+		return x.Summaries
+	}
+	return nil
+}
+
+type TradeSymbolSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	TradeCount    int32                  `protobuf:"varint,2,opt,name=trade_count,json=tradeCount,proto3" json:"trade_count,omitempty"`
+	Volume        *DecimalValue          `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`     // total quantity
+	Notional      *DecimalValue          `protobuf:"bytes,4,opt,name=notional,proto3" json:"notional,omitempty"` // total quantity * price
+	Fees          *DecimalValue          `protobuf:"bytes,5,opt,name=fees,proto3" json:"fees,omitempty"`         // total commission
+	RealizedPnl   *DecimalValue          `protobuf:"bytes,6,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeSymbolSummary) Reset() {
+	*x = TradeSymbolSummary{}
+	mi := &file_trading_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeSymbolSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeSymbolSummary) ProtoMessage() {}
+
+func (x *TradeSymbolSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeSymbolSummary.ProtoReflect.Descriptor instead.
+func (*TradeSymbolSummary) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TradeSymbolSummary) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TradeSymbolSummary) GetTradeCount() int32 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+func (x *TradeSymbolSummary) GetVolume() *DecimalValue {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *TradeSymbolSummary) GetNotional() *DecimalValue {
+	if x != nil {
+		return x.Notional
+	}
+	return nil
+}
+
+func (x *TradeSymbolSummary) GetFees() *DecimalValue {
+	if x != nil {
+		return x.Fees
+	}
+	return nil
+}
+
+func (x *TradeSymbolSummary) GetRealizedPnl() *DecimalValue {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return nil
+}
+
+type AuthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRequest) Reset() {
+	*x = AuthRequest{}
+	mi := &file_trading_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRequest) ProtoMessage() {}
+
+func (x *AuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRequest.ProtoReflect.Descriptor instead.
+func (*AuthRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AuthRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuthRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AuthResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Success               bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message               string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token                 string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAtUnix         int64                  `protobuf:"varint,4,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	Role                  string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	Username              string                 `protobuf:"bytes,6,opt,name=username,proto3" json:"username,omitempty"`
+	Email                 string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,8,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // opaque; exchange with RefreshToken before it expires
+	RefreshExpiresAtUnix  int64                  `protobuf:"varint,9,opt,name=refresh_expires_at_unix,json=refreshExpiresAtUnix,proto3" json:"refresh_expires_at_unix,omitempty"`
+	MfaRequired           bool                   `protobuf:"varint,10,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`                                 // password accepted; finish with CompleteLogin
+	MfaEnrollmentRequired bool                   `protobuf:"varint,11,opt,name=mfa_enrollment_required,json=mfaEnrollmentRequired,proto3" json:"mfa_enrollment_required,omitempty"` // the role requires 2FA; enroll with EnrollTOTP and VerifyTOTP
+	ChallengeToken        string                 `protobuf:"bytes,12,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`                         // short-lived; only accepted by the TOTP RPCs
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_trading_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AuthResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AuthResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshExpiresAtUnix() int64 {
+	if x != nil {
+		return x.RefreshExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthResponse) GetMfaEnrollmentRequired() bool {
+	if x != nil {
+		return x.MfaEnrollmentRequired
+	}
+	return false
+}
+
+func (x *AuthResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_trading_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // Plaintext over TLS; will be hashed server-side
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_trading_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RegisterRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type UserInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAtUnix int64                  `protobuf:"varint,3,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,6,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserInfo) Reset() {
+	*x = UserInfo{}
+	mi := &file_trading_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInfo) ProtoMessage() {}
+
+func (x *UserInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInfo.ProtoReflect.Descriptor instead.
+func (*UserInfo) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UserInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserInfo) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserInfo) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *UserInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserInfo) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserInfo) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+type UpdateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // a changed address must be verified again
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_trading_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateProfileRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_trading_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`           // required when deleting your own account
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // admins only: delete another user's account
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_trading_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_trading_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // optional; the session of the calling access token is always ended
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_trading_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+// Session is a chain of refresh tokens started by one login.
+type Session struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastRefreshedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_refreshed_at,json=lastRefreshedAt,proto3" json:"last_refreshed_at,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Current         bool                   `protobuf:"varint,5,opt,name=current,proto3" json:"current,omitempty"` // the session of the calling access token
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_trading_api_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRefreshedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_trading_api_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_trading_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RevokeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// APIKey is a long-lived credential for scripts and services. The secret itself is
+// only returned once, by CreateAPIKey.
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`                           // first characters of the secret, to tell keys apart
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`                           // "read", "trade", "admin"
+	AllowedIps    []string               `protobuf:"bytes,5,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"` // IPs or CIDRs; empty allows any address
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unset when the key does not expire
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_trading_api_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetAllowedIps() []string {
+	if x != nil {
+		return x.AllowedIps
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	AllowedIps    []string               `protobuf:"bytes,3,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
+	ExpiresInDays int32                  `protobuf:"varint,4,opt,name=expires_in_days,json=expiresInDays,proto3" json:"expires_in_days,omitempty"` // 0 for no expiry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_trading_api_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetAllowedIps() []string {
+	if x != nil {
+		return x.AllowedIps
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresInDays() int32 {
+	if x != nil {
+		return x.ExpiresInDays
+	}
+	return 0
+}
+
+type CreateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *APIKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // send as "authorization: Bearer <secret>" or "x-api-key: <secret>"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_trading_api_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateAPIKeyResponse) GetKey() *APIKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*APIKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_trading_api_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListAPIKeysResponse) GetKeys() []*APIKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_trading_api_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RevokeAPIKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+// EnrollTOTP and VerifyTOTP act on the signed-in user, or on the user of an enrollment
+// challenge_token returned by Login.
+type EnrollTOTPRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_trading_api_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EnrollTOTPRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+type EnrollTOTPResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Secret          string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                                          // base32, for manual entry
+	ProvisioningUri string                 `protobuf:"bytes,2,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"` // otpauth:// URI, usually shown as a QR code
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_trading_api_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type VerifyTOTPRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ChallengeToken string                 `protobuf:"bytes,2,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyTOTPRequest) Reset() {
+	*x = VerifyTOTPRequest{}
+	mi := &file_trading_api_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTOTPRequest) ProtoMessage() {}
+
+func (x *VerifyTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTOTPRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *VerifyTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *VerifyTOTPRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+type VerifyTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // single-use; shown only once
+	Auth          *AuthResponse          `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`                                        // set when enrolling with a challenge_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTOTPResponse) Reset() {
+	*x = VerifyTOTPResponse{}
+	mi := &file_trading_api_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTOTPResponse) ProtoMessage() {}
+
+func (x *VerifyTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTOTPResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *VerifyTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+func (x *VerifyTOTPResponse) GetAuth() *AuthResponse {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type CompleteLoginRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP code, or
+	RecoveryCode   string                 `protobuf:"bytes,3,opt,name=recovery_code,json=recoveryCode,proto3" json:"recovery_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CompleteLoginRequest) Reset() {
+	*x = CompleteLoginRequest{}
+	mi := &file_trading_api_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteLoginRequest) ProtoMessage() {}
+
+func (x *CompleteLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteLoginRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CompleteLoginRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *CompleteLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CompleteLoginRequest) GetRecoveryCode() string {
+	if x != nil {
+		return x.RecoveryCode
+	}
+	return ""
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // current TOTP code or a recovery code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_trading_api_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_trading_api_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // from the reset email
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_trading_api_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // from the verification email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_trading_api_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Bot struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	BotId               string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol              string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Strategy            string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Parameters          map[string]string      `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IsActive            bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAtUnixMs     int64                  `protobuf:"varint,6,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	Name                string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	UserId              string                 `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccountValue        float64                `protobuf:"fixed64,9,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
+	Description         string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	InitialAccountValue *DecimalValue          `protobuf:"bytes,11,opt,name=initial_account_value,json=initialAccountValue,proto3" json:"initial_account_value,omitempty"`
+	CurrentAccountValue *DecimalValue          `protobuf:"bytes,12,opt,name=current_account_value,json=currentAccountValue,proto3" json:"current_account_value,omitempty"`
+	IsLive              bool                   `protobuf:"varint,13,opt,name=is_live,json=isLive,proto3" json:"is_live,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StrategyStatus      string                 `protobuf:"bytes,16,opt,name=strategy_status,json=strategyStatus,proto3" json:"strategy_status,omitempty"` // RUNNING, STOPPED or FAILED; empty until the bot is first started
+	StatusReason        string                 `protobuf:"bytes,17,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`       // why the bot is FAILED
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Bot) Reset() {
+	*x = Bot{}
+	mi := &file_trading_api_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bot) ProtoMessage() {}
+
+func (x *Bot) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bot.ProtoReflect.Descriptor instead.
+func (*Bot) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *Bot) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *Bot) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Bot) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Bot) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Bot) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Bot) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *Bot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bot) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Bot) GetAccountValue() float64 {
+	if x != nil {
+		return x.AccountValue
+	}
+	return 0
+}
+
+func (x *Bot) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Bot) GetInitialAccountValue() *DecimalValue {
+	if x != nil {
+		return x.InitialAccountValue
+	}
+	return nil
+}
+
+func (x *Bot) GetCurrentAccountValue() *DecimalValue {
+	if x != nil {
+		return x.CurrentAccountValue
+	}
+	return nil
+}
+
+func (x *Bot) GetIsLive() bool {
+	if x != nil {
+		return x.IsLive
+	}
+	return false
+}
+
+func (x *Bot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Bot) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Bot) GetStrategyStatus() string {
+	if x != nil {
+		return x.StrategyStatus
+	}
+	return ""
+}
+
+func (x *Bot) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+type UpdateBotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`                                                        // starts or stops the bot
+	Parameters    map[string]string      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // merged into the bot's parameters; an empty value removes the key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBotRequest) Reset() {
+	*x = UpdateBotRequest{}
+	mi := &file_trading_api_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBotRequest) ProtoMessage() {}
+
+func (x *UpdateBotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBotRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBotRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *UpdateBotRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *UpdateBotRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateBotRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateBotRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateBotRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type CreateBotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	AccountValue  float64                `protobuf:"fixed64,5,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
+	IsLive        bool                   `protobuf:"varint,6,opt,name=is_live,json=isLive,proto3" json:"is_live,omitempty"` // route orders to the live exchange instead of the paper simulator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBotRequest) Reset() {
+	*x = CreateBotRequest{}
+	mi := &file_trading_api_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBotRequest) ProtoMessage() {}
+
+func (x *CreateBotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBotRequest.ProtoReflect.Descriptor instead.
+func (*CreateBotRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateBotRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreateBotRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *CreateBotRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *CreateBotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBotRequest) GetAccountValue() float64 {
+	if x != nil {
+		return x.AccountValue
+	}
+	return 0
+}
+
+func (x *CreateBotRequest) GetIsLive() bool {
+	if x != nil {
+		return x.IsLive
+	}
+	return false
+}
+
+type BotIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BotIdRequest) Reset() {
+	*x = BotIdRequest{}
+	mi := &file_trading_api_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotIdRequest) ProtoMessage() {}
+
+func (x *BotIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotIdRequest.ProtoReflect.Descriptor instead.
+func (*BotIdRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *BotIdRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+type ListBotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBotsRequest) Reset() {
+	*x = ListBotsRequest{}
+	mi := &file_trading_api_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBotsRequest) ProtoMessage() {}
+
+func (x *ListBotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBotsRequest.ProtoReflect.Descriptor instead.
+func (*ListBotsRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListBotsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BotList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bots          []*Bot                 `protobuf:"bytes,1,rep,name=bots,proto3" json:"bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BotList) Reset() {
+	*x = BotList{}
+	mi := &file_trading_api_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotList) ProtoMessage() {}
+
+func (x *BotList) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotList.ProtoReflect.Descriptor instead.
+func (*BotList) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *BotList) GetBots() []*Bot {
+	if x != nil {
+		return x.Bots
+	}
+	return nil
+}
+
+type VaRRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPortfolio *PortfolioResponse     `protobuf:"bytes,1,opt,name=current_portfolio,json=currentPortfolio,proto3" json:"current_portfolio,omitempty"`
+	RiskModel        string                 `protobuf:"bytes,2,opt,name=risk_model,json=riskModel,proto3" json:"risk_model,omitempty"`                     // e.g. "historical", "monte_carlo"
+	ConfidenceLevel  float64                `protobuf:"fixed64,3,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"` // e.g. 0.95
+	HorizonDays      float64                `protobuf:"fixed64,4,opt,name=horizon_days,json=horizonDays,proto3" json:"horizon_days,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *VaRRequest) Reset() {
+	*x = VaRRequest{}
+	mi := &file_trading_api_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VaRRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaRRequest) ProtoMessage() {}
+
+func (x *VaRRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaRRequest.ProtoReflect.Descriptor instead.
+func (*VaRRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *VaRRequest) GetCurrentPortfolio() *PortfolioResponse {
+	if x != nil {
+		return x.CurrentPortfolio
+	}
+	return nil
+}
+
+func (x *VaRRequest) GetRiskModel() string {
+	if x != nil {
+		return x.RiskModel
+	}
+	return ""
+}
+
+func (x *VaRRequest) GetConfidenceLevel() float64 {
+	if x != nil {
+		return x.ConfidenceLevel
+	}
+	return 0
+}
+
+func (x *VaRRequest) GetHorizonDays() float64 {
+	if x != nil {
+		return x.HorizonDays
+	}
+	return 0
+}
+
+type VaRResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ValueAtRisk        *DecimalValue          `protobuf:"bytes,1,opt,name=value_at_risk,json=valueAtRisk,proto3" json:"value_at_risk,omitempty"`
+	AssetNames         []string               `protobuf:"bytes,2,rep,name=asset_names,json=assetNames,proto3" json:"asset_names,omitempty"`
+	CorrelationMatrix  []float64              `protobuf:"fixed64,3,rep,packed,name=correlation_matrix,json=correlationMatrix,proto3" json:"correlation_matrix,omitempty"`
+	VolatilityPerAsset []float64              `protobuf:"fixed64,4,rep,packed,name=volatility_per_asset,json=volatilityPerAsset,proto3" json:"volatility_per_asset,omitempty"`
+	SimulationMode     string                 `protobuf:"bytes,5,opt,name=simulation_mode,json=simulationMode,proto3" json:"simulation_mode,omitempty"`
+	LastUpdate         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *VaRResponse) Reset() {
+	*x = VaRResponse{}
+	mi := &file_trading_api_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VaRResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaRResponse) ProtoMessage() {}
+
+func (x *VaRResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaRResponse.ProtoReflect.Descriptor instead.
+func (*VaRResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *VaRResponse) GetValueAtRisk() *DecimalValue {
+	if x != nil {
+		return x.ValueAtRisk
+	}
+	return nil
+}
+
+func (x *VaRResponse) GetAssetNames() []string {
+	if x != nil {
+		return x.AssetNames
+	}
+	return nil
+}
+
+func (x *VaRResponse) GetCorrelationMatrix() []float64 {
+	if x != nil {
+		return x.CorrelationMatrix
+	}
+	return nil
+}
+
+func (x *VaRResponse) GetVolatilityPerAsset() []float64 {
+	if x != nil {
+		return x.VolatilityPerAsset
+	}
+	return nil
+}
+
+func (x *VaRResponse) GetSimulationMode() string {
+	if x != nil {
+		return x.SimulationMode
+	}
+	return ""
+}
+
+func (x *VaRResponse) GetLastUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+type MomentumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"` // optional filter list
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MomentumRequest) Reset() {
+	*x = MomentumRequest{}
+	mi := &file_trading_api_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Bot) String() string {
+func (x *MomentumRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Bot) ProtoMessage() {}
+func (*MomentumRequest) ProtoMessage() {}
 
-func (x *Bot) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[30]
+func (x *MomentumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2159,141 +5213,178 @@ func (x *Bot) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Bot.ProtoReflect.Descriptor instead.
-func (*Bot) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use MomentumRequest.ProtoReflect.Descriptor instead.
+func (*MomentumRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *Bot) GetBotId() string {
+func (x *MomentumRequest) GetSymbols() []string {
 	if x != nil {
-		return x.BotId
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-func (x *Bot) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
+type MomentumMetric struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	LastPrice     float64                `protobuf:"fixed64,2,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
+	PctChange_1M  float64                `protobuf:"fixed64,3,opt,name=pct_change_1m,json=pctChange1m,proto3" json:"pct_change_1m,omitempty"`     // percentage change over ~1 minute
+	PctChange_5M  float64                `protobuf:"fixed64,4,opt,name=pct_change_5m,json=pctChange5m,proto3" json:"pct_change_5m,omitempty"`     // percentage change over ~5 minutes
+	Volatility    float64                `protobuf:"fixed64,5,opt,name=volatility,proto3" json:"volatility,omitempty"`                            // standard deviation of log returns (annualized approximation)
+	MomentumScore float64                `protobuf:"fixed64,6,opt,name=momentum_score,json=momentumScore,proto3" json:"momentum_score,omitempty"` // composite score (weighted changes - volatility penalty)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Bot) GetStrategy() string {
-	if x != nil {
-		return x.Strategy
-	}
-	return ""
+func (x *MomentumMetric) Reset() {
+	*x = MomentumMetric{}
+	mi := &file_trading_api_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Bot) GetParameters() map[string]string {
-	if x != nil {
-		return x.Parameters
-	}
-	return nil
+func (x *MomentumMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Bot) GetIsActive() bool {
+func (*MomentumMetric) ProtoMessage() {}
+
+func (x *MomentumMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[69]
 	if x != nil {
-		return x.IsActive
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Bot) GetCreatedAtUnixMs() int64 {
-	if x != nil {
-		return x.CreatedAtUnixMs
-	}
-	return 0
+// Deprecated: Use MomentumMetric.ProtoReflect.Descriptor instead.
+func (*MomentumMetric) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *Bot) GetName() string {
+func (x *MomentumMetric) GetSymbol() string {
 	if x != nil {
-		return x.Name
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *Bot) GetUserId() string {
+func (x *MomentumMetric) GetLastPrice() float64 {
 	if x != nil {
-		return x.UserId
+		return x.LastPrice
 	}
-	return ""
+	return 0
 }
 
-func (x *Bot) GetAccountValue() float64 {
+func (x *MomentumMetric) GetPctChange_1M() float64 {
 	if x != nil {
-		return x.AccountValue
+		return x.PctChange_1M
 	}
 	return 0
 }
 
-func (x *Bot) GetDescription() string {
+func (x *MomentumMetric) GetPctChange_5M() float64 {
 	if x != nil {
-		return x.Description
+		return x.PctChange_5M
 	}
-	return ""
+	return 0
 }
 
-func (x *Bot) GetInitialAccountValue() *DecimalValue {
+func (x *MomentumMetric) GetVolatility() float64 {
 	if x != nil {
-		return x.InitialAccountValue
+		return x.Volatility
 	}
-	return nil
+	return 0
 }
 
-func (x *Bot) GetCurrentAccountValue() *DecimalValue {
+func (x *MomentumMetric) GetMomentumScore() float64 {
 	if x != nil {
-		return x.CurrentAccountValue
+		return x.MomentumScore
 	}
-	return nil
+	return 0
 }
 
-func (x *Bot) GetIsLive() bool {
+type MomentumResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Metrics           []*MomentumMetric      `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	GeneratedAtUnixMs int64                  `protobuf:"varint,2,opt,name=generated_at_unix_ms,json=generatedAtUnixMs,proto3" json:"generated_at_unix_ms,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MomentumResponse) Reset() {
+	*x = MomentumResponse{}
+	mi := &file_trading_api_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MomentumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MomentumResponse) ProtoMessage() {}
+
+func (x *MomentumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[70]
 	if x != nil {
-		return x.IsLive
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Bot) GetCreatedAt() *timestamppb.Timestamp {
+// Deprecated: Use MomentumResponse.ProtoReflect.Descriptor instead.
+func (*MomentumResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *MomentumResponse) GetMetrics() []*MomentumMetric {
 	if x != nil {
-		return x.CreatedAt
+		return x.Metrics
 	}
 	return nil
 }
 
-func (x *Bot) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *MomentumResponse) GetGeneratedAtUnixMs() int64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.GeneratedAtUnixMs
 	}
-	return nil
+	return 0
 }
 
-type UpdateBotRequest struct {
+type Tick struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	IsActive      *bool                  `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	TimestampNs   int64                  `protobuf:"varint,3,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"` // Nanosecond precision timestamp
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateBotRequest) Reset() {
-	*x = UpdateBotRequest{}
-	mi := &file_trading_api_proto_msgTypes[31]
+func (x *Tick) Reset() {
+	*x = Tick{}
+	mi := &file_trading_api_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBotRequest) String() string {
+func (x *Tick) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBotRequest) ProtoMessage() {}
+func (*Tick) ProtoMessage() {}
 
-func (x *UpdateBotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[31]
+func (x *Tick) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2304,65 +5395,54 @@ func (x *UpdateBotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBotRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBotRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use Tick.ProtoReflect.Descriptor instead.
+func (*Tick) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *UpdateBotRequest) GetBotId() string {
+func (x *Tick) GetSymbol() string {
 	if x != nil {
-		return x.BotId
-	}
-	return ""
-}
-
-func (x *UpdateBotRequest) GetName() string {
-	if x != nil && x.Name != nil {
-		return *x.Name
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *UpdateBotRequest) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+func (x *Tick) GetPrice() float64 {
+	if x != nil {
+		return x.Price
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateBotRequest) GetIsActive() bool {
-	if x != nil && x.IsActive != nil {
-		return *x.IsActive
+func (x *Tick) GetTimestampNs() int64 {
+	if x != nil {
+		return x.TimestampNs
 	}
-	return false
+	return 0
 }
 
-type CreateBotRequest struct {
+type TickStreamRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
-	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	AccountValue  float64                `protobuf:"fixed64,5,opt,name=account_value,json=accountValue,proto3" json:"account_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateBotRequest) Reset() {
-	*x = CreateBotRequest{}
-	mi := &file_trading_api_proto_msgTypes[32]
+func (x *TickStreamRequest) Reset() {
+	*x = TickStreamRequest{}
+	mi := &file_trading_api_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBotRequest) String() string {
+func (x *TickStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBotRequest) ProtoMessage() {}
+func (*TickStreamRequest) ProtoMessage() {}
 
-func (x *CreateBotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[32]
+func (x *TickStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2373,68 +5453,40 @@ func (x *CreateBotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBotRequest.ProtoReflect.Descriptor instead.
-func (*CreateBotRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use TickStreamRequest.ProtoReflect.Descriptor instead.
+func (*TickStreamRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *CreateBotRequest) GetSymbol() string {
+func (x *TickStreamRequest) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *CreateBotRequest) GetStrategy() string {
-	if x != nil {
-		return x.Strategy
-	}
-	return ""
-}
-
-func (x *CreateBotRequest) GetParameters() map[string]string {
-	if x != nil {
-		return x.Parameters
-	}
-	return nil
-}
-
-func (x *CreateBotRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreateBotRequest) GetAccountValue() float64 {
-	if x != nil {
-		return x.AccountValue
-	}
-	return 0
-}
-
-type BotIdRequest struct {
+type SymbolRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BotIdRequest) Reset() {
-	*x = BotIdRequest{}
-	mi := &file_trading_api_proto_msgTypes[33]
+func (x *SymbolRequest) Reset() {
+	*x = SymbolRequest{}
+	mi := &file_trading_api_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BotIdRequest) String() string {
+func (x *SymbolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BotIdRequest) ProtoMessage() {}
+func (*SymbolRequest) ProtoMessage() {}
 
-func (x *BotIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[33]
+func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2445,40 +5497,40 @@ func (x *BotIdRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BotIdRequest.ProtoReflect.Descriptor instead.
-func (*BotIdRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use SymbolRequest.ProtoReflect.Descriptor instead.
+func (*SymbolRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *BotIdRequest) GetBotId() string {
+func (x *SymbolRequest) GetSymbol() string {
 	if x != nil {
-		return x.BotId
+		return x.Symbol
 	}
 	return ""
 }
 
-type ListBotsRequest struct {
+type SymbolList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBotsRequest) Reset() {
-	*x = ListBotsRequest{}
-	mi := &file_trading_api_proto_msgTypes[34]
+func (x *SymbolList) Reset() {
+	*x = SymbolList{}
+	mi := &file_trading_api_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBotsRequest) String() string {
+func (x *SymbolList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBotsRequest) ProtoMessage() {}
+func (*SymbolList) ProtoMessage() {}
 
-func (x *ListBotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[34]
+func (x *SymbolList) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2489,40 +5541,43 @@ func (x *ListBotsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBotsRequest.ProtoReflect.Descriptor instead.
-func (*ListBotsRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use SymbolList.ProtoReflect.Descriptor instead.
+func (*SymbolList) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *ListBotsRequest) GetUserId() string {
+func (x *SymbolList) GetSymbols() []string {
 	if x != nil {
-		return x.UserId
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-type BotList struct {
+type StrategyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bots          []*Bot                 `protobuf:"bytes,1,rep,name=bots,proto3" json:"bots,omitempty"`
+	StrategyId    string                 `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BotList) Reset() {
-	*x = BotList{}
-	mi := &file_trading_api_proto_msgTypes[35]
+func (x *StrategyRequest) Reset() {
+	*x = StrategyRequest{}
+	mi := &file_trading_api_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BotList) String() string {
+func (x *StrategyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BotList) ProtoMessage() {}
+func (*StrategyRequest) ProtoMessage() {}
 
-func (x *BotList) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[35]
+func (x *StrategyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2533,43 +5588,69 @@ func (x *BotList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BotList.ProtoReflect.Descriptor instead.
-func (*BotList) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use StrategyRequest.ProtoReflect.Descriptor instead.
+func (*StrategyRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *BotList) GetBots() []*Bot {
+func (x *StrategyRequest) GetStrategyId() string {
 	if x != nil {
-		return x.Bots
+		return x.StrategyId
+	}
+	return ""
+}
+
+func (x *StrategyRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StrategyRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
 	}
 	return nil
 }
 
-type VaRRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	CurrentPortfolio *PortfolioResponse     `protobuf:"bytes,1,opt,name=current_portfolio,json=currentPortfolio,proto3" json:"current_portfolio,omitempty"`
-	RiskModel        string                 `protobuf:"bytes,2,opt,name=risk_model,json=riskModel,proto3" json:"risk_model,omitempty"`                     // e.g. "historical", "monte_carlo"
-	ConfidenceLevel  float64                `protobuf:"fixed64,3,opt,name=confidence_level,json=confidenceLevel,proto3" json:"confidence_level,omitempty"` // e.g. 0.95
-	HorizonDays      float64                `protobuf:"fixed64,4,opt,name=horizon_days,json=horizonDays,proto3" json:"horizon_days,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *StrategyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *VaRRequest) Reset() {
-	*x = VaRRequest{}
-	mi := &file_trading_api_proto_msgTypes[36]
+type Product struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StripeProductId      string                 `protobuf:"bytes,4,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
+	PriceMonthly         float64                `protobuf:"fixed64,5,opt,name=price_monthly,json=priceMonthly,proto3" json:"price_monthly,omitempty"`
+	StripePriceIdMonthly string                 `protobuf:"bytes,6,opt,name=stripe_price_id_monthly,json=stripePriceIdMonthly,proto3" json:"stripe_price_id_monthly,omitempty"`
+	PriceYearly          float64                `protobuf:"fixed64,7,opt,name=price_yearly,json=priceYearly,proto3" json:"price_yearly,omitempty"`
+	StripePriceIdYearly  string                 `protobuf:"bytes,8,opt,name=stripe_price_id_yearly,json=stripePriceIdYearly,proto3" json:"stripe_price_id_yearly,omitempty"`
+	Features             []string               `protobuf:"bytes,9,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_trading_api_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VaRRequest) String() string {
+func (x *Product) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VaRRequest) ProtoMessage() {}
+func (*Product) ProtoMessage() {}
 
-func (x *VaRRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[36]
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2580,66 +5661,99 @@ func (x *VaRRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VaRRequest.ProtoReflect.Descriptor instead.
-func (*VaRRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *VaRRequest) GetCurrentPortfolio() *PortfolioResponse {
+func (x *Product) GetId() string {
 	if x != nil {
-		return x.CurrentPortfolio
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *VaRRequest) GetRiskModel() string {
+func (x *Product) GetName() string {
 	if x != nil {
-		return x.RiskModel
+		return x.Name
 	}
 	return ""
 }
 
-func (x *VaRRequest) GetConfidenceLevel() float64 {
+func (x *Product) GetDescription() string {
 	if x != nil {
-		return x.ConfidenceLevel
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetStripeProductId() string {
+	if x != nil {
+		return x.StripeProductId
+	}
+	return ""
+}
+
+func (x *Product) GetPriceMonthly() float64 {
+	if x != nil {
+		return x.PriceMonthly
 	}
 	return 0
 }
 
-func (x *VaRRequest) GetHorizonDays() float64 {
+func (x *Product) GetStripePriceIdMonthly() string {
 	if x != nil {
-		return x.HorizonDays
+		return x.StripePriceIdMonthly
+	}
+	return ""
+}
+
+func (x *Product) GetPriceYearly() float64 {
+	if x != nil {
+		return x.PriceYearly
 	}
 	return 0
 }
 
-type VaRResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	ValueAtRisk        *DecimalValue          `protobuf:"bytes,1,opt,name=value_at_risk,json=valueAtRisk,proto3" json:"value_at_risk,omitempty"`
-	AssetNames         []string               `protobuf:"bytes,2,rep,name=asset_names,json=assetNames,proto3" json:"asset_names,omitempty"`
-	CorrelationMatrix  []float64              `protobuf:"fixed64,3,rep,packed,name=correlation_matrix,json=correlationMatrix,proto3" json:"correlation_matrix,omitempty"`
-	VolatilityPerAsset []float64              `protobuf:"fixed64,4,rep,packed,name=volatility_per_asset,json=volatilityPerAsset,proto3" json:"volatility_per_asset,omitempty"`
-	SimulationMode     string                 `protobuf:"bytes,5,opt,name=simulation_mode,json=simulationMode,proto3" json:"simulation_mode,omitempty"`
-	LastUpdate         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *Product) GetStripePriceIdYearly() string {
+	if x != nil {
+		return x.StripePriceIdYearly
+	}
+	return ""
 }
 
-func (x *VaRResponse) Reset() {
-	*x = VaRResponse{}
-	mi := &file_trading_api_proto_msgTypes[37]
+func (x *Product) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type Subscription struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId        string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Status           string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CurrentPeriodEnd int64                  `protobuf:"varint,4,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_trading_api_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VaRResponse) String() string {
+func (x *Subscription) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VaRResponse) ProtoMessage() {}
+func (*Subscription) ProtoMessage() {}
 
-func (x *VaRResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[37]
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2650,75 +5764,61 @@ func (x *VaRResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VaRResponse.ProtoReflect.Descriptor instead.
-func (*VaRResponse) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{37}
-}
-
-func (x *VaRResponse) GetValueAtRisk() *DecimalValue {
-	if x != nil {
-		return x.ValueAtRisk
-	}
-	return nil
-}
-
-func (x *VaRResponse) GetAssetNames() []string {
-	if x != nil {
-		return x.AssetNames
-	}
-	return nil
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *VaRResponse) GetCorrelationMatrix() []float64 {
+func (x *Subscription) GetId() string {
 	if x != nil {
-		return x.CorrelationMatrix
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *VaRResponse) GetVolatilityPerAsset() []float64 {
+func (x *Subscription) GetProductId() string {
 	if x != nil {
-		return x.VolatilityPerAsset
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-func (x *VaRResponse) GetSimulationMode() string {
+func (x *Subscription) GetStatus() string {
 	if x != nil {
-		return x.SimulationMode
+		return x.Status
 	}
 	return ""
 }
 
-func (x *VaRResponse) GetLastUpdate() *timestamppb.Timestamp {
+func (x *Subscription) GetCurrentPeriodEnd() int64 {
 	if x != nil {
-		return x.LastUpdate
+		return x.CurrentPeriodEnd
 	}
-	return nil
+	return 0
 }
 
-type MomentumRequest struct {
+type GetProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"` // optional filter list
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MomentumRequest) Reset() {
-	*x = MomentumRequest{}
-	mi := &file_trading_api_proto_msgTypes[38]
+func (x *GetProductsResponse) Reset() {
+	*x = GetProductsResponse{}
+	mi := &file_trading_api_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MomentumRequest) String() string {
+func (x *GetProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MomentumRequest) ProtoMessage() {}
+func (*GetProductsResponse) ProtoMessage() {}
 
-func (x *MomentumRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[38]
+func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2729,45 +5829,40 @@ func (x *MomentumRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MomentumRequest.ProtoReflect.Descriptor instead.
-func (*MomentumRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *MomentumRequest) GetSymbols() []string {
+func (x *GetProductsResponse) GetProducts() []*Product {
 	if x != nil {
-		return x.Symbols
+		return x.Products
 	}
 	return nil
 }
 
-type MomentumMetric struct {
+type CreateCheckoutSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	LastPrice     float64                `protobuf:"fixed64,2,opt,name=last_price,json=lastPrice,proto3" json:"last_price,omitempty"`
-	PctChange_1M  float64                `protobuf:"fixed64,3,opt,name=pct_change_1m,json=pctChange1m,proto3" json:"pct_change_1m,omitempty"`     // percentage change over ~1 minute
-	PctChange_5M  float64                `protobuf:"fixed64,4,opt,name=pct_change_5m,json=pctChange5m,proto3" json:"pct_change_5m,omitempty"`     // percentage change over ~5 minutes
-	Volatility    float64                `protobuf:"fixed64,5,opt,name=volatility,proto3" json:"volatility,omitempty"`                            // standard deviation of log returns (annualized approximation)
-	MomentumScore float64                `protobuf:"fixed64,6,opt,name=momentum_score,json=momentumScore,proto3" json:"momentum_score,omitempty"` // composite score (weighted changes - volatility penalty)
+	PriceId       string                 `protobuf:"bytes,1,opt,name=price_id,json=priceId,proto3" json:"price_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MomentumMetric) Reset() {
-	*x = MomentumMetric{}
-	mi := &file_trading_api_proto_msgTypes[39]
+func (x *CreateCheckoutSessionRequest) Reset() {
+	*x = CreateCheckoutSessionRequest{}
+	mi := &file_trading_api_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MomentumMetric) String() string {
+func (x *CreateCheckoutSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MomentumMetric) ProtoMessage() {}
+func (*CreateCheckoutSessionRequest) ProtoMessage() {}
 
-func (x *MomentumMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[39]
+func (x *CreateCheckoutSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2778,76 +5873,40 @@ func (x *MomentumMetric) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MomentumMetric.ProtoReflect.Descriptor instead.
-func (*MomentumMetric) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use CreateCheckoutSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCheckoutSessionRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *MomentumMetric) GetSymbol() string {
+func (x *CreateCheckoutSessionRequest) GetPriceId() string {
 	if x != nil {
-		return x.Symbol
+		return x.PriceId
 	}
 	return ""
 }
 
-func (x *MomentumMetric) GetLastPrice() float64 {
-	if x != nil {
-		return x.LastPrice
-	}
-	return 0
-}
-
-func (x *MomentumMetric) GetPctChange_1M() float64 {
-	if x != nil {
-		return x.PctChange_1M
-	}
-	return 0
-}
-
-func (x *MomentumMetric) GetPctChange_5M() float64 {
-	if x != nil {
-		return x.PctChange_5M
-	}
-	return 0
-}
-
-func (x *MomentumMetric) GetVolatility() float64 {
-	if x != nil {
-		return x.Volatility
-	}
-	return 0
-}
-
-func (x *MomentumMetric) GetMomentumScore() float64 {
-	if x != nil {
-		return x.MomentumScore
-	}
-	return 0
-}
-
-type MomentumResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Metrics           []*MomentumMetric      `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
-	GeneratedAtUnixMs int64                  `protobuf:"varint,2,opt,name=generated_at_unix_ms,json=generatedAtUnixMs,proto3" json:"generated_at_unix_ms,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type CreateCheckoutSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MomentumResponse) Reset() {
-	*x = MomentumResponse{}
-	mi := &file_trading_api_proto_msgTypes[40]
+func (x *CreateCheckoutSessionResponse) Reset() {
+	*x = CreateCheckoutSessionResponse{}
+	mi := &file_trading_api_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MomentumResponse) String() string {
+func (x *CreateCheckoutSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MomentumResponse) ProtoMessage() {}
+func (*CreateCheckoutSessionResponse) ProtoMessage() {}
 
-func (x *MomentumResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[40]
+func (x *CreateCheckoutSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2858,49 +5917,45 @@ func (x *MomentumResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MomentumResponse.ProtoReflect.Descriptor instead.
-func (*MomentumResponse) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{40}
-}
-
-func (x *MomentumResponse) GetMetrics() []*MomentumMetric {
-	if x != nil {
-		return x.Metrics
-	}
-	return nil
+// Deprecated: Use CreateCheckoutSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCheckoutSessionResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *MomentumResponse) GetGeneratedAtUnixMs() int64 {
+func (x *CreateCheckoutSessionResponse) GetSessionId() string {
 	if x != nil {
-		return x.GeneratedAtUnixMs
+		return x.SessionId
 	}
-	return 0
+	return ""
 }
 
-type Tick struct {
+type VenueCredential struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
-	TimestampNs   int64                  `protobuf:"varint,3,opt,name=timestamp_ns,json=timestampNs,proto3" json:"timestamp_ns,omitempty"` // Nanosecond precision timestamp
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Venue         string                 `protobuf:"bytes,2,opt,name=venue,proto3" json:"venue,omitempty"` // e.g. "coinbase"
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	ApiKeyMasked  string                 `protobuf:"bytes,4,opt,name=api_key_masked,json=apiKeyMasked,proto3" json:"api_key_masked,omitempty"` // last four characters of the API key
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RotatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Tick) Reset() {
-	*x = Tick{}
-	mi := &file_trading_api_proto_msgTypes[41]
+func (x *VenueCredential) Reset() {
+	*x = VenueCredential{}
+	mi := &file_trading_api_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Tick) String() string {
+func (x *VenueCredential) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tick) ProtoMessage() {}
+func (*VenueCredential) ProtoMessage() {}
 
-func (x *Tick) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[41]
+func (x *VenueCredential) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2911,54 +5966,78 @@ func (x *Tick) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tick.ProtoReflect.Descriptor instead.
-func (*Tick) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use VenueCredential.ProtoReflect.Descriptor instead.
+func (*VenueCredential) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *Tick) GetSymbol() string {
+func (x *VenueCredential) GetId() string {
 	if x != nil {
-		return x.Symbol
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Tick) GetPrice() float64 {
+func (x *VenueCredential) GetVenue() string {
 	if x != nil {
-		return x.Price
+		return x.Venue
 	}
-	return 0
+	return ""
 }
 
-func (x *Tick) GetTimestampNs() int64 {
+func (x *VenueCredential) GetLabel() string {
 	if x != nil {
-		return x.TimestampNs
+		return x.Label
 	}
-	return 0
+	return ""
 }
 
-type TickStreamRequest struct {
+func (x *VenueCredential) GetApiKeyMasked() string {
+	if x != nil {
+		return x.ApiKeyMasked
+	}
+	return ""
+}
+
+func (x *VenueCredential) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *VenueCredential) GetRotatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RotatedAt
+	}
+	return nil
+}
+
+type AddCredentialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Venue         string                 `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`          // Coinbase: the CDP key name
+	ApiSecret     string                 `protobuf:"bytes,4,opt,name=api_secret,json=apiSecret,proto3" json:"api_secret,omitempty"` // Coinbase: the EC private key in PEM form
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TickStreamRequest) Reset() {
-	*x = TickStreamRequest{}
-	mi := &file_trading_api_proto_msgTypes[42]
+func (x *AddCredentialRequest) Reset() {
+	*x = AddCredentialRequest{}
+	mi := &file_trading_api_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TickStreamRequest) String() string {
+func (x *AddCredentialRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TickStreamRequest) ProtoMessage() {}
+func (*AddCredentialRequest) ProtoMessage() {}
 
-func (x *TickStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[42]
+func (x *AddCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2969,40 +6048,61 @@ func (x *TickStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TickStreamRequest.ProtoReflect.Descriptor instead.
-func (*TickStreamRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use AddCredentialRequest.ProtoReflect.Descriptor instead.
+func (*AddCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *TickStreamRequest) GetSymbol() string {
+func (x *AddCredentialRequest) GetVenue() string {
 	if x != nil {
-		return x.Symbol
+		return x.Venue
 	}
 	return ""
 }
 
-type SymbolRequest struct {
+func (x *AddCredentialRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *AddCredentialRequest) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *AddCredentialRequest) GetApiSecret() string {
+	if x != nil {
+		return x.ApiSecret
+	}
+	return ""
+}
+
+type ListCredentialsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Venue         string                 `protobuf:"bytes,1,opt,name=venue,proto3" json:"venue,omitempty"` // optional filter
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SymbolRequest) Reset() {
-	*x = SymbolRequest{}
-	mi := &file_trading_api_proto_msgTypes[43]
+func (x *ListCredentialsRequest) Reset() {
+	*x = ListCredentialsRequest{}
+	mi := &file_trading_api_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SymbolRequest) String() string {
+func (x *ListCredentialsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolRequest) ProtoMessage() {}
+func (*ListCredentialsRequest) ProtoMessage() {}
 
-func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[43]
+func (x *ListCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3013,40 +6113,40 @@ func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolRequest.ProtoReflect.Descriptor instead.
-func (*SymbolRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ListCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*ListCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *SymbolRequest) GetSymbol() string {
+func (x *ListCredentialsRequest) GetVenue() string {
 	if x != nil {
-		return x.Symbol
+		return x.Venue
 	}
 	return ""
 }
 
-type SymbolList struct {
+type ListCredentialsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Credentials   []*VenueCredential     `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SymbolList) Reset() {
-	*x = SymbolList{}
-	mi := &file_trading_api_proto_msgTypes[44]
+func (x *ListCredentialsResponse) Reset() {
+	*x = ListCredentialsResponse{}
+	mi := &file_trading_api_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SymbolList) String() string {
+func (x *ListCredentialsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolList) ProtoMessage() {}
+func (*ListCredentialsResponse) ProtoMessage() {}
 
-func (x *SymbolList) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[44]
+func (x *ListCredentialsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3057,43 +6157,42 @@ func (x *SymbolList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolList.ProtoReflect.Descriptor instead.
-func (*SymbolList) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use ListCredentialsResponse.ProtoReflect.Descriptor instead.
+func (*ListCredentialsResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *SymbolList) GetSymbols() []string {
+func (x *ListCredentialsResponse) GetCredentials() []*VenueCredential {
 	if x != nil {
-		return x.Symbols
+		return x.Credentials
 	}
 	return nil
 }
 
-type StrategyRequest struct {
+type RotateCredentialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StrategyId    string                 `protobuf:"bytes,1,opt,name=strategy_id,json=strategyId,proto3" json:"strategy_id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Parameters    map[string]string      `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CredentialId  string                 `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	ApiKey        string                 `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	ApiSecret     string                 `protobuf:"bytes,3,opt,name=api_secret,json=apiSecret,proto3" json:"api_secret,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StrategyRequest) Reset() {
-	*x = StrategyRequest{}
-	mi := &file_trading_api_proto_msgTypes[45]
+func (x *RotateCredentialRequest) Reset() {
+	*x = RotateCredentialRequest{}
+	mi := &file_trading_api_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StrategyRequest) String() string {
+func (x *RotateCredentialRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StrategyRequest) ProtoMessage() {}
+func (*RotateCredentialRequest) ProtoMessage() {}
 
-func (x *StrategyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[45]
+func (x *RotateCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3104,69 +6203,54 @@ func (x *StrategyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StrategyRequest.ProtoReflect.Descriptor instead.
-func (*StrategyRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{45}
-}
-
-func (x *StrategyRequest) GetStrategyId() string {
-	if x != nil {
-		return x.StrategyId
-	}
-	return ""
+// Deprecated: Use RotateCredentialRequest.ProtoReflect.Descriptor instead.
+func (*RotateCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *StrategyRequest) GetSymbol() string {
+func (x *RotateCredentialRequest) GetCredentialId() string {
 	if x != nil {
-		return x.Symbol
+		return x.CredentialId
 	}
 	return ""
 }
 
-func (x *StrategyRequest) GetParameters() map[string]string {
+func (x *RotateCredentialRequest) GetApiKey() string {
 	if x != nil {
-		return x.Parameters
+		return x.ApiKey
 	}
-	return nil
+	return ""
 }
 
-func (x *StrategyRequest) GetUserId() string {
+func (x *RotateCredentialRequest) GetApiSecret() string {
 	if x != nil {
-		return x.UserId
+		return x.ApiSecret
 	}
 	return ""
 }
 
-type Product struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StripeProductId      string                 `protobuf:"bytes,4,opt,name=stripe_product_id,json=stripeProductId,proto3" json:"stripe_product_id,omitempty"`
-	PriceMonthly         float64                `protobuf:"fixed64,5,opt,name=price_monthly,json=priceMonthly,proto3" json:"price_monthly,omitempty"`
-	StripePriceIdMonthly string                 `protobuf:"bytes,6,opt,name=stripe_price_id_monthly,json=stripePriceIdMonthly,proto3" json:"stripe_price_id_monthly,omitempty"`
-	PriceYearly          float64                `protobuf:"fixed64,7,opt,name=price_yearly,json=priceYearly,proto3" json:"price_yearly,omitempty"`
-	StripePriceIdYearly  string                 `protobuf:"bytes,8,opt,name=stripe_price_id_yearly,json=stripePriceIdYearly,proto3" json:"stripe_price_id_yearly,omitempty"`
-	Features             []string               `protobuf:"bytes,9,rep,name=features,proto3" json:"features,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+type DeleteCredentialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialId  string                 `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Product) Reset() {
-	*x = Product{}
-	mi := &file_trading_api_proto_msgTypes[46]
+func (x *DeleteCredentialRequest) Reset() {
+	*x = DeleteCredentialRequest{}
+	mi := &file_trading_api_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Product) String() string {
+func (x *DeleteCredentialRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Product) ProtoMessage() {}
+func (*DeleteCredentialRequest) ProtoMessage() {}
 
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[46]
+func (x *DeleteCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3177,99 +6261,139 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{46}
+// Deprecated: Use DeleteCredentialRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *Product) GetId() string {
+func (x *DeleteCredentialRequest) GetCredentialId() string {
 	if x != nil {
-		return x.Id
+		return x.CredentialId
 	}
 	return ""
 }
 
-func (x *Product) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // default 100
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Product) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_trading_api_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Product) GetStripeProductId() string {
-	if x != nil {
-		return x.StripeProductId
-	}
-	return ""
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Product) GetPriceMonthly() float64 {
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[87]
 	if x != nil {
-		return x.PriceMonthly
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Product) GetStripePriceIdMonthly() string {
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
 	if x != nil {
-		return x.StripePriceIdMonthly
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetPriceYearly() float64 {
+func (x *ListUsersRequest) GetOffset() int32 {
 	if x != nil {
-		return x.PriceYearly
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *Product) GetStripePriceIdYearly() string {
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserInfo            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_trading_api_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[88]
 	if x != nil {
-		return x.StripePriceIdYearly
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Product) GetFeatures() []string {
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserInfo {
 	if x != nil {
-		return x.Features
+		return x.Users
 	}
 	return nil
 }
 
-type Subscription struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProductId        string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Status           string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	CurrentPeriodEnd int64                  `protobuf:"varint,4,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// ForceStopBotsRequest stops one bot, or every active bot of a user.
+type ForceStopBotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BotId         string                 `protobuf:"bytes,1,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // recorded in the server log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Subscription) Reset() {
-	*x = Subscription{}
-	mi := &file_trading_api_proto_msgTypes[47]
+func (x *ForceStopBotsRequest) Reset() {
+	*x = ForceStopBotsRequest{}
+	mi := &file_trading_api_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Subscription) String() string {
+func (x *ForceStopBotsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Subscription) ProtoMessage() {}
+func (*ForceStopBotsRequest) ProtoMessage() {}
 
-func (x *Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[47]
+func (x *ForceStopBotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3280,61 +6404,54 @@ func (x *Subscription) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
-func (*Subscription) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use ForceStopBotsRequest.ProtoReflect.Descriptor instead.
+func (*ForceStopBotsRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *Subscription) GetId() string {
+func (x *ForceStopBotsRequest) GetBotId() string {
 	if x != nil {
-		return x.Id
+		return x.BotId
 	}
 	return ""
 }
 
-func (x *Subscription) GetProductId() string {
+func (x *ForceStopBotsRequest) GetUserId() string {
 	if x != nil {
-		return x.ProductId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Subscription) GetStatus() string {
+func (x *ForceStopBotsRequest) GetReason() string {
 	if x != nil {
-		return x.Status
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *Subscription) GetCurrentPeriodEnd() int64 {
-	if x != nil {
-		return x.CurrentPeriodEnd
-	}
-	return 0
-}
-
-type GetProductsResponse struct {
+type ForceStopBotsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	StoppedBotIds []string               `protobuf:"bytes,1,rep,name=stopped_bot_ids,json=stoppedBotIds,proto3" json:"stopped_bot_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductsResponse) Reset() {
-	*x = GetProductsResponse{}
-	mi := &file_trading_api_proto_msgTypes[48]
+func (x *ForceStopBotsResponse) Reset() {
+	*x = ForceStopBotsResponse{}
+	mi := &file_trading_api_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductsResponse) String() string {
+func (x *ForceStopBotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductsResponse) ProtoMessage() {}
+func (*ForceStopBotsResponse) ProtoMessage() {}
 
-func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[48]
+func (x *ForceStopBotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3345,40 +6462,47 @@ func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductsResponse) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use ForceStopBotsResponse.ProtoReflect.Descriptor instead.
+func (*ForceStopBotsResponse) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *GetProductsResponse) GetProducts() []*Product {
+func (x *ForceStopBotsResponse) GetStoppedBotIds() []string {
 	if x != nil {
-		return x.Products
+		return x.StoppedBotIds
 	}
 	return nil
 }
 
-type CreateCheckoutSessionRequest struct {
+// ExportRequest selects the rows to export: a bot's, or those of every bot of a user.
+// Rows are exported oldest first.
+type ExportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PriceId       string                 `protobuf:"bytes,1,opt,name=price_id,json=priceId,proto3" json:"price_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // defaults to the caller when bot_id is empty
+	BotId         string                 `protobuf:"bytes,2,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`                        // trades and orders only
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // inclusive
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // exclusive
+	Format        ExportFormat           `protobuf:"varint,6,opt,name=format,proto3,enum=trading.ExportFormat" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCheckoutSessionRequest) Reset() {
-	*x = CreateCheckoutSessionRequest{}
-	mi := &file_trading_api_proto_msgTypes[49]
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_trading_api_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCheckoutSessionRequest) String() string {
+func (x *ExportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCheckoutSessionRequest) ProtoMessage() {}
+func (*ExportRequest) ProtoMessage() {}
 
-func (x *CreateCheckoutSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[49]
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3389,40 +6513,78 @@ func (x *CreateCheckoutSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCheckoutSessionRequest.ProtoReflect.Descriptor instead.
-func (*CreateCheckoutSessionRequest) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *CreateCheckoutSessionRequest) GetPriceId() string {
+func (x *ExportRequest) GetUserId() string {
 	if x != nil {
-		return x.PriceId
+		return x.UserId
 	}
 	return ""
 }
 
-type CreateCheckoutSessionResponse struct {
+func (x *ExportRequest) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+// ExportChunk is the next piece of the file; concatenate data in order.
+type ExportChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // first chunk only
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // first chunk only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCheckoutSessionResponse) Reset() {
-	*x = CreateCheckoutSessionResponse{}
-	mi := &file_trading_api_proto_msgTypes[50]
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_trading_api_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCheckoutSessionResponse) String() string {
+func (x *ExportChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCheckoutSessionResponse) ProtoMessage() {}
+func (*ExportChunk) ProtoMessage() {}
 
-func (x *CreateCheckoutSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_trading_api_proto_msgTypes[50]
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_trading_api_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3433,14 +6595,28 @@ func (x *CreateCheckoutSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCheckoutSessionResponse.ProtoReflect.Descriptor instead.
-func (*CreateCheckoutSessionResponse) Descriptor() ([]byte, []int) {
-	return file_trading_api_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_trading_api_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *CreateCheckoutSessionResponse) GetSessionId() string {
+func (x *ExportChunk) GetData() []byte {
 	if x != nil {
-		return x.SessionId
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
 	}
 	return ""
 }
@@ -3497,7 +6673,7 @@ const file_trading_api_proto_rawDesc = "" +
 	"\x12ListOrdersResponse\x12&\n" +
 	"\x06orders\x18\x01 \x03(\v2\x0e.trading.OrderR\x06orders\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xff\x04\n" +
+	"totalCount\"\xd6\x06\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
 	"\x06bot_id\x18\x02 \x01(\tR\x05botId\x12\x16\n" +
@@ -3516,9 +6692,14 @@ const file_trading_api_proto_rawDesc = "" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12&\n" +
-	"\x06trades\x18\r \x03(\v2\x0e.trading.TradeR\x06tradesB\x0e\n" +
+	"\x06trades\x18\r \x03(\v2\x0e.trading.TradeR\x06trades\x128\n" +
+	"\rtime_in_force\x18\x0e \x01(\x0e2\x14.trading.TimeInForceR\vtimeInForce\x127\n" +
+	"\texpire_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12&\n" +
+	"\x0fclient_order_id\x18\x10 \x01(\tR\rclientOrderId\x12\x14\n" +
+	"\x05venue\x18\x11 \x01(\tR\x05venue\x12$\n" +
+	"\x0evenue_order_id\x18\x12 \x01(\tR\fvenueOrderIdB\x0e\n" +
 	"\f_limit_priceB\r\n" +
-	"\v_stop_price\"\xdd\x02\n" +
+	"\v_stop_price\"\xf8\x03\n" +
 	"\x12CreateOrderRequest\x12\x15\n" +
 	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12&\n" +
@@ -3528,14 +6709,85 @@ const file_trading_api_proto_rawDesc = "" +
 	"\vlimit_price\x18\x06 \x01(\v2\x15.trading.DecimalValueH\x00R\n" +
 	"limitPrice\x88\x01\x01\x129\n" +
 	"\n" +
-	"stop_price\x18\a \x01(\v2\x15.trading.DecimalValueH\x01R\tstopPrice\x88\x01\x01B\x0e\n" +
+	"stop_price\x18\a \x01(\v2\x15.trading.DecimalValueH\x01R\tstopPrice\x88\x01\x01\x128\n" +
+	"\rtime_in_force\x18\b \x01(\x0e2\x14.trading.TimeInForceR\vtimeInForce\x127\n" +
+	"\texpire_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12&\n" +
+	"\x0fclient_order_id\x18\n" +
+	" \x01(\tR\rclientOrderIdB\x0e\n" +
 	"\f_limit_priceB\r\n" +
 	"\v_stop_price\"F\n" +
 	"\x12CancelOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x15\n" +
-	"\x06bot_id\x18\x02 \x01(\tR\x05botId\",\n" +
+	"\x06bot_id\x18\x02 \x01(\tR\x05botId\"k\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\"}\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x15\n" +
+	"\x06bot_id\x18\x03 \x01(\tR\x05botId\"W\n" +
+	"\x19StreamOrderUpdatesRequest\x12\x15\n" +
+	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12#\n" +
+	"\rfrom_sequence\x18\x02 \x01(\x03R\ffromSequence\"\xdc\x01\n" +
+	"\vOrderUpdate\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12,\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.trading.OrderUpdateTypeR\x04type\x12$\n" +
+	"\x05order\x18\x03 \x01(\v2\x0e.trading.OrderR\x05order\x12\"\n" +
+	"\x04fill\x18\x04 \x01(\v2\x0e.trading.TradeR\x04fill\x129\n" +
+	"\n" +
+	"event_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\teventTime\"\xa2\x03\n" +
+	"\x16CreateAlgoOrderRequest\x12\x15\n" +
+	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12&\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x12.trading.OrderSideR\x04side\x12%\n" +
+	"\x04algo\x18\x04 \x01(\x0e2\x11.trading.AlgoTypeR\x04algo\x121\n" +
+	"\bquantity\x18\x05 \x01(\v2\x15.trading.DecimalValueR\bquantity\x12;\n" +
+	"\vlimit_price\x18\x06 \x01(\v2\x15.trading.DecimalValueH\x00R\n" +
+	"limitPrice\x88\x01\x01\x12)\n" +
+	"\x10duration_minutes\x18\a \x01(\x05R\x0fdurationMinutes\x12\x1d\n" +
+	"\n" +
+	"num_slices\x18\b \x01(\x05R\tnumSlices\x12@\n" +
+	"\x10display_quantity\x18\t \x01(\v2\x15.trading.DecimalValueR\x0fdisplayQuantityB\x0e\n" +
+	"\f_limit_price\"6\n" +
+	"\x10AlgoOrderRequest\x12\"\n" +
+	"\ralgo_order_id\x18\x01 \x01(\tR\valgoOrderId\"\xe7\x05\n" +
+	"\tAlgoOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06bot_id\x18\x02 \x01(\tR\x05botId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12&\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x12.trading.OrderSideR\x04side\x12%\n" +
+	"\x04algo\x18\x05 \x01(\x0e2\x11.trading.AlgoTypeR\x04algo\x12+\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x13.trading.AlgoStatusR\x06status\x121\n" +
+	"\bquantity\x18\a \x01(\v2\x15.trading.DecimalValueR\bquantity\x12:\n" +
+	"\rquantity_sent\x18\b \x01(\v2\x15.trading.DecimalValueR\fquantitySent\x12>\n" +
+	"\x0fquantity_filled\x18\t \x01(\v2\x15.trading.DecimalValueR\x0equantityFilled\x12!\n" +
+	"\fslices_total\x18\n" +
+	" \x01(\x05R\vslicesTotal\x12\x1f\n" +
+	"\vslices_sent\x18\v \x01(\x05R\n" +
+	"slicesSent\x12#\n" +
+	"\rarrival_price\x18\f \x01(\x01R\farrivalPrice\x12,\n" +
+	"\x12average_fill_price\x18\r \x01(\x01R\x10averageFillPrice\x12!\n" +
+	"\fslippage_bps\x18\x0e \x01(\x01R\vslippageBps\x12&\n" +
+	"\x0fchild_order_ids\x18\x0f \x03(\tR\rchildOrderIds\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\amessage\x18\x12 \x01(\tR\amessage\"\xfa\x01\n" +
+	"\vDiscrepancy\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.trading.DiscrepancyTypeR\x04type\x12\x14\n" +
+	"\x05venue\x18\x02 \x01(\tR\x05venue\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x19\n" +
+	"\border_id\x18\x04 \x01(\tR\aorderId\x12$\n" +
+	"\x0evenue_order_id\x18\x05 \x01(\tR\fvenueOrderId\x12\x16\n" +
+	"\x06symbol\x18\x06 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06detail\x18\a \x01(\tR\x06detail\x12\x1c\n" +
+	"\tcorrected\x18\b \x01(\bR\tcorrected\"\xd8\x01\n" +
+	"\x14ReconciliationReport\x121\n" +
+	"\x06run_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12\x14\n" +
+	"\x05venue\x18\x02 \x01(\tR\x05venue\x12:\n" +
+	"\rdiscrepancies\x18\x03 \x03(\v2\x14.trading.DiscrepancyR\rdiscrepancies\x12%\n" +
+	"\x0efills_imported\x18\x04 \x01(\x05R\rfillsImported\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"=\n" +
+	"\x18RunReconciliationRequest\x12!\n" +
+	"\fauto_correct\x18\x01 \x01(\bR\vautoCorrect\"}\n" +
 	"\tOrderBook\x12+\n" +
 	"\x04bids\x18\x01 \x03(\v2\x17.trading.OrderBookEntryR\x04bids\x12+\n" +
 	"\x04asks\x18\x02 \x03(\v2\x17.trading.OrderBookEntryR\x04asks\x12\x16\n" +
@@ -3545,7 +6797,7 @@ const file_trading_api_proto_rawDesc = "" +
 	"\x04size\x18\x02 \x01(\x01R\x04size\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"*\n" +
 	"\x10OrderBookRequest\x12\x16\n" +
-	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x9a\x04\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\xfb\x04\n" +
 	"\x05Trade\x12\x19\n" +
 	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
@@ -3563,10 +6815,13 @@ const file_trading_api_proto_rawDesc = "" +
 	"\x15executed_at_timestamp\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\x13executedAtTimestamp\x12=\n" +
 	"\fpnl_realized\x18\v \x01(\v2\x15.trading.DecimalValueH\x01R\vpnlRealized\x88\x01\x01\x12A\n" +
-	"\x0epnl_unrealized\x18\f \x01(\v2\x15.trading.DecimalValueH\x02R\rpnlUnrealized\x88\x01\x01B\r\n" +
+	"\x0epnl_unrealized\x18\f \x01(\v2\x15.trading.DecimalValueH\x02R\rpnlUnrealized\x88\x01\x01\x12&\n" +
+	"\x0fclient_order_id\x18\r \x01(\tR\rclientOrderId\x12\x19\n" +
+	"\border_id\x18\x0e \x01(\tR\aorderId\x12\x1c\n" +
+	"\tliquidity\x18\x0f \x01(\tR\tliquidityB\r\n" +
 	"\v_commissionB\x0f\n" +
 	"\r_pnl_realizedB\x11\n" +
-	"\x0f_pnl_unrealized\"\xb5\x01\n" +
+	"\x0f_pnl_unrealized\"\xdd\x01\n" +
 	"\fTradeRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
 	"\x04side\x18\x02 \x01(\tR\x04side\x12\x12\n" +
@@ -3575,22 +6830,42 @@ const file_trading_api_proto_rawDesc = "" +
 	"\vstrategy_id\x18\x05 \x01(\tR\n" +
 	"strategyId\x12\x17\n" +
 	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x15\n" +
-	"\x06bot_id\x18\a \x01(\tR\x05botId\"~\n" +
+	"\x06bot_id\x18\a \x01(\tR\x05botId\x12&\n" +
+	"\x0fclient_order_id\x18\b \x01(\tR\rclientOrderId\"\x99\x01\n" +
 	"\rTradeResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
 	"\x0eexecuted_price\x18\x03 \x01(\x01R\rexecutedPrice\x12\x10\n" +
-	"\x03pnl\x18\x04 \x01(\x01R\x03pnl\"E\n" +
+	"\x03pnl\x18\x04 \x01(\x01R\x03pnl\x12\x19\n" +
+	"\btrade_id\x18\x05 \x01(\tR\atradeId\"\x9f\x02\n" +
 	"\x13TradeHistoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
-	"\x06bot_id\x18\x02 \x01(\tR\x05botId\"_\n" +
+	"\x06bot_id\x18\x02 \x01(\tR\x05botId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x04 \x01(\tR\x04side\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"\xc2\x01\n" +
 	"\x14TradeHistoryResponse\x12&\n" +
 	"\x06trades\x18\x01 \x03(\v2\x0e.trading.TradeR\x06trades\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"E\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x129\n" +
+	"\tsummaries\x18\x04 \x03(\v2\x1b.trading.TradeSymbolSummaryR\tsummaries\"\x94\x02\n" +
+	"\x12TradeSymbolSummary\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1f\n" +
+	"\vtrade_count\x18\x02 \x01(\x05R\n" +
+	"tradeCount\x12-\n" +
+	"\x06volume\x18\x03 \x01(\v2\x15.trading.DecimalValueR\x06volume\x121\n" +
+	"\bnotional\x18\x04 \x01(\v2\x15.trading.DecimalValueR\bnotional\x12)\n" +
+	"\x04fees\x18\x05 \x01(\v2\x15.trading.DecimalValueR\x04fees\x128\n" +
+	"\frealized_pnl\x18\x06 \x01(\v2\x15.trading.DecimalValueR\vrealizedPnl\"E\n" +
 	"\vAuthRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xc6\x01\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xa6\x03\n" +
 	"\fAuthResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
@@ -3598,19 +6873,101 @@ const file_trading_api_proto_rawDesc = "" +
 	"\x0fexpires_at_unix\x18\x04 \x01(\x03R\rexpiresAtUnix\x12\x12\n" +
 	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1a\n" +
 	"\busername\x18\x06 \x01(\tR\busername\x12\x14\n" +
-	"\x05email\x18\a \x01(\tR\x05email\",\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12#\n" +
+	"\rrefresh_token\x18\b \x01(\tR\frefreshToken\x125\n" +
+	"\x17refresh_expires_at_unix\x18\t \x01(\x03R\x14refreshExpiresAtUnix\x12!\n" +
+	"\fmfa_required\x18\n" +
+	" \x01(\bR\vmfaRequired\x126\n" +
+	"\x17mfa_enrollment_required\x18\v \x01(\bR\x15mfaEnrollmentRequired\x12'\n" +
+	"\x0fchallenge_token\x18\f \x01(\tR\x0echallengeToken\",\n" +
 	"\x0eGetUserRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\"_\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\"d\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"\xb8\x01\n" +
 	"\bUserInfo\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12&\n" +
-	"\x0fcreated_at_unix\x18\x03 \x01(\x03R\rcreatedAtUnix\":\n" +
+	"\x0fcreated_at_unix\x18\x03 \x01(\x03R\rcreatedAtUnix\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12%\n" +
+	"\x0eemail_verified\x18\x06 \x01(\bR\remailVerified\",\n" +
+	"\x14UpdateProfileRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"K\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\":\n" +
 	"\x13RefreshTokenRequest\x12#\n" +
-	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xb0\x05\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"4\n" +
+	"\rLogoutRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xf1\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12F\n" +
+	"\x11last_refreshed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastRefreshedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\bR\acurrent\"D\n" +
+	"\x14ListSessionsResponse\x12,\n" +
+	"\bsessions\x18\x01 \x03(\v2\x10.trading.SessionR\bsessions\"5\n" +
+	"\x14RevokeSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xb1\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x1f\n" +
+	"\vallowed_ips\x18\x05 \x03(\tR\n" +
+	"allowedIps\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x8a\x01\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12\x1f\n" +
+	"\vallowed_ips\x18\x03 \x03(\tR\n" +
+	"allowedIps\x12&\n" +
+	"\x0fexpires_in_days\x18\x04 \x01(\x05R\rexpiresInDays\"Q\n" +
+	"\x14CreateAPIKeyResponse\x12!\n" +
+	"\x03key\x18\x01 \x01(\v2\x0f.trading.APIKeyR\x03key\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\":\n" +
+	"\x13ListAPIKeysResponse\x12#\n" +
+	"\x04keys\x18\x01 \x03(\v2\x0f.trading.APIKeyR\x04keys\",\n" +
+	"\x13RevokeAPIKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"<\n" +
+	"\x11EnrollTOTPRequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\"W\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x02 \x01(\tR\x0fprovisioningUri\"P\n" +
+	"\x11VerifyTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12'\n" +
+	"\x0fchallenge_token\x18\x02 \x01(\tR\x0echallengeToken\"f\n" +
+	"\x12VerifyTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\x12)\n" +
+	"\x04auth\x18\x02 \x01(\v2\x15.trading.AuthResponseR\x04auth\"x\n" +
+	"\x14CompleteLoginRequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12#\n" +
+	"\rrecovery_code\x18\x03 \x01(\tR\frecoveryCode\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"O\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xfe\x05\n" +
 	"\x03Bot\x12\x15\n" +
 	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
@@ -3631,19 +6988,27 @@ const file_trading_api_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a=\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x0fstrategy_status\x18\x10 \x01(\tR\x0estrategyStatus\x12#\n" +
+	"\rstatus_reason\x18\x11 \x01(\tR\fstatusReason\x1a=\n" +
 	"\x0fParametersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb2\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x02\n" +
 	"\x10UpdateBotRequest\x12\x15\n" +
 	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
 	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12 \n" +
-	"\tis_active\x18\x04 \x01(\bH\x02R\bisActive\x88\x01\x01B\a\n" +
+	"\tis_active\x18\x04 \x01(\bH\x02R\bisActive\x88\x01\x01\x12I\n" +
+	"\n" +
+	"parameters\x18\x05 \x03(\v2).trading.UpdateBotRequest.ParametersEntryR\n" +
+	"parameters\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
 	"\x05_nameB\x0e\n" +
 	"\f_descriptionB\f\n" +
 	"\n" +
-	"_is_active\"\x89\x02\n" +
+	"_is_active\"\xa2\x02\n" +
 	"\x10CreateBotRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
 	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12I\n" +
@@ -3651,7 +7016,8 @@ const file_trading_api_proto_rawDesc = "" +
 	"parameters\x18\x03 \x03(\v2).trading.CreateBotRequest.ParametersEntryR\n" +
 	"parameters\x12\x12\n" +
 	"\x04name\x18\x04 \x01(\tR\x04name\x12#\n" +
-	"\raccount_value\x18\x05 \x01(\x01R\faccountValue\x1a=\n" +
+	"\raccount_value\x18\x05 \x01(\x01R\faccountValue\x12\x17\n" +
+	"\ais_live\x18\x06 \x01(\bR\x06isLive\x1a=\n" +
 	"\x0fParametersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"%\n" +
@@ -3736,7 +7102,56 @@ const file_trading_api_proto_rawDesc = "" +
 	"\bprice_id\x18\x01 \x01(\tR\apriceId\">\n" +
 	"\x1dCreateCheckoutSessionResponse\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId*:\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xe9\x01\n" +
+	"\x0fVenueCredential\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05venue\x18\x02 \x01(\tR\x05venue\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12$\n" +
+	"\x0eapi_key_masked\x18\x04 \x01(\tR\fapiKeyMasked\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"rotated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\trotatedAt\"z\n" +
+	"\x14AddCredentialRequest\x12\x14\n" +
+	"\x05venue\x18\x01 \x01(\tR\x05venue\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x17\n" +
+	"\aapi_key\x18\x03 \x01(\tR\x06apiKey\x12\x1d\n" +
+	"\n" +
+	"api_secret\x18\x04 \x01(\tR\tapiSecret\".\n" +
+	"\x16ListCredentialsRequest\x12\x14\n" +
+	"\x05venue\x18\x01 \x01(\tR\x05venue\"U\n" +
+	"\x17ListCredentialsResponse\x12:\n" +
+	"\vcredentials\x18\x01 \x03(\v2\x18.trading.VenueCredentialR\vcredentials\"v\n" +
+	"\x17RotateCredentialRequest\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\tR\fcredentialId\x12\x17\n" +
+	"\aapi_key\x18\x02 \x01(\tR\x06apiKey\x12\x1d\n" +
+	"\n" +
+	"api_secret\x18\x03 \x01(\tR\tapiSecret\">\n" +
+	"\x17DeleteCredentialRequest\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\tR\fcredentialId\"@\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"<\n" +
+	"\x11ListUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.trading.UserInfoR\x05users\"^\n" +
+	"\x14ForceStopBotsRequest\x12\x15\n" +
+	"\x06bot_id\x18\x01 \x01(\tR\x05botId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"?\n" +
+	"\x15ForceStopBotsResponse\x12&\n" +
+	"\x0fstopped_bot_ids\x18\x01 \x03(\tR\rstoppedBotIds\"\xf8\x01\n" +
+	"\rExportRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06bot_id\x18\x02 \x01(\tR\x05botId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12-\n" +
+	"\x06format\x18\x06 \x01(\x0e2\x15.trading.ExportFormatR\x06format\"`\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType*:\n" +
 	"\tOrderSide\x12\x1a\n" +
 	"\x16ORDER_SIDE_UNSPECIFIED\x10\x00\x12\a\n" +
 	"\x03BUY\x10\x01\x12\b\n" +
@@ -3746,7 +7161,7 @@ const file_trading_api_proto_rawDesc = "" +
 	"\n" +
 	"\x06MARKET\x10\x01\x12\t\n" +
 	"\x05LIMIT\x10\x02\x12\b\n" +
-	"\x04STOP\x10\x03*\x81\x01\n" +
+	"\x04STOP\x10\x03*\x8e\x01\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\a\n" +
 	"\x03NEW\x10\x01\x12\r\n" +
@@ -3755,23 +7170,84 @@ const file_trading_api_proto_rawDesc = "" +
 	"\n" +
 	"\x06FILLED\x10\x04\x12\f\n" +
 	"\bCANCELED\x10\x05\x12\f\n" +
-	"\bREJECTED\x10\x062\x8d\x02\n" +
+	"\bREJECTED\x10\x06\x12\v\n" +
+	"\aEXPIRED\x10\a*Y\n" +
+	"\vTimeInForce\x12\x1d\n" +
+	"\x19TIME_IN_FORCE_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03GTC\x10\x01\x12\a\n" +
+	"\x03IOC\x10\x02\x12\a\n" +
+	"\x03FOK\x10\x03\x12\a\n" +
+	"\x03GTD\x10\x04\x12\a\n" +
+	"\x03DAY\x10\x05*F\n" +
+	"\bAlgoType\x12\x19\n" +
+	"\x15ALGO_TYPE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04TWAP\x10\x01\x12\b\n" +
+	"\x04VWAP\x10\x02\x12\v\n" +
+	"\aICEBERG\x10\x03*s\n" +
+	"\n" +
+	"AlgoStatus\x12\x1b\n" +
+	"\x17ALGO_STATUS_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fALGO_WORKING\x10\x01\x12\x12\n" +
+	"\x0eALGO_COMPLETED\x10\x02\x12\x11\n" +
+	"\rALGO_CANCELED\x10\x03\x12\x0f\n" +
+	"\vALGO_FAILED\x10\x04*d\n" +
+	"\x0fOrderUpdateType\x12!\n" +
+	"\x1dORDER_UPDATE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ORDER_UPDATE_STATUS\x10\x01\x12\x15\n" +
+	"\x11ORDER_UPDATE_FILL\x10\x02*\xb3\x01\n" +
+	"\x0fDiscrepancyType\x12 \n" +
+	"\x1cDISCREPANCY_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ORDER_MISSING_AT_VENUE\x10\x01\x12\x1c\n" +
+	"\x18ORDER_UNKNOWN_INTERNALLY\x10\x02\x12\x1b\n" +
+	"\x17ORDER_CLOSED_INTERNALLY\x10\x03\x12\x10\n" +
+	"\fFILL_MISSING\x10\x04\x12\x15\n" +
+	"\x11BALANCE_SHORTFALL\x10\x05*N\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03CSV\x10\x01\x12\t\n" +
+	"\x05JSONL\x10\x02\x12\v\n" +
+	"\aPARQUET\x10\x032\x8d\x02\n" +
 	"\x10PortfolioService\x12G\n" +
 	"\fGetPortfolio\x12\x19.trading.PortfolioRequest\x1a\x1a.trading.PortfolioResponse\"\x00\x12L\n" +
 	"\x0fStreamPortfolio\x12\x19.trading.PortfolioRequest\x1a\x1a.trading.PortfolioResponse\"\x000\x01\x12b\n" +
-	"\x15GetPerformanceHistory\x12\".trading.PerformanceHistoryRequest\x1a#.trading.PerformanceHistoryResponse\"\x002\xdd\x02\n" +
+	"\x15GetPerformanceHistory\x12\".trading.PerformanceHistoryRequest\x1a#.trading.PerformanceHistoryResponse\"\x002\xab\x06\n" +
 	"\fOrderService\x12<\n" +
 	"\vCreateOrder\x12\x1b.trading.CreateOrderRequest\x1a\x0e.trading.Order\"\x00\x12<\n" +
 	"\vCancelOrder\x12\x1b.trading.CancelOrderRequest\x1a\x0e.trading.Order\"\x00\x126\n" +
 	"\bGetOrder\x12\x18.trading.GetOrderRequest\x1a\x0e.trading.Order\"\x00\x12P\n" +
 	"\x0fGetTradeHistory\x12\x1c.trading.TradeHistoryRequest\x1a\x1d.trading.TradeHistoryResponse\"\x00\x12G\n" +
 	"\n" +
-	"ListOrders\x12\x1a.trading.ListOrdersRequest\x1a\x1b.trading.ListOrdersResponse\"\x002\x82\x02\n" +
+	"ListOrders\x12\x1a.trading.ListOrdersRequest\x1a\x1b.trading.ListOrdersResponse\"\x00\x12H\n" +
+	"\x0fCreateAlgoOrder\x12\x1f.trading.CreateAlgoOrderRequest\x1a\x12.trading.AlgoOrder\"\x00\x12E\n" +
+	"\x12GetAlgoOrderStatus\x12\x19.trading.AlgoOrderRequest\x1a\x12.trading.AlgoOrder\"\x00\x12B\n" +
+	"\x0fCancelAlgoOrder\x12\x19.trading.AlgoOrderRequest\x1a\x12.trading.AlgoOrder\"\x00\x12R\n" +
+	"\x12StreamOrderUpdates\x12\".trading.StreamOrderUpdatesRequest\x1a\x14.trading.OrderUpdate\"\x000\x01\x12J\n" +
+	"\x17GetReconciliationReport\x12\x0e.trading.Empty\x1a\x1d.trading.ReconciliationReport\"\x00\x12W\n" +
+	"\x11RunReconciliation\x12!.trading.RunReconciliationRequest\x1a\x1d.trading.ReconciliationReport\"\x002\xd2\v\n" +
 	"\vAuthService\x12=\n" +
 	"\bRegister\x12\x18.trading.RegisterRequest\x1a\x15.trading.AuthResponse\"\x00\x126\n" +
 	"\x05Login\x12\x14.trading.AuthRequest\x1a\x15.trading.AuthResponse\"\x00\x125\n" +
 	"\aGetUser\x12\x17.trading.GetUserRequest\x1a\x11.trading.UserInfo\x12E\n" +
-	"\fRefreshToken\x12\x1c.trading.RefreshTokenRequest\x1a\x15.trading.AuthResponse\"\x002\x95\x04\n" +
+	"\fRefreshToken\x12\x1c.trading.RefreshTokenRequest\x1a\x15.trading.AuthResponse\"\x00\x12;\n" +
+	"\x06Logout\x12\x16.trading.LogoutRequest\x1a\x17.trading.StatusResponse\"\x00\x12?\n" +
+	"\fListSessions\x12\x0e.trading.Empty\x1a\x1d.trading.ListSessionsResponse\"\x00\x12I\n" +
+	"\rRevokeSession\x12\x1d.trading.RevokeSessionRequest\x1a\x17.trading.StatusResponse\"\x00\x12M\n" +
+	"\fCreateAPIKey\x12\x1c.trading.CreateAPIKeyRequest\x1a\x1d.trading.CreateAPIKeyResponse\"\x00\x12=\n" +
+	"\vListAPIKeys\x12\x0e.trading.Empty\x1a\x1c.trading.ListAPIKeysResponse\"\x00\x12G\n" +
+	"\fRevokeAPIKey\x12\x1c.trading.RevokeAPIKeyRequest\x1a\x17.trading.StatusResponse\"\x00\x12G\n" +
+	"\n" +
+	"EnrollTOTP\x12\x1a.trading.EnrollTOTPRequest\x1a\x1b.trading.EnrollTOTPResponse\"\x00\x12G\n" +
+	"\n" +
+	"VerifyTOTP\x12\x1a.trading.VerifyTOTPRequest\x1a\x1b.trading.VerifyTOTPResponse\"\x00\x12G\n" +
+	"\rCompleteLogin\x12\x1d.trading.CompleteLoginRequest\x1a\x15.trading.AuthResponse\"\x00\x12E\n" +
+	"\vDisableTOTP\x12\x1b.trading.DisableTOTPRequest\x1a\x17.trading.StatusResponse\"\x00\x12W\n" +
+	"\x14RequestPasswordReset\x12$.trading.RequestPasswordResetRequest\x1a\x17.trading.StatusResponse\"\x00\x12I\n" +
+	"\rResetPassword\x12\x1d.trading.ResetPasswordRequest\x1a\x17.trading.StatusResponse\"\x00\x12E\n" +
+	"\vVerifyEmail\x12\x1b.trading.VerifyEmailRequest\x1a\x17.trading.StatusResponse\"\x00\x12D\n" +
+	"\x17ResendVerificationEmail\x12\x0e.trading.Empty\x1a\x17.trading.StatusResponse\"\x00\x12C\n" +
+	"\rUpdateProfile\x12\x1d.trading.UpdateProfileRequest\x1a\x11.trading.UserInfo\"\x00\x12K\n" +
+	"\x0eChangePassword\x12\x1e.trading.ChangePasswordRequest\x1a\x17.trading.StatusResponse\"\x00\x12I\n" +
+	"\rDeleteAccount\x12\x1d.trading.DeleteAccountRequest\x1a\x17.trading.StatusResponse\"\x002\x95\x04\n" +
 	"\n" +
 	"BotService\x12A\n" +
 	"\tCreateBot\x12\x19.trading.CreateBotRequest\x1a\x17.trading.StatusResponse\"\x00\x12/\n" +
@@ -3800,7 +7276,19 @@ const file_trading_api_proto_rawDesc = "" +
 	"\vGetProducts\x12\x0e.trading.Empty\x1a\x1c.trading.GetProductsResponse\"\x00\x12h\n" +
 	"\x15CreateCheckoutSession\x12%.trading.CreateCheckoutSessionRequest\x1a&.trading.CreateCheckoutSessionResponse\"\x00\x12>\n" +
 	"\x13GetUserSubscription\x12\x0e.trading.Empty\x1a\x15.trading.Subscription\"\x00\x12C\n" +
-	"\x16CancelUserSubscription\x12\x0e.trading.Empty\x1a\x17.trading.StatusResponse\"\x00B\x0fZ\raetherion/genb\x06proto3"
+	"\x16CancelUserSubscription\x12\x0e.trading.Empty\x1a\x17.trading.StatusResponse\"\x002\xda\x02\n" +
+	"\x11CredentialService\x12J\n" +
+	"\rAddCredential\x12\x1d.trading.AddCredentialRequest\x1a\x18.trading.VenueCredential\"\x00\x12V\n" +
+	"\x0fListCredentials\x12\x1f.trading.ListCredentialsRequest\x1a .trading.ListCredentialsResponse\"\x00\x12P\n" +
+	"\x10RotateCredential\x12 .trading.RotateCredentialRequest\x1a\x18.trading.VenueCredential\"\x00\x12O\n" +
+	"\x10DeleteCredential\x12 .trading.DeleteCredentialRequest\x1a\x17.trading.StatusResponse\"\x002\xa6\x01\n" +
+	"\fAdminService\x12D\n" +
+	"\tListUsers\x12\x19.trading.ListUsersRequest\x1a\x1a.trading.ListUsersResponse\"\x00\x12P\n" +
+	"\rForceStopBots\x12\x1d.trading.ForceStopBotsRequest\x1a\x1e.trading.ForceStopBotsResponse\"\x002\xda\x01\n" +
+	"\rExportService\x12@\n" +
+	"\fExportTrades\x12\x16.trading.ExportRequest\x1a\x14.trading.ExportChunk\"\x000\x01\x12@\n" +
+	"\fExportOrders\x12\x16.trading.ExportRequest\x1a\x14.trading.ExportChunk\"\x000\x01\x12E\n" +
+	"\x11ExportPerformance\x12\x16.trading.ExportRequest\x1a\x14.trading.ExportChunk\"\x000\x01B\x0fZ\raetherion/genb\x06proto3"
 
 var (
 	file_trading_api_proto_rawDescOnce sync.Once
@@ -3814,198 +7302,360 @@ func file_trading_api_proto_rawDescGZIP() []byte {
 	return file_trading_api_proto_rawDescData
 }
 
-var file_trading_api_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_trading_api_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_trading_api_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_trading_api_proto_msgTypes = make([]protoimpl.MessageInfo, 97)
 var file_trading_api_proto_goTypes = []any{
 	(OrderSide)(0),                        // 0: trading.OrderSide
 	(OrderType)(0),                        // 1: trading.OrderType
 	(OrderStatus)(0),                      // 2: trading.OrderStatus
-	(*Empty)(nil),                         // 3: trading.Empty
-	(*DecimalValue)(nil),                  // 4: trading.DecimalValue
-	(*StatusResponse)(nil),                // 5: trading.StatusResponse
-	(*Pagination)(nil),                    // 6: trading.Pagination
-	(*PortfolioRequest)(nil),              // 7: trading.PortfolioRequest
-	(*PortfolioPosition)(nil),             // 8: trading.PortfolioPosition
-	(*PortfolioResponse)(nil),             // 9: trading.PortfolioResponse
-	(*PerformanceHistoryRequest)(nil),     // 10: trading.PerformanceHistoryRequest
-	(*BotPerformanceSnapshot)(nil),        // 11: trading.BotPerformanceSnapshot
-	(*PerformanceHistoryResponse)(nil),    // 12: trading.PerformanceHistoryResponse
-	(*ListOrdersRequest)(nil),             // 13: trading.ListOrdersRequest
-	(*ListOrdersResponse)(nil),            // 14: trading.ListOrdersResponse
-	(*Order)(nil),                         // 15: trading.Order
-	(*CreateOrderRequest)(nil),            // 16: trading.CreateOrderRequest
-	(*CancelOrderRequest)(nil),            // 17: trading.CancelOrderRequest
-	(*GetOrderRequest)(nil),               // 18: trading.GetOrderRequest
-	(*OrderBook)(nil),                     // 19: trading.OrderBook
-	(*OrderBookEntry)(nil),                // 20: trading.OrderBookEntry
-	(*OrderBookRequest)(nil),              // 21: trading.OrderBookRequest
-	(*Trade)(nil),                         // 22: trading.Trade
-	(*TradeRequest)(nil),                  // 23: trading.TradeRequest
-	(*TradeResponse)(nil),                 // 24: trading.TradeResponse
-	(*TradeHistoryRequest)(nil),           // 25: trading.TradeHistoryRequest
-	(*TradeHistoryResponse)(nil),          // 26: trading.TradeHistoryResponse
-	(*AuthRequest)(nil),                   // 27: trading.AuthRequest
-	(*AuthResponse)(nil),                  // 28: trading.AuthResponse
-	(*GetUserRequest)(nil),                // 29: trading.GetUserRequest
-	(*RegisterRequest)(nil),               // 30: trading.RegisterRequest
-	(*UserInfo)(nil),                      // 31: trading.UserInfo
-	(*RefreshTokenRequest)(nil),           // 32: trading.RefreshTokenRequest
-	(*Bot)(nil),                           // 33: trading.Bot
-	(*UpdateBotRequest)(nil),              // 34: trading.UpdateBotRequest
-	(*CreateBotRequest)(nil),              // 35: trading.CreateBotRequest
-	(*BotIdRequest)(nil),                  // 36: trading.BotIdRequest
-	(*ListBotsRequest)(nil),               // 37: trading.ListBotsRequest
-	(*BotList)(nil),                       // 38: trading.BotList
-	(*VaRRequest)(nil),                    // 39: trading.VaRRequest
-	(*VaRResponse)(nil),                   // 40: trading.VaRResponse
-	(*MomentumRequest)(nil),               // 41: trading.MomentumRequest
-	(*MomentumMetric)(nil),                // 42: trading.MomentumMetric
-	(*MomentumResponse)(nil),              // 43: trading.MomentumResponse
-	(*Tick)(nil),                          // 44: trading.Tick
-	(*TickStreamRequest)(nil),             // 45: trading.TickStreamRequest
-	(*SymbolRequest)(nil),                 // 46: trading.SymbolRequest
-	(*SymbolList)(nil),                    // 47: trading.SymbolList
-	(*StrategyRequest)(nil),               // 48: trading.StrategyRequest
-	(*Product)(nil),                       // 49: trading.Product
-	(*Subscription)(nil),                  // 50: trading.Subscription
-	(*GetProductsResponse)(nil),           // 51: trading.GetProductsResponse
-	(*CreateCheckoutSessionRequest)(nil),  // 52: trading.CreateCheckoutSessionRequest
-	(*CreateCheckoutSessionResponse)(nil), // 53: trading.CreateCheckoutSessionResponse
-	nil,                                   // 54: trading.Bot.ParametersEntry
-	nil,                                   // 55: trading.CreateBotRequest.ParametersEntry
-	nil,                                   // 56: trading.StrategyRequest.ParametersEntry
-	(*timestamppb.Timestamp)(nil),         // 57: google.protobuf.Timestamp
+	(TimeInForce)(0),                      // 3: trading.TimeInForce
+	(AlgoType)(0),                         // 4: trading.AlgoType
+	(AlgoStatus)(0),                       // 5: trading.AlgoStatus
+	(OrderUpdateType)(0),                  // 6: trading.OrderUpdateType
+	(DiscrepancyType)(0),                  // 7: trading.DiscrepancyType
+	(ExportFormat)(0),                     // 8: trading.ExportFormat
+	(*Empty)(nil),                         // 9: trading.Empty
+	(*DecimalValue)(nil),                  // 10: trading.DecimalValue
+	(*StatusResponse)(nil),                // 11: trading.StatusResponse
+	(*Pagination)(nil),                    // 12: trading.Pagination
+	(*PortfolioRequest)(nil),              // 13: trading.PortfolioRequest
+	(*PortfolioPosition)(nil),             // 14: trading.PortfolioPosition
+	(*PortfolioResponse)(nil),             // 15: trading.PortfolioResponse
+	(*PerformanceHistoryRequest)(nil),     // 16: trading.PerformanceHistoryRequest
+	(*BotPerformanceSnapshot)(nil),        // 17: trading.BotPerformanceSnapshot
+	(*PerformanceHistoryResponse)(nil),    // 18: trading.PerformanceHistoryResponse
+	(*ListOrdersRequest)(nil),             // 19: trading.ListOrdersRequest
+	(*ListOrdersResponse)(nil),            // 20: trading.ListOrdersResponse
+	(*Order)(nil),                         // 21: trading.Order
+	(*CreateOrderRequest)(nil),            // 22: trading.CreateOrderRequest
+	(*CancelOrderRequest)(nil),            // 23: trading.CancelOrderRequest
+	(*GetOrderRequest)(nil),               // 24: trading.GetOrderRequest
+	(*StreamOrderUpdatesRequest)(nil),     // 25: trading.StreamOrderUpdatesRequest
+	(*OrderUpdate)(nil),                   // 26: trading.OrderUpdate
+	(*CreateAlgoOrderRequest)(nil),        // 27: trading.CreateAlgoOrderRequest
+	(*AlgoOrderRequest)(nil),              // 28: trading.AlgoOrderRequest
+	(*AlgoOrder)(nil),                     // 29: trading.AlgoOrder
+	(*Discrepancy)(nil),                   // 30: trading.Discrepancy
+	(*ReconciliationReport)(nil),          // 31: trading.ReconciliationReport
+	(*RunReconciliationRequest)(nil),      // 32: trading.RunReconciliationRequest
+	(*OrderBook)(nil),                     // 33: trading.OrderBook
+	(*OrderBookEntry)(nil),                // 34: trading.OrderBookEntry
+	(*OrderBookRequest)(nil),              // 35: trading.OrderBookRequest
+	(*Trade)(nil),                         // 36: trading.Trade
+	(*TradeRequest)(nil),                  // 37: trading.TradeRequest
+	(*TradeResponse)(nil),                 // 38: trading.TradeResponse
+	(*TradeHistoryRequest)(nil),           // 39: trading.TradeHistoryRequest
+	(*TradeHistoryResponse)(nil),          // 40: trading.TradeHistoryResponse
+	(*TradeSymbolSummary)(nil),            // 41: trading.TradeSymbolSummary
+	(*AuthRequest)(nil),                   // 42: trading.AuthRequest
+	(*AuthResponse)(nil),                  // 43: trading.AuthResponse
+	(*GetUserRequest)(nil),                // 44: trading.GetUserRequest
+	(*RegisterRequest)(nil),               // 45: trading.RegisterRequest
+	(*UserInfo)(nil),                      // 46: trading.UserInfo
+	(*UpdateProfileRequest)(nil),          // 47: trading.UpdateProfileRequest
+	(*ChangePasswordRequest)(nil),         // 48: trading.ChangePasswordRequest
+	(*DeleteAccountRequest)(nil),          // 49: trading.DeleteAccountRequest
+	(*RefreshTokenRequest)(nil),           // 50: trading.RefreshTokenRequest
+	(*LogoutRequest)(nil),                 // 51: trading.LogoutRequest
+	(*Session)(nil),                       // 52: trading.Session
+	(*ListSessionsResponse)(nil),          // 53: trading.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),          // 54: trading.RevokeSessionRequest
+	(*APIKey)(nil),                        // 55: trading.APIKey
+	(*CreateAPIKeyRequest)(nil),           // 56: trading.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),          // 57: trading.CreateAPIKeyResponse
+	(*ListAPIKeysResponse)(nil),           // 58: trading.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),           // 59: trading.RevokeAPIKeyRequest
+	(*EnrollTOTPRequest)(nil),             // 60: trading.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),            // 61: trading.EnrollTOTPResponse
+	(*VerifyTOTPRequest)(nil),             // 62: trading.VerifyTOTPRequest
+	(*VerifyTOTPResponse)(nil),            // 63: trading.VerifyTOTPResponse
+	(*CompleteLoginRequest)(nil),          // 64: trading.CompleteLoginRequest
+	(*DisableTOTPRequest)(nil),            // 65: trading.DisableTOTPRequest
+	(*RequestPasswordResetRequest)(nil),   // 66: trading.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),          // 67: trading.ResetPasswordRequest
+	(*VerifyEmailRequest)(nil),            // 68: trading.VerifyEmailRequest
+	(*Bot)(nil),                           // 69: trading.Bot
+	(*UpdateBotRequest)(nil),              // 70: trading.UpdateBotRequest
+	(*CreateBotRequest)(nil),              // 71: trading.CreateBotRequest
+	(*BotIdRequest)(nil),                  // 72: trading.BotIdRequest
+	(*ListBotsRequest)(nil),               // 73: trading.ListBotsRequest
+	(*BotList)(nil),                       // 74: trading.BotList
+	(*VaRRequest)(nil),                    // 75: trading.VaRRequest
+	(*VaRResponse)(nil),                   // 76: trading.VaRResponse
+	(*MomentumRequest)(nil),               // 77: trading.MomentumRequest
+	(*MomentumMetric)(nil),                // 78: trading.MomentumMetric
+	(*MomentumResponse)(nil),              // 79: trading.MomentumResponse
+	(*Tick)(nil),                          // 80: trading.Tick
+	(*TickStreamRequest)(nil),             // 81: trading.TickStreamRequest
+	(*SymbolRequest)(nil),                 // 82: trading.SymbolRequest
+	(*SymbolList)(nil),                    // 83: trading.SymbolList
+	(*StrategyRequest)(nil),               // 84: trading.StrategyRequest
+	(*Product)(nil),                       // 85: trading.Product
+	(*Subscription)(nil),                  // 86: trading.Subscription
+	(*GetProductsResponse)(nil),           // 87: trading.GetProductsResponse
+	(*CreateCheckoutSessionRequest)(nil),  // 88: trading.CreateCheckoutSessionRequest
+	(*CreateCheckoutSessionResponse)(nil), // 89: trading.CreateCheckoutSessionResponse
+	(*VenueCredential)(nil),               // 90: trading.VenueCredential
+	(*AddCredentialRequest)(nil),          // 91: trading.AddCredentialRequest
+	(*ListCredentialsRequest)(nil),        // 92: trading.ListCredentialsRequest
+	(*ListCredentialsResponse)(nil),       // 93: trading.ListCredentialsResponse
+	(*RotateCredentialRequest)(nil),       // 94: trading.RotateCredentialRequest
+	(*DeleteCredentialRequest)(nil),       // 95: trading.DeleteCredentialRequest
+	(*ListUsersRequest)(nil),              // 96: trading.ListUsersRequest
+	(*ListUsersResponse)(nil),             // 97: trading.ListUsersResponse
+	(*ForceStopBotsRequest)(nil),          // 98: trading.ForceStopBotsRequest
+	(*ForceStopBotsResponse)(nil),         // 99: trading.ForceStopBotsResponse
+	(*ExportRequest)(nil),                 // 100: trading.ExportRequest
+	(*ExportChunk)(nil),                   // 101: trading.ExportChunk
+	nil,                                   // 102: trading.Bot.ParametersEntry
+	nil,                                   // 103: trading.UpdateBotRequest.ParametersEntry
+	nil,                                   // 104: trading.CreateBotRequest.ParametersEntry
+	nil,                                   // 105: trading.StrategyRequest.ParametersEntry
+	(*timestamppb.Timestamp)(nil),         // 106: google.protobuf.Timestamp
 }
 var file_trading_api_proto_depIdxs = []int32{
-	4,  // 0: trading.PortfolioPosition.quantity:type_name -> trading.DecimalValue
-	4,  // 1: trading.PortfolioPosition.average_price:type_name -> trading.DecimalValue
-	4,  // 2: trading.PortfolioPosition.market_value:type_name -> trading.DecimalValue
-	4,  // 3: trading.PortfolioPosition.unrealized_pnl:type_name -> trading.DecimalValue
-	4,  // 4: trading.PortfolioPosition.exposure_pct:type_name -> trading.DecimalValue
-	8,  // 5: trading.PortfolioResponse.positions:type_name -> trading.PortfolioPosition
-	4,  // 6: trading.PortfolioResponse.total_portfolio_value:type_name -> trading.DecimalValue
-	4,  // 7: trading.PortfolioResponse.cash_balance:type_name -> trading.DecimalValue
-	57, // 8: trading.PortfolioResponse.updated_at:type_name -> google.protobuf.Timestamp
-	57, // 9: trading.PerformanceHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
-	57, // 10: trading.PerformanceHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
-	57, // 11: trading.BotPerformanceSnapshot.snapshot_time:type_name -> google.protobuf.Timestamp
-	4,  // 12: trading.BotPerformanceSnapshot.equity_value:type_name -> trading.DecimalValue
-	4,  // 13: trading.BotPerformanceSnapshot.cash_balance:type_name -> trading.DecimalValue
-	4,  // 14: trading.BotPerformanceSnapshot.pnl:type_name -> trading.DecimalValue
-	11, // 15: trading.PerformanceHistoryResponse.snapshots:type_name -> trading.BotPerformanceSnapshot
-	15, // 16: trading.ListOrdersResponse.orders:type_name -> trading.Order
-	0,  // 17: trading.Order.side:type_name -> trading.OrderSide
-	1,  // 18: trading.Order.type:type_name -> trading.OrderType
-	2,  // 19: trading.Order.status:type_name -> trading.OrderStatus
-	4,  // 20: trading.Order.quantity_requested:type_name -> trading.DecimalValue
-	4,  // 21: trading.Order.quantity_filled:type_name -> trading.DecimalValue
-	4,  // 22: trading.Order.limit_price:type_name -> trading.DecimalValue
-	4,  // 23: trading.Order.stop_price:type_name -> trading.DecimalValue
-	57, // 24: trading.Order.created_at:type_name -> google.protobuf.Timestamp
-	57, // 25: trading.Order.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 26: trading.Order.trades:type_name -> trading.Trade
-	0,  // 27: trading.CreateOrderRequest.side:type_name -> trading.OrderSide
-	1,  // 28: trading.CreateOrderRequest.type:type_name -> trading.OrderType
-	4,  // 29: trading.CreateOrderRequest.quantity:type_name -> trading.DecimalValue
-	4,  // 30: trading.CreateOrderRequest.limit_price:type_name -> trading.DecimalValue
-	4,  // 31: trading.CreateOrderRequest.stop_price:type_name -> trading.DecimalValue
-	20, // 32: trading.OrderBook.bids:type_name -> trading.OrderBookEntry
-	20, // 33: trading.OrderBook.asks:type_name -> trading.OrderBookEntry
-	4,  // 34: trading.Trade.commission:type_name -> trading.DecimalValue
-	57, // 35: trading.Trade.executed_at_timestamp:type_name -> google.protobuf.Timestamp
-	4,  // 36: trading.Trade.pnl_realized:type_name -> trading.DecimalValue
-	4,  // 37: trading.Trade.pnl_unrealized:type_name -> trading.DecimalValue
-	22, // 38: trading.TradeHistoryResponse.trades:type_name -> trading.Trade
-	54, // 39: trading.Bot.parameters:type_name -> trading.Bot.ParametersEntry
-	4,  // 40: trading.Bot.initial_account_value:type_name -> trading.DecimalValue
-	4,  // 41: trading.Bot.current_account_value:type_name -> trading.DecimalValue
-	57, // 42: trading.Bot.created_at:type_name -> google.protobuf.Timestamp
-	57, // 43: trading.Bot.updated_at:type_name -> google.protobuf.Timestamp
-	55, // 44: trading.CreateBotRequest.parameters:type_name -> trading.CreateBotRequest.ParametersEntry
-	33, // 45: trading.BotList.bots:type_name -> trading.Bot
-	9,  // 46: trading.VaRRequest.current_portfolio:type_name -> trading.PortfolioResponse
-	4,  // 47: trading.VaRResponse.value_at_risk:type_name -> trading.DecimalValue
-	57, // 48: trading.VaRResponse.last_update:type_name -> google.protobuf.Timestamp
-	42, // 49: trading.MomentumResponse.metrics:type_name -> trading.MomentumMetric
-	56, // 50: trading.StrategyRequest.parameters:type_name -> trading.StrategyRequest.ParametersEntry
-	49, // 51: trading.GetProductsResponse.products:type_name -> trading.Product
-	7,  // 52: trading.PortfolioService.GetPortfolio:input_type -> trading.PortfolioRequest
-	7,  // 53: trading.PortfolioService.StreamPortfolio:input_type -> trading.PortfolioRequest
-	10, // 54: trading.PortfolioService.GetPerformanceHistory:input_type -> trading.PerformanceHistoryRequest
-	16, // 55: trading.OrderService.CreateOrder:input_type -> trading.CreateOrderRequest
-	17, // 56: trading.OrderService.CancelOrder:input_type -> trading.CancelOrderRequest
-	18, // 57: trading.OrderService.GetOrder:input_type -> trading.GetOrderRequest
-	25, // 58: trading.OrderService.GetTradeHistory:input_type -> trading.TradeHistoryRequest
-	13, // 59: trading.OrderService.ListOrders:input_type -> trading.ListOrdersRequest
-	30, // 60: trading.AuthService.Register:input_type -> trading.RegisterRequest
-	27, // 61: trading.AuthService.Login:input_type -> trading.AuthRequest
-	29, // 62: trading.AuthService.GetUser:input_type -> trading.GetUserRequest
-	32, // 63: trading.AuthService.RefreshToken:input_type -> trading.RefreshTokenRequest
-	35, // 64: trading.BotService.CreateBot:input_type -> trading.CreateBotRequest
-	36, // 65: trading.BotService.GetBot:input_type -> trading.BotIdRequest
-	34, // 66: trading.BotService.UpdateBot:input_type -> trading.UpdateBotRequest
-	36, // 67: trading.BotService.DeleteBot:input_type -> trading.BotIdRequest
-	3,  // 68: trading.BotService.ListBots:input_type -> trading.Empty
-	36, // 69: trading.BotService.StartBot:input_type -> trading.BotIdRequest
-	36, // 70: trading.BotService.StopBot:input_type -> trading.BotIdRequest
-	36, // 71: trading.BotService.GetBotStatus:input_type -> trading.BotIdRequest
-	36, // 72: trading.BotService.StreamBotStatus:input_type -> trading.BotIdRequest
-	39, // 73: trading.RiskService.CalculateVaR:input_type -> trading.VaRRequest
-	21, // 74: trading.TradingService.StreamOrderBook:input_type -> trading.OrderBookRequest
-	44, // 75: trading.TradingService.GetPrice:input_type -> trading.Tick
-	48, // 76: trading.TradingService.StartStrategy:input_type -> trading.StrategyRequest
-	48, // 77: trading.TradingService.StopStrategy:input_type -> trading.StrategyRequest
-	48, // 78: trading.TradingService.SubscribeTicks:input_type -> trading.StrategyRequest
-	45, // 79: trading.TradingService.StreamPrice:input_type -> trading.TickStreamRequest
-	46, // 80: trading.TradingService.AddSymbol:input_type -> trading.SymbolRequest
-	46, // 81: trading.TradingService.RemoveSymbol:input_type -> trading.SymbolRequest
-	3,  // 82: trading.TradingService.ListSymbols:input_type -> trading.Empty
-	41, // 83: trading.TradingService.GetMomentum:input_type -> trading.MomentumRequest
-	3,  // 84: trading.SubscriptionService.GetProducts:input_type -> trading.Empty
-	52, // 85: trading.SubscriptionService.CreateCheckoutSession:input_type -> trading.CreateCheckoutSessionRequest
-	3,  // 86: trading.SubscriptionService.GetUserSubscription:input_type -> trading.Empty
-	3,  // 87: trading.SubscriptionService.CancelUserSubscription:input_type -> trading.Empty
-	9,  // 88: trading.PortfolioService.GetPortfolio:output_type -> trading.PortfolioResponse
-	9,  // 89: trading.PortfolioService.StreamPortfolio:output_type -> trading.PortfolioResponse
-	12, // 90: trading.PortfolioService.GetPerformanceHistory:output_type -> trading.PerformanceHistoryResponse
-	15, // 91: trading.OrderService.CreateOrder:output_type -> trading.Order
-	15, // 92: trading.OrderService.CancelOrder:output_type -> trading.Order
-	15, // 93: trading.OrderService.GetOrder:output_type -> trading.Order
-	26, // 94: trading.OrderService.GetTradeHistory:output_type -> trading.TradeHistoryResponse
-	14, // 95: trading.OrderService.ListOrders:output_type -> trading.ListOrdersResponse
-	28, // 96: trading.AuthService.Register:output_type -> trading.AuthResponse
-	28, // 97: trading.AuthService.Login:output_type -> trading.AuthResponse
-	31, // 98: trading.AuthService.GetUser:output_type -> trading.UserInfo
-	28, // 99: trading.AuthService.RefreshToken:output_type -> trading.AuthResponse
-	5,  // 100: trading.BotService.CreateBot:output_type -> trading.StatusResponse
-	33, // 101: trading.BotService.GetBot:output_type -> trading.Bot
-	33, // 102: trading.BotService.UpdateBot:output_type -> trading.Bot
-	5,  // 103: trading.BotService.DeleteBot:output_type -> trading.StatusResponse
-	38, // 104: trading.BotService.ListBots:output_type -> trading.BotList
-	5,  // 105: trading.BotService.StartBot:output_type -> trading.StatusResponse
-	5,  // 106: trading.BotService.StopBot:output_type -> trading.StatusResponse
-	33, // 107: trading.BotService.GetBotStatus:output_type -> trading.Bot
-	33, // 108: trading.BotService.StreamBotStatus:output_type -> trading.Bot
-	40, // 109: trading.RiskService.CalculateVaR:output_type -> trading.VaRResponse
-	19, // 110: trading.TradingService.StreamOrderBook:output_type -> trading.OrderBook
-	44, // 111: trading.TradingService.GetPrice:output_type -> trading.Tick
-	5,  // 112: trading.TradingService.StartStrategy:output_type -> trading.StatusResponse
-	5,  // 113: trading.TradingService.StopStrategy:output_type -> trading.StatusResponse
-	44, // 114: trading.TradingService.SubscribeTicks:output_type -> trading.Tick
-	44, // 115: trading.TradingService.StreamPrice:output_type -> trading.Tick
-	5,  // 116: trading.TradingService.AddSymbol:output_type -> trading.StatusResponse
-	5,  // 117: trading.TradingService.RemoveSymbol:output_type -> trading.StatusResponse
-	47, // 118: trading.TradingService.ListSymbols:output_type -> trading.SymbolList
-	43, // 119: trading.TradingService.GetMomentum:output_type -> trading.MomentumResponse
-	51, // 120: trading.SubscriptionService.GetProducts:output_type -> trading.GetProductsResponse
-	53, // 121: trading.SubscriptionService.CreateCheckoutSession:output_type -> trading.CreateCheckoutSessionResponse
-	50, // 122: trading.SubscriptionService.GetUserSubscription:output_type -> trading.Subscription
-	5,  // 123: trading.SubscriptionService.CancelUserSubscription:output_type -> trading.StatusResponse
-	88, // [88:124] is the sub-list for method output_type
-	52, // [52:88] is the sub-list for method input_type
-	52, // [52:52] is the sub-list for extension type_name
-	52, // [52:52] is the sub-list for extension extendee
-	0,  // [0:52] is the sub-list for field type_name
+	10,  // 0: trading.PortfolioPosition.quantity:type_name -> trading.DecimalValue
+	10,  // 1: trading.PortfolioPosition.average_price:type_name -> trading.DecimalValue
+	10,  // 2: trading.PortfolioPosition.market_value:type_name -> trading.DecimalValue
+	10,  // 3: trading.PortfolioPosition.unrealized_pnl:type_name -> trading.DecimalValue
+	10,  // 4: trading.PortfolioPosition.exposure_pct:type_name -> trading.DecimalValue
+	14,  // 5: trading.PortfolioResponse.positions:type_name -> trading.PortfolioPosition
+	10,  // 6: trading.PortfolioResponse.total_portfolio_value:type_name -> trading.DecimalValue
+	10,  // 7: trading.PortfolioResponse.cash_balance:type_name -> trading.DecimalValue
+	106, // 8: trading.PortfolioResponse.updated_at:type_name -> google.protobuf.Timestamp
+	106, // 9: trading.PerformanceHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	106, // 10: trading.PerformanceHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	106, // 11: trading.BotPerformanceSnapshot.snapshot_time:type_name -> google.protobuf.Timestamp
+	10,  // 12: trading.BotPerformanceSnapshot.equity_value:type_name -> trading.DecimalValue
+	10,  // 13: trading.BotPerformanceSnapshot.cash_balance:type_name -> trading.DecimalValue
+	10,  // 14: trading.BotPerformanceSnapshot.pnl:type_name -> trading.DecimalValue
+	17,  // 15: trading.PerformanceHistoryResponse.snapshots:type_name -> trading.BotPerformanceSnapshot
+	21,  // 16: trading.ListOrdersResponse.orders:type_name -> trading.Order
+	0,   // 17: trading.Order.side:type_name -> trading.OrderSide
+	1,   // 18: trading.Order.type:type_name -> trading.OrderType
+	2,   // 19: trading.Order.status:type_name -> trading.OrderStatus
+	10,  // 20: trading.Order.quantity_requested:type_name -> trading.DecimalValue
+	10,  // 21: trading.Order.quantity_filled:type_name -> trading.DecimalValue
+	10,  // 22: trading.Order.limit_price:type_name -> trading.DecimalValue
+	10,  // 23: trading.Order.stop_price:type_name -> trading.DecimalValue
+	106, // 24: trading.Order.created_at:type_name -> google.protobuf.Timestamp
+	106, // 25: trading.Order.updated_at:type_name -> google.protobuf.Timestamp
+	36,  // 26: trading.Order.trades:type_name -> trading.Trade
+	3,   // 27: trading.Order.time_in_force:type_name -> trading.TimeInForce
+	106, // 28: trading.Order.expire_at:type_name -> google.protobuf.Timestamp
+	0,   // 29: trading.CreateOrderRequest.side:type_name -> trading.OrderSide
+	1,   // 30: trading.CreateOrderRequest.type:type_name -> trading.OrderType
+	10,  // 31: trading.CreateOrderRequest.quantity:type_name -> trading.DecimalValue
+	10,  // 32: trading.CreateOrderRequest.limit_price:type_name -> trading.DecimalValue
+	10,  // 33: trading.CreateOrderRequest.stop_price:type_name -> trading.DecimalValue
+	3,   // 34: trading.CreateOrderRequest.time_in_force:type_name -> trading.TimeInForce
+	106, // 35: trading.CreateOrderRequest.expire_at:type_name -> google.protobuf.Timestamp
+	6,   // 36: trading.OrderUpdate.type:type_name -> trading.OrderUpdateType
+	21,  // 37: trading.OrderUpdate.order:type_name -> trading.Order
+	36,  // 38: trading.OrderUpdate.fill:type_name -> trading.Trade
+	106, // 39: trading.OrderUpdate.event_time:type_name -> google.protobuf.Timestamp
+	0,   // 40: trading.CreateAlgoOrderRequest.side:type_name -> trading.OrderSide
+	4,   // 41: trading.CreateAlgoOrderRequest.algo:type_name -> trading.AlgoType
+	10,  // 42: trading.CreateAlgoOrderRequest.quantity:type_name -> trading.DecimalValue
+	10,  // 43: trading.CreateAlgoOrderRequest.limit_price:type_name -> trading.DecimalValue
+	10,  // 44: trading.CreateAlgoOrderRequest.display_quantity:type_name -> trading.DecimalValue
+	0,   // 45: trading.AlgoOrder.side:type_name -> trading.OrderSide
+	4,   // 46: trading.AlgoOrder.algo:type_name -> trading.AlgoType
+	5,   // 47: trading.AlgoOrder.status:type_name -> trading.AlgoStatus
+	10,  // 48: trading.AlgoOrder.quantity:type_name -> trading.DecimalValue
+	10,  // 49: trading.AlgoOrder.quantity_sent:type_name -> trading.DecimalValue
+	10,  // 50: trading.AlgoOrder.quantity_filled:type_name -> trading.DecimalValue
+	106, // 51: trading.AlgoOrder.created_at:type_name -> google.protobuf.Timestamp
+	106, // 52: trading.AlgoOrder.updated_at:type_name -> google.protobuf.Timestamp
+	7,   // 53: trading.Discrepancy.type:type_name -> trading.DiscrepancyType
+	106, // 54: trading.ReconciliationReport.run_at:type_name -> google.protobuf.Timestamp
+	30,  // 55: trading.ReconciliationReport.discrepancies:type_name -> trading.Discrepancy
+	34,  // 56: trading.OrderBook.bids:type_name -> trading.OrderBookEntry
+	34,  // 57: trading.OrderBook.asks:type_name -> trading.OrderBookEntry
+	10,  // 58: trading.Trade.commission:type_name -> trading.DecimalValue
+	106, // 59: trading.Trade.executed_at_timestamp:type_name -> google.protobuf.Timestamp
+	10,  // 60: trading.Trade.pnl_realized:type_name -> trading.DecimalValue
+	10,  // 61: trading.Trade.pnl_unrealized:type_name -> trading.DecimalValue
+	106, // 62: trading.TradeHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	106, // 63: trading.TradeHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	36,  // 64: trading.TradeHistoryResponse.trades:type_name -> trading.Trade
+	41,  // 65: trading.TradeHistoryResponse.summaries:type_name -> trading.TradeSymbolSummary
+	10,  // 66: trading.TradeSymbolSummary.volume:type_name -> trading.DecimalValue
+	10,  // 67: trading.TradeSymbolSummary.notional:type_name -> trading.DecimalValue
+	10,  // 68: trading.TradeSymbolSummary.fees:type_name -> trading.DecimalValue
+	10,  // 69: trading.TradeSymbolSummary.realized_pnl:type_name -> trading.DecimalValue
+	106, // 70: trading.Session.created_at:type_name -> google.protobuf.Timestamp
+	106, // 71: trading.Session.last_refreshed_at:type_name -> google.protobuf.Timestamp
+	106, // 72: trading.Session.expires_at:type_name -> google.protobuf.Timestamp
+	52,  // 73: trading.ListSessionsResponse.sessions:type_name -> trading.Session
+	106, // 74: trading.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	106, // 75: trading.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	106, // 76: trading.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	55,  // 77: trading.CreateAPIKeyResponse.key:type_name -> trading.APIKey
+	55,  // 78: trading.ListAPIKeysResponse.keys:type_name -> trading.APIKey
+	43,  // 79: trading.VerifyTOTPResponse.auth:type_name -> trading.AuthResponse
+	102, // 80: trading.Bot.parameters:type_name -> trading.Bot.ParametersEntry
+	10,  // 81: trading.Bot.initial_account_value:type_name -> trading.DecimalValue
+	10,  // 82: trading.Bot.current_account_value:type_name -> trading.DecimalValue
+	106, // 83: trading.Bot.created_at:type_name -> google.protobuf.Timestamp
+	106, // 84: trading.Bot.updated_at:type_name -> google.protobuf.Timestamp
+	103, // 85: trading.UpdateBotRequest.parameters:type_name -> trading.UpdateBotRequest.ParametersEntry
+	104, // 86: trading.CreateBotRequest.parameters:type_name -> trading.CreateBotRequest.ParametersEntry
+	69,  // 87: trading.BotList.bots:type_name -> trading.Bot
+	15,  // 88: trading.VaRRequest.current_portfolio:type_name -> trading.PortfolioResponse
+	10,  // 89: trading.VaRResponse.value_at_risk:type_name -> trading.DecimalValue
+	106, // 90: trading.VaRResponse.last_update:type_name -> google.protobuf.Timestamp
+	78,  // 91: trading.MomentumResponse.metrics:type_name -> trading.MomentumMetric
+	105, // 92: trading.StrategyRequest.parameters:type_name -> trading.StrategyRequest.ParametersEntry
+	85,  // 93: trading.GetProductsResponse.products:type_name -> trading.Product
+	106, // 94: trading.VenueCredential.created_at:type_name -> google.protobuf.Timestamp
+	106, // 95: trading.VenueCredential.rotated_at:type_name -> google.protobuf.Timestamp
+	90,  // 96: trading.ListCredentialsResponse.credentials:type_name -> trading.VenueCredential
+	46,  // 97: trading.ListUsersResponse.users:type_name -> trading.UserInfo
+	106, // 98: trading.ExportRequest.start_time:type_name -> google.protobuf.Timestamp
+	106, // 99: trading.ExportRequest.end_time:type_name -> google.protobuf.Timestamp
+	8,   // 100: trading.ExportRequest.format:type_name -> trading.ExportFormat
+	13,  // 101: trading.PortfolioService.GetPortfolio:input_type -> trading.PortfolioRequest
+	13,  // 102: trading.PortfolioService.StreamPortfolio:input_type -> trading.PortfolioRequest
+	16,  // 103: trading.PortfolioService.GetPerformanceHistory:input_type -> trading.PerformanceHistoryRequest
+	22,  // 104: trading.OrderService.CreateOrder:input_type -> trading.CreateOrderRequest
+	23,  // 105: trading.OrderService.CancelOrder:input_type -> trading.CancelOrderRequest
+	24,  // 106: trading.OrderService.GetOrder:input_type -> trading.GetOrderRequest
+	39,  // 107: trading.OrderService.GetTradeHistory:input_type -> trading.TradeHistoryRequest
+	19,  // 108: trading.OrderService.ListOrders:input_type -> trading.ListOrdersRequest
+	27,  // 109: trading.OrderService.CreateAlgoOrder:input_type -> trading.CreateAlgoOrderRequest
+	28,  // 110: trading.OrderService.GetAlgoOrderStatus:input_type -> trading.AlgoOrderRequest
+	28,  // 111: trading.OrderService.CancelAlgoOrder:input_type -> trading.AlgoOrderRequest
+	25,  // 112: trading.OrderService.StreamOrderUpdates:input_type -> trading.StreamOrderUpdatesRequest
+	9,   // 113: trading.OrderService.GetReconciliationReport:input_type -> trading.Empty
+	32,  // 114: trading.OrderService.RunReconciliation:input_type -> trading.RunReconciliationRequest
+	45,  // 115: trading.AuthService.Register:input_type -> trading.RegisterRequest
+	42,  // 116: trading.AuthService.Login:input_type -> trading.AuthRequest
+	44,  // 117: trading.AuthService.GetUser:input_type -> trading.GetUserRequest
+	50,  // 118: trading.AuthService.RefreshToken:input_type -> trading.RefreshTokenRequest
+	51,  // 119: trading.AuthService.Logout:input_type -> trading.LogoutRequest
+	9,   // 120: trading.AuthService.ListSessions:input_type -> trading.Empty
+	54,  // 121: trading.AuthService.RevokeSession:input_type -> trading.RevokeSessionRequest
+	56,  // 122: trading.AuthService.CreateAPIKey:input_type -> trading.CreateAPIKeyRequest
+	9,   // 123: trading.AuthService.ListAPIKeys:input_type -> trading.Empty
+	59,  // 124: trading.AuthService.RevokeAPIKey:input_type -> trading.RevokeAPIKeyRequest
+	60,  // 125: trading.AuthService.EnrollTOTP:input_type -> trading.EnrollTOTPRequest
+	62,  // 126: trading.AuthService.VerifyTOTP:input_type -> trading.VerifyTOTPRequest
+	64,  // 127: trading.AuthService.CompleteLogin:input_type -> trading.CompleteLoginRequest
+	65,  // 128: trading.AuthService.DisableTOTP:input_type -> trading.DisableTOTPRequest
+	66,  // 129: trading.AuthService.RequestPasswordReset:input_type -> trading.RequestPasswordResetRequest
+	67,  // 130: trading.AuthService.ResetPassword:input_type -> trading.ResetPasswordRequest
+	68,  // 131: trading.AuthService.VerifyEmail:input_type -> trading.VerifyEmailRequest
+	9,   // 132: trading.AuthService.ResendVerificationEmail:input_type -> trading.Empty
+	47,  // 133: trading.AuthService.UpdateProfile:input_type -> trading.UpdateProfileRequest
+	48,  // 134: trading.AuthService.ChangePassword:input_type -> trading.ChangePasswordRequest
+	49,  // 135: trading.AuthService.DeleteAccount:input_type -> trading.DeleteAccountRequest
+	71,  // 136: trading.BotService.CreateBot:input_type -> trading.CreateBotRequest
+	72,  // 137: trading.BotService.GetBot:input_type -> trading.BotIdRequest
+	70,  // 138: trading.BotService.UpdateBot:input_type -> trading.UpdateBotRequest
+	72,  // 139: trading.BotService.DeleteBot:input_type -> trading.BotIdRequest
+	9,   // 140: trading.BotService.ListBots:input_type -> trading.Empty
+	72,  // 141: trading.BotService.StartBot:input_type -> trading.BotIdRequest
+	72,  // 142: trading.BotService.StopBot:input_type -> trading.BotIdRequest
+	72,  // 143: trading.BotService.GetBotStatus:input_type -> trading.BotIdRequest
+	72,  // 144: trading.BotService.StreamBotStatus:input_type -> trading.BotIdRequest
+	75,  // 145: trading.RiskService.CalculateVaR:input_type -> trading.VaRRequest
+	35,  // 146: trading.TradingService.StreamOrderBook:input_type -> trading.OrderBookRequest
+	80,  // 147: trading.TradingService.GetPrice:input_type -> trading.Tick
+	84,  // 148: trading.TradingService.StartStrategy:input_type -> trading.StrategyRequest
+	84,  // 149: trading.TradingService.StopStrategy:input_type -> trading.StrategyRequest
+	84,  // 150: trading.TradingService.SubscribeTicks:input_type -> trading.StrategyRequest
+	81,  // 151: trading.TradingService.StreamPrice:input_type -> trading.TickStreamRequest
+	82,  // 152: trading.TradingService.AddSymbol:input_type -> trading.SymbolRequest
+	82,  // 153: trading.TradingService.RemoveSymbol:input_type -> trading.SymbolRequest
+	9,   // 154: trading.TradingService.ListSymbols:input_type -> trading.Empty
+	77,  // 155: trading.TradingService.GetMomentum:input_type -> trading.MomentumRequest
+	9,   // 156: trading.SubscriptionService.GetProducts:input_type -> trading.Empty
+	88,  // 157: trading.SubscriptionService.CreateCheckoutSession:input_type -> trading.CreateCheckoutSessionRequest
+	9,   // 158: trading.SubscriptionService.GetUserSubscription:input_type -> trading.Empty
+	9,   // 159: trading.SubscriptionService.CancelUserSubscription:input_type -> trading.Empty
+	91,  // 160: trading.CredentialService.AddCredential:input_type -> trading.AddCredentialRequest
+	92,  // 161: trading.CredentialService.ListCredentials:input_type -> trading.ListCredentialsRequest
+	94,  // 162: trading.CredentialService.RotateCredential:input_type -> trading.RotateCredentialRequest
+	95,  // 163: trading.CredentialService.DeleteCredential:input_type -> trading.DeleteCredentialRequest
+	96,  // 164: trading.AdminService.ListUsers:input_type -> trading.ListUsersRequest
+	98,  // 165: trading.AdminService.ForceStopBots:input_type -> trading.ForceStopBotsRequest
+	100, // 166: trading.ExportService.ExportTrades:input_type -> trading.ExportRequest
+	100, // 167: trading.ExportService.ExportOrders:input_type -> trading.ExportRequest
+	100, // 168: trading.ExportService.ExportPerformance:input_type -> trading.ExportRequest
+	15,  // 169: trading.PortfolioService.GetPortfolio:output_type -> trading.PortfolioResponse
+	15,  // 170: trading.PortfolioService.StreamPortfolio:output_type -> trading.PortfolioResponse
+	18,  // 171: trading.PortfolioService.GetPerformanceHistory:output_type -> trading.PerformanceHistoryResponse
+	21,  // 172: trading.OrderService.CreateOrder:output_type -> trading.Order
+	21,  // 173: trading.OrderService.CancelOrder:output_type -> trading.Order
+	21,  // 174: trading.OrderService.GetOrder:output_type -> trading.Order
+	40,  // 175: trading.OrderService.GetTradeHistory:output_type -> trading.TradeHistoryResponse
+	20,  // 176: trading.OrderService.ListOrders:output_type -> trading.ListOrdersResponse
+	29,  // 177: trading.OrderService.CreateAlgoOrder:output_type -> trading.AlgoOrder
+	29,  // 178: trading.OrderService.GetAlgoOrderStatus:output_type -> trading.AlgoOrder
+	29,  // 179: trading.OrderService.CancelAlgoOrder:output_type -> trading.AlgoOrder
+	26,  // 180: trading.OrderService.StreamOrderUpdates:output_type -> trading.OrderUpdate
+	31,  // 181: trading.OrderService.GetReconciliationReport:output_type -> trading.ReconciliationReport
+	31,  // 182: trading.OrderService.RunReconciliation:output_type -> trading.ReconciliationReport
+	43,  // 183: trading.AuthService.Register:output_type -> trading.AuthResponse
+	43,  // 184: trading.AuthService.Login:output_type -> trading.AuthResponse
+	46,  // 185: trading.AuthService.GetUser:output_type -> trading.UserInfo
+	43,  // 186: trading.AuthService.RefreshToken:output_type -> trading.AuthResponse
+	11,  // 187: trading.AuthService.Logout:output_type -> trading.StatusResponse
+	53,  // 188: trading.AuthService.ListSessions:output_type -> trading.ListSessionsResponse
+	11,  // 189: trading.AuthService.RevokeSession:output_type -> trading.StatusResponse
+	57,  // 190: trading.AuthService.CreateAPIKey:output_type -> trading.CreateAPIKeyResponse
+	58,  // 191: trading.AuthService.ListAPIKeys:output_type -> trading.ListAPIKeysResponse
+	11,  // 192: trading.AuthService.RevokeAPIKey:output_type -> trading.StatusResponse
+	61,  // 193: trading.AuthService.EnrollTOTP:output_type -> trading.EnrollTOTPResponse
+	63,  // 194: trading.AuthService.VerifyTOTP:output_type -> trading.VerifyTOTPResponse
+	43,  // 195: trading.AuthService.CompleteLogin:output_type -> trading.AuthResponse
+	11,  // 196: trading.AuthService.DisableTOTP:output_type -> trading.StatusResponse
+	11,  // 197: trading.AuthService.RequestPasswordReset:output_type -> trading.StatusResponse
+	11,  // 198: trading.AuthService.ResetPassword:output_type -> trading.StatusResponse
+	11,  // 199: trading.AuthService.VerifyEmail:output_type -> trading.StatusResponse
+	11,  // 200: trading.AuthService.ResendVerificationEmail:output_type -> trading.StatusResponse
+	46,  // 201: trading.AuthService.UpdateProfile:output_type -> trading.UserInfo
+	11,  // 202: trading.AuthService.ChangePassword:output_type -> trading.StatusResponse
+	11,  // 203: trading.AuthService.DeleteAccount:output_type -> trading.StatusResponse
+	11,  // 204: trading.BotService.CreateBot:output_type -> trading.StatusResponse
+	69,  // 205: trading.BotService.GetBot:output_type -> trading.Bot
+	69,  // 206: trading.BotService.UpdateBot:output_type -> trading.Bot
+	11,  // 207: trading.BotService.DeleteBot:output_type -> trading.StatusResponse
+	74,  // 208: trading.BotService.ListBots:output_type -> trading.BotList
+	11,  // 209: trading.BotService.StartBot:output_type -> trading.StatusResponse
+	11,  // 210: trading.BotService.StopBot:output_type -> trading.StatusResponse
+	69,  // 211: trading.BotService.GetBotStatus:output_type -> trading.Bot
+	69,  // 212: trading.BotService.StreamBotStatus:output_type -> trading.Bot
+	76,  // 213: trading.RiskService.CalculateVaR:output_type -> trading.VaRResponse
+	33,  // 214: trading.TradingService.StreamOrderBook:output_type -> trading.OrderBook
+	80,  // 215: trading.TradingService.GetPrice:output_type -> trading.Tick
+	11,  // 216: trading.TradingService.StartStrategy:output_type -> trading.StatusResponse
+	11,  // 217: trading.TradingService.StopStrategy:output_type -> trading.StatusResponse
+	80,  // 218: trading.TradingService.SubscribeTicks:output_type -> trading.Tick
+	80,  // 219: trading.TradingService.StreamPrice:output_type -> trading.Tick
+	11,  // 220: trading.TradingService.AddSymbol:output_type -> trading.StatusResponse
+	11,  // 221: trading.TradingService.RemoveSymbol:output_type -> trading.StatusResponse
+	83,  // 222: trading.TradingService.ListSymbols:output_type -> trading.SymbolList
+	79,  // 223: trading.TradingService.GetMomentum:output_type -> trading.MomentumResponse
+	87,  // 224: trading.SubscriptionService.GetProducts:output_type -> trading.GetProductsResponse
+	89,  // 225: trading.SubscriptionService.CreateCheckoutSession:output_type -> trading.CreateCheckoutSessionResponse
+	86,  // 226: trading.SubscriptionService.GetUserSubscription:output_type -> trading.Subscription
+	11,  // 227: trading.SubscriptionService.CancelUserSubscription:output_type -> trading.StatusResponse
+	90,  // 228: trading.CredentialService.AddCredential:output_type -> trading.VenueCredential
+	93,  // 229: trading.CredentialService.ListCredentials:output_type -> trading.ListCredentialsResponse
+	90,  // 230: trading.CredentialService.RotateCredential:output_type -> trading.VenueCredential
+	11,  // 231: trading.CredentialService.DeleteCredential:output_type -> trading.StatusResponse
+	97,  // 232: trading.AdminService.ListUsers:output_type -> trading.ListUsersResponse
+	99,  // 233: trading.AdminService.ForceStopBots:output_type -> trading.ForceStopBotsResponse
+	101, // 234: trading.ExportService.ExportTrades:output_type -> trading.ExportChunk
+	101, // 235: trading.ExportService.ExportOrders:output_type -> trading.ExportChunk
+	101, // 236: trading.ExportService.ExportPerformance:output_type -> trading.ExportChunk
+	169, // [169:237] is the sub-list for method output_type
+	101, // [101:169] is the sub-list for method input_type
+	101, // [101:101] is the sub-list for extension type_name
+	101, // [101:101] is the sub-list for extension extendee
+	0,   // [0:101] is the sub-list for field type_name
 }
 
 func init() { file_trading_api_proto_init() }
@@ -4015,17 +7665,18 @@ func file_trading_api_proto_init() {
 	}
 	file_trading_api_proto_msgTypes[12].OneofWrappers = []any{}
 	file_trading_api_proto_msgTypes[13].OneofWrappers = []any{}
-	file_trading_api_proto_msgTypes[19].OneofWrappers = []any{}
-	file_trading_api_proto_msgTypes[31].OneofWrappers = []any{}
+	file_trading_api_proto_msgTypes[18].OneofWrappers = []any{}
+	file_trading_api_proto_msgTypes[27].OneofWrappers = []any{}
+	file_trading_api_proto_msgTypes[61].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trading_api_proto_rawDesc), len(file_trading_api_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   54,
+			NumEnums:      9,
+			NumMessages:   97,
 			NumExtensions: 0,
-			NumServices:   7,
+			NumServices:   10,
 		},
 		GoTypes:           file_trading_api_proto_goTypes,
 		DependencyIndexes: file_trading_api_proto_depIdxs,
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKey is one key tokens may be signed or verified with. sign is nil for keys that
+// are only accepted, such as the previous secret during a rotation.
+type jwtKey struct {
+	kid    string
+	method jwt.SigningMethod
+	sign   interface{} // []byte or ed25519.PrivateKey
+	verify interface{} // []byte or ed25519.PublicKey
+}
+
+// signingKeys signs new tokens with the current key and accepts tokens signed by any
+// configured key. Tokens carry the signing key's id in their kid header; tokens without
+// one are tried against every key of their algorithm.
+type signingKeys struct {
+	current jwtKey
+	keys    []jwtKey // current first
+}
+
+func hmacKey(secret string) jwtKey {
+	sum := sha256.Sum256([]byte(secret))
+	return jwtKey{
+		kid:    "hs256-" + hex.EncodeToString(sum[:8]),
+		method: jwt.SigningMethodHS256,
+		sign:   []byte(secret),
+		verify: []byte(secret),
+	}
+}
+
+func ed25519Key(pub ed25519.PublicKey, priv ed25519.PrivateKey) jwtKey {
+	sum := sha256.Sum256(pub)
+	k := jwtKey{kid: "ed25519-" + hex.EncodeToString(sum[:8]), method: jwt.SigningMethodEdDSA, verify: pub}
+	if priv != nil {
+		k.sign = priv
+	}
+	return k
+}
+
+// newSigningKeys builds the key set. Tokens are signed with the Ed25519 key when one
+// is configured, else with the HMAC secret. The HMAC secrets, the previous secret and
+// the previous Ed25519 public key stay valid for verification so that rotating or
+// switching keys does not log anyone out.
+func newSigningKeys(secret, previousSecret, ed25519PrivatePEM, ed25519PreviousPublicPEM string) (*signingKeys, error) {
+	if secret == "" {
+		return nil, errors.New("auth secret is required")
+	}
+	ks := &signingKeys{}
+	if ed25519PrivatePEM != "" {
+		priv, err := parseEd25519PrivateKey(ed25519PrivatePEM)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys = append(ks.keys, ed25519Key(priv.Public().(ed25519.PublicKey), priv))
+	}
+	ks.keys = append(ks.keys, hmacKey(secret))
+	if previousSecret != "" && previousSecret != secret {
+		prev := hmacKey(previousSecret)
+		prev.sign = nil
+		ks.keys = append(ks.keys, prev)
+	}
+	if ed25519PreviousPublicPEM != "" {
+		pub, err := parseEd25519PublicKey(ed25519PreviousPublicPEM)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys = append(ks.keys, ed25519Key(pub, nil))
+	}
+	ks.current = ks.keys[0]
+	return ks, nil
+}
+
+func parseEd25519PrivateKey(pemData string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("ed25519 private key: no PEM block")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("ed25519 private key: %w", err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("ed25519 private key: not an Ed25519 key")
+	}
+	return priv, nil
+}
+
+func parseEd25519PublicKey(pemData string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("ed25519 public key: no PEM block")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("ed25519 public key: %w", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("ed25519 public key: not an Ed25519 key")
+	}
+	return pub, nil
+}
+
+// sign returns claims signed with the current key.
+func (k *signingKeys) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(k.current.method, claims)
+	token.Header["kid"] = k.current.kid
+	return token.SignedString(k.current.sign)
+}
+
+// parse verifies a token against the configured keys.
+func (k *signingKeys) parse(tokenStr string) (*jwt.Token, error) {
+	return jwt.Parse(tokenStr, k.keyFunc, jwt.WithValidMethods(k.algorithms()))
+}
+
+func (k *signingKeys) algorithms() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, key := range k.keys {
+		if alg := key.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			out = append(out, alg)
+		}
+	}
+	return out
+}
+
+func (k *signingKeys) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	var keys []jwt.VerificationKey
+	for _, key := range k.keys {
+		if key.method.Alg() != t.Method.Alg() || (kid != "" && key.kid != kid) {
+			continue
+		}
+		keys = append(keys, key.verify)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+// jwk is an Ed25519 public key in JSON Web Key form (RFC 8037).
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+// jwks returns the public keys other services can verify tokens with. HMAC secrets
+// are never published.
+func (k *signingKeys) jwks() []jwk {
+	out := []jwk{}
+	for _, key := range k.keys {
+		pub, ok := key.verify.(ed25519.PublicKey)
+		if !ok {
+			continue
+		}
+		out = append(out, jwk{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub), Kid: key.kid, Alg: "EdDSA", Use: "sig"})
+	}
+	return out
+}
+
+// handleJWKS serves the JWKS document.
+func (k *signingKeys) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	_ = json.NewEncoder(w).Encode(map[string][]jwk{"keys": k.jwks()})
+}
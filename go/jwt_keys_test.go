@@ -0,0 +1,72 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestSigningKeysRotation(t *testing.T) {
+	const oldSecret = "old-secret-old-secret-old-secret-0"
+	const newSecret = "new-secret-new-secret-new-secret-1"
+	claims := func() jwt.MapClaims { return jwt.MapClaims{"sub": "u1", "exp": time.Now().Add(time.Minute).Unix()} }
+
+	before, _ := newSigningKeys(oldSecret, "", "", "")
+	oldToken, _ := before.sign(claims())
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims()).SignedString([]byte(oldSecret)) // no kid
+
+	during, err := newSigningKeys(newSecret, oldSecret, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tok := range map[string]string{"old kid": oldToken, "no kid": legacy} {
+		if _, err := during.parse(tok); err != nil {
+			t.Errorf("%s: expected the previous secret to be accepted during rotation, got %v", name, err)
+		}
+	}
+	newToken, _ := during.sign(claims())
+	parsed, err := during.parse(newToken)
+	if err != nil || parsed.Header["kid"] != during.current.kid {
+		t.Fatalf("expected a token signed with the current kid, got %v", err)
+	}
+
+	after, _ := newSigningKeys(newSecret, "", "", "")
+	if _, err := after.parse(oldToken); err == nil {
+		t.Error("expected the old secret to be rejected once rotation ends")
+	}
+}
+
+func TestSigningKeysEd25519(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	privPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	keys, err := newSigningKeys("shared-secret-shared-secret-shared", "", privPEM, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := keys.sign(jwt.MapClaims{"sub": "u1", "exp": time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := keys.parse(tok)
+	if err != nil || parsed.Method.Alg() != "EdDSA" {
+		t.Fatalf("expected a valid EdDSA token, got %v", err)
+	}
+	if set := keys.jwks(); len(set) != 1 || set[0].Kid != keys.current.kid || set[0].Crv != "Ed25519" {
+		t.Errorf("expected the Ed25519 public key in the JWKS, got %+v", set)
+	}
+
+	// A token signed with the shared secret must not verify as the published key.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "u1"})
+	forged.Header["kid"] = keys.current.kid
+	forgedStr, _ := forged.SignedString([]byte("shared-secret-shared-secret-shared"))
+	if _, err := keys.parse(forgedStr); err == nil {
+		t.Error("expected an HS256 token claiming the Ed25519 kid to be rejected")
+	}
+}
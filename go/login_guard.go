@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLoginMaxFailures = 10
+	defaultLoginLockout     = 15 * time.Minute
+	loginCounterWindow      = 24 * time.Hour      // failures older than this are forgotten
+	loginAttemptRetention   = 90 * 24 * time.Hour // how long the audit trail is kept
+	memLoginAuditSize       = 1000
+)
+
+// loginCounter is the run of failed logins for a username or IP.
+type loginCounter struct {
+	Failures    int
+	LastFailure time.Time
+}
+
+// loginAttempt is one audit record.
+type loginAttempt struct {
+	Username string
+	IP       string
+	Success  bool
+	Reason   string
+	At       time.Time
+}
+
+// loginAttemptStore keeps failed-login counters and the login audit trail.
+// memLoginAttemptStore and DBService implement it.
+type loginAttemptStore interface {
+	LoginCounter(ctx context.Context, key string) (loginCounter, error)
+	RecordLoginFailure(ctx context.Context, key string, at time.Time, window time.Duration) (loginCounter, error)
+	ResetLoginCounter(ctx context.Context, key string) error
+	RecordLoginAttempt(ctx context.Context, a loginAttempt) error
+	PurgeLoginState(ctx context.Context, countersBefore, attemptsBefore time.Time) error
+}
+
+// memLoginAttemptStore implements loginAttemptStore in-memory. It keeps only the most
+// recent audit records.
+type memLoginAttemptStore struct {
+	mu       sync.Mutex
+	counters map[string]loginCounter
+	audit    []loginAttempt
+}
+
+func newMemLoginAttemptStore() *memLoginAttemptStore {
+	return &memLoginAttemptStore{counters: make(map[string]loginCounter)}
+}
+
+func (m *memLoginAttemptStore) LoginCounter(_ context.Context, key string) (loginCounter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[key], nil
+}
+
+func (m *memLoginAttemptStore) RecordLoginFailure(_ context.Context, key string, at time.Time, window time.Duration) (loginCounter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.counters[key]
+	if c.LastFailure.Before(at.Add(-window)) {
+		c.Failures = 0
+	}
+	c.Failures++
+	c.LastFailure = at
+	m.counters[key] = c
+	return c, nil
+}
+
+func (m *memLoginAttemptStore) ResetLoginCounter(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.counters, key)
+	return nil
+}
+
+func (m *memLoginAttemptStore) RecordLoginAttempt(_ context.Context, a loginAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.audit) >= memLoginAuditSize {
+		m.audit = append(m.audit[:0], m.audit[1:]...)
+	}
+	m.audit = append(m.audit, a)
+	return nil
+}
+
+func (m *memLoginAttemptStore) PurgeLoginState(_ context.Context, countersBefore, _ time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, c := range m.counters {
+		if c.LastFailure.Before(countersBefore) {
+			delete(m.counters, k)
+		}
+	}
+	return nil
+}
+
+// backoffPolicy turns a failure count into a wait. The first free failures cost
+// nothing; after that the wait doubles from base up to max, and lockAfter failures
+// lock the key for lockFor.
+type backoffPolicy struct {
+	free      int
+	base      time.Duration
+	max       time.Duration
+	lockAfter int
+	lockFor   time.Duration
+}
+
+// blockedFor returns how long the key must wait before its next attempt.
+func (p backoffPolicy) blockedFor(c loginCounter, now time.Time) time.Duration {
+	if c.Failures == 0 || now.Sub(c.LastFailure) > loginCounterWindow {
+		return 0
+	}
+	var wait time.Duration
+	switch {
+	case p.lockAfter > 0 && c.Failures >= p.lockAfter:
+		wait = p.lockFor
+	case c.Failures > p.free:
+		wait = p.max
+		if shift := c.Failures - p.free - 1; shift < 32 {
+			wait = min(p.base<<shift, p.max)
+		}
+	}
+	return max(c.LastFailure.Add(wait).Sub(now), 0)
+}
+
+// loginGuard throttles password and second-factor guessing per username and per
+// client IP, and writes the login audit trail. Store errors are logged and never
+// block a login.
+type loginGuard struct {
+	store loginAttemptStore
+	user  backoffPolicy
+	ip    backoffPolicy
+	// trustedProxies are the peers allowed to name the client in x-forwarded-for; set
+	// from TRUSTED_PROXY_CIDRS.
+	trustedProxies []netip.Prefix
+}
+
+func newLoginGuard(store loginAttemptStore, maxFailures int, lockFor time.Duration) *loginGuard {
+	return &loginGuard{
+		store: store,
+		user:  backoffPolicy{free: 3, base: time.Second, max: 5 * time.Minute, lockAfter: maxFailures, lockFor: lockFor},
+		// Many users can share an address, so IPs get more room before backing off.
+		ip: backoffPolicy{free: 20, base: time.Second, max: 5 * time.Minute, lockAfter: 10 * maxFailures, lockFor: lockFor},
+	}
+}
+
+func loginUserKey(username string) string { return "user:" + strings.ToLower(username) }
+func loginIPKey(ip string) string         { return "ip:" + ip }
+
+// blocked returns how long username or ip must still wait.
+func (g *loginGuard) blocked(ctx context.Context, username, ip string) time.Duration {
+	now := time.Now()
+	var wait time.Duration
+	for _, k := range []struct {
+		key    string
+		policy backoffPolicy
+	}{{loginUserKey(username), g.user}, {loginIPKey(ip), g.ip}} {
+		c, err := g.store.LoginCounter(ctx, k.key)
+		if err != nil {
+			log.Warn().Err(err).Msg("login guard: counter lookup failed")
+			continue
+		}
+		wait = max(wait, k.policy.blockedFor(c, now))
+	}
+	return wait
+}
+
+// check returns ResourceExhausted while username or ip is backing off or locked out.
+func (g *loginGuard) check(ctx context.Context, username, ip string) error {
+	wait := g.blocked(ctx, username, ip)
+	if wait <= 0 {
+		return nil
+	}
+	g.audit(ctx, username, ip, false, "throttled")
+	return status.Errorf(codes.ResourceExhausted, "too many failed login attempts; try again in %s", wait.Round(time.Second))
+}
+
+// fail counts a failed attempt against username and ip.
+func (g *loginGuard) fail(ctx context.Context, username, ip, reason string) {
+	now := time.Now()
+	for _, key := range []string{loginUserKey(username), loginIPKey(ip)} {
+		if _, err := g.store.RecordLoginFailure(ctx, key, now, loginCounterWindow); err != nil {
+			log.Warn().Err(err).Msg("login guard: failure not recorded")
+		}
+	}
+	g.audit(ctx, username, ip, false, reason)
+}
+
+// succeed clears username's failures. The IP's counter is left to expire, so one
+// valid account cannot be used to reset guessing from an address.
+func (g *loginGuard) succeed(ctx context.Context, username, ip, reason string) {
+	if err := g.store.ResetLoginCounter(ctx, loginUserKey(username)); err != nil {
+		log.Warn().Err(err).Msg("login guard: counter reset failed")
+	}
+	g.audit(ctx, username, ip, true, reason)
+}
+
+func (g *loginGuard) audit(ctx context.Context, username, ip string, success bool, reason string) {
+	a := loginAttempt{Username: username, IP: ip, Success: success, Reason: reason, At: time.Now()}
+	if err := g.store.RecordLoginAttempt(ctx, a); err != nil {
+		log.Warn().Err(err).Msg("login guard: audit record failed")
+	}
+}
+
+// Run purges expired counters and old audit records every interval.
+func (g *loginGuard) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			if err := g.store.PurgeLoginState(ctx, now.Add(-loginCounterWindow), now.Add(-loginAttemptRetention)); err != nil {
+				log.Warn().Err(err).Msg("login guard: purge failed")
+			}
+		}
+	}
+}
+
+// clientIP returns the caller's address. When the peer is a trusted proxy that is the
+// last x-forwarded-for entry, which the proxy appends; earlier entries are supplied by
+// the client and not trusted. Any other peer could send a new x-forwarded-for with
+// every attempt, so its own address is used.
+func (g *loginGuard) clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if !g.trustedProxy(host) {
+		return host
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get("x-forwarded-for"); len(vals) > 0 {
+			parts := strings.Split(vals[len(vals)-1], ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	return host
+}
+
+func (g *loginGuard) trustedProxy(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range g.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackoffPolicy(t *testing.T) {
+	p := backoffPolicy{free: 3, base: time.Second, max: time.Minute, lockAfter: 10, lockFor: 15 * time.Minute}
+	now := time.Now()
+	cases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0}, {3, 0}, {4, time.Second}, {5, 2 * time.Second}, {8, 16 * time.Second}, {9, 32 * time.Second}, {10, 15 * time.Minute},
+	}
+	for _, tc := range cases {
+		if got := p.blockedFor(loginCounter{Failures: tc.failures, LastFailure: now}, now); got != tc.want {
+			t.Errorf("blockedFor(%d failures) = %s, want %s", tc.failures, got, tc.want)
+		}
+	}
+	if got := p.blockedFor(loginCounter{Failures: 10, LastFailure: now.Add(-20 * time.Minute)}, now); got != 0 {
+		t.Errorf("expected an expired lockout to allow logins, got %s", got)
+	}
+}
+
+func TestLoginGuardLocksOutUsername(t *testing.T) {
+	ctx := context.Background()
+	store := newMemLoginAttemptStore()
+	g := newLoginGuard(store, 3, time.Minute)
+	g.user.free = 10 // isolate the lockout from the backoff
+
+	for i := 0; i < 3; i++ {
+		if err := g.check(ctx, "Alice", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: unexpected %v", i, err)
+		}
+		g.fail(ctx, "Alice", "10.0.0.1", "invalid credentials")
+	}
+	// Locked per username, regardless of case or address.
+	if err := g.check(ctx, "alice", "10.0.0.2"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after the lockout threshold, got %v", err)
+	}
+	if err := g.check(ctx, "bob", "10.0.0.2"); err != nil {
+		t.Errorf("expected other users to be unaffected, got %v", err)
+	}
+
+	g.succeed(ctx, "alice", "10.0.0.2", "password")
+	if err := g.check(ctx, "alice", "10.0.0.2"); err != nil {
+		t.Errorf("expected a successful login to clear the counter, got %v", err)
+	}
+	if len(store.audit) != 5 || !store.audit[4].Success {
+		t.Errorf("expected every attempt to be audited, got %+v", store.audit)
+	}
+}
+
+func TestLoginClientIP(t *testing.T) {
+	g := newLoginGuard(nil, 10, time.Minute)
+	g.trustedProxies = []netip.Prefix{netip.MustParsePrefix("172.16.0.0/12")}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("172.18.0.5"), Port: 4000}})
+	if ip := g.clientIP(ctx); ip != "172.18.0.5" {
+		t.Errorf("expected the peer address, got %s", ip)
+	}
+	xff := metadata.Pairs("x-forwarded-for", "1.2.3.4, 203.0.113.7")
+	if ip := g.clientIP(metadata.NewIncomingContext(ctx, xff)); ip != "203.0.113.7" {
+		t.Errorf("expected the proxy-appended address, got %s", ip)
+	}
+
+	// A client connecting directly cannot choose its address
+	direct := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.9"), Port: 4000}})
+	if ip := g.clientIP(metadata.NewIncomingContext(direct, xff)); ip != "198.51.100.9" {
+		t.Errorf("expected x-forwarded-for from an untrusted peer to be ignored, got %s", ip)
+	}
+	if ip := newLoginGuard(nil, 10, time.Minute).clientIP(metadata.NewIncomingContext(ctx, xff)); ip != "172.18.0.5" {
+		t.Errorf("expected no peer to be trusted by default, got %s", ip)
+	}
+}
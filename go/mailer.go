@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mailMessage is a plain-text email.
+type mailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends account emails such as password resets and address verification.
+type Mailer interface {
+	Send(ctx context.Context, msg mailMessage) error
+}
+
+// newMailer returns an SMTP mailer when SMTP_ADDR is configured, and otherwise a
+// logMailer for local development.
+func newMailer(cfg *AppConfig) Mailer {
+	if cfg.SMTPAddr != "" {
+		return newSMTPMailer(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+	return &logMailer{dir: cfg.MailDir}
+}
+
+// smtpMailer sends mail through an SMTP relay, using STARTTLS when the server offers
+// it. PLAIN auth is only used over TLS or to localhost, as net/smtp enforces.
+type smtpMailer struct {
+	addr     string
+	from     string // From header, possibly with a display name
+	envelope string // bare sender address for MAIL FROM
+	auth     smtp.Auth
+}
+
+func newSMTPMailer(addr, username, password, from string) *smtpMailer {
+	m := &smtpMailer{addr: addr, from: from, envelope: from}
+	if a, err := mail.ParseAddress(from); err == nil {
+		m.envelope = a.Address
+	}
+	if username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg mailMessage) error {
+	errc := make(chan error, 1)
+	go func() { errc <- smtp.SendMail(m.addr, m.auth, m.envelope, []string{msg.To}, formatMail(m.from, msg)) }()
+	select {
+	case err := <-errc:
+		if err != nil {
+			return fmt.Errorf("smtp send to %s: %w", msg.To, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logMailer writes each message to dir as an .eml file, or logs it when dir is empty.
+// It is meant for local development, where the links can be copied from the output.
+type logMailer struct {
+	dir string
+}
+
+func (m *logMailer) Send(_ context.Context, msg mailMessage) error {
+	if m.dir == "" {
+		log.Info().Str("to", msg.To).Str("subject", msg.Subject).Msg("mail (not sent):\n" + msg.Body)
+		return nil
+	}
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitizeFilename(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), formatMail("dev@localhost", msg), 0o600)
+}
+
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '@' || r == '.' || r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// formatMail renders a message with the headers SMTP servers expect. Header values
+// have line breaks removed so user input cannot inject headers.
+func formatMail(from string, msg mailMessage) []byte {
+	clean := strings.NewReplacer("\r", "", "\n", "").Replace
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", clean(from))
+	fmt.Fprintf(&b, "To: %s\r\n", clean(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", clean(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatMailHeaders(t *testing.T) {
+	raw := string(formatMail("Aetherion <no-reply@example.com>", mailMessage{
+		To:      "alice@example.com\r\nBcc: mallory@example.com",
+		Subject: "Reset your password",
+		Body:    "line one\nline two\n",
+	}))
+	headers, body, ok := strings.Cut(raw, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("expected a header/body separator in %q", raw)
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("expected line breaks in header values to be removed, got %q", headers)
+	}
+	if body != "line one\r\nline two\r\n" {
+		t.Errorf("expected CRLF line endings in the body, got %q", body)
+	}
+}
+
+func TestLogMailerWritesEml(t *testing.T) {
+	dir := t.TempDir()
+	m := &logMailer{dir: dir}
+	a := &authServer{appBaseURL: "http://localhost:3000/"}
+	link := a.accountLink("/verify-email", "tok+en")
+	if link != "http://localhost:3000/verify-email?token=tok%2Ben" {
+		t.Errorf("unexpected link %s", link)
+	}
+	if err := m.Send(context.Background(), mailMessage{To: "alice@example.com", Subject: "Verify", Body: link}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "alice@example.com.eml") {
+		t.Fatalf("expected one .eml file, got %v %v", entries, err)
+	}
+	data, _ := os.ReadFile(dir + "/" + entries[0].Name())
+	if !strings.Contains(string(data), link) {
+		t.Errorf("expected the link in the written mail, got %q", data)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -58,6 +60,21 @@ func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInt
 	}
 }
 
+// chainStream composes multiple stream interceptors into a single interceptor.
+func chainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			current := interceptors[i]
+			next := wrapped
+			wrapped = func(sv interface{}, st grpc.ServerStream) error {
+				return current(sv, st, info, next)
+			}
+		}
+		return wrapped(srv, ss)
+	}
+}
+
 // PriceLevel & OrderBookSide definitions are in orderbook.go, but we reassert interface methods here
 func (h OrderBookSide) Len() int           { return len(h) }
 func (h OrderBookSide) Less(i, j int) bool { return h[i].Price < h[j].Price }
@@ -177,9 +194,6 @@ type OrderBookEntry struct {
 }
 
 func (s *Strategy) Run(ctx context.Context, server *tradingServer) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	// Initialize strategy-specific parameters
 	threshold, _ := strconv.ParseFloat(s.Parameters["threshold"], 64)
 	period, _ := strconv.Atoi(s.Parameters["period"])
@@ -194,10 +208,12 @@ func (s *Strategy) Run(ctx context.Context, server *tradingServer) {
 	for {
 		select {
 		case <-ctx.Done():
+			s.mu.Lock()
 			s.IsActive = false
+			s.mu.Unlock()
 			return
 		case <-ticker.C:
-			if !s.IsActive {
+			if !s.running() {
 				return
 			}
 
@@ -207,17 +223,14 @@ func (s *Strategy) Run(ctx context.Context, server *tradingServer) {
 				log.Printf("Error getting price for %s: %v", s.Symbol, err)
 				continue
 			}
+			mean, first := s.observe(price)
 
 			// Implement strategy logic here based on StrategyType
 			switch s.StrategyType {
 			case "MEAN_REVERSION":
-				// Example mean reversion logic
-				// You would typically calculate moving average here
-				// and compare with current price
-				log.Printf("Running mean reversion strategy for %s at price %.2f (threshold: %.2f)", s.Symbol, price, threshold)
+				log.Printf("Running mean reversion strategy for %s at price %.2f (mean: %.2f, threshold: %.2f)", s.Symbol, price, mean, threshold)
 			case "MOMENTUM":
-				// Implement momentum strategy logic
-				log.Printf("Running momentum strategy for %s at price %.2f", s.Symbol, price)
+				log.Printf("Running momentum strategy for %s at price %.2f (change over window: %.2f)", s.Symbol, price, price-first)
 			default:
 				log.Printf("Unknown strategy type: %s", s.StrategyType)
 			}
@@ -235,6 +248,7 @@ func (s *Strategy) Run(ctx context.Context, server *tradingServer) {
 type portfolioServer struct {
 	pb.UnimplementedPortfolioServiceServer
 	dbService *DBService
+	bots      botLookup // bot ownership for authorization (injected)
 }
 
 func newPortfolioServer(dbService *DBService) *portfolioServer {
@@ -257,6 +271,10 @@ type tradingServer struct {
 	histMu    sync.RWMutex
 	priceHist map[string][]histPoint
 	dbService *DBService
+	// checkpoints saves and restores bots' strategy state (injected)
+	checkpoints *strategyCheckpointer
+	// bots checks that trades are recorded against the caller's own bots (injected)
+	bots botLookup
 }
 
 type histPoint struct {
@@ -296,6 +314,13 @@ func (s *tradingServer) GetPrice(ctx context.Context, req *pb.Tick) (*pb.Tick, e
 
 // StartStrategy is a standard RPC call
 func (s *tradingServer) StartStrategy(ctx context.Context, req *pb.StrategyRequest) (*pb.StatusResponse, error) {
+	// Strategies started over the RPC belong to no bot, so they are never checkpointed
+	return s.runStrategy(ctx, req, "")
+}
+
+// runStrategy starts a strategy for botID, restoring the bot's checkpoint.
+// Only BotService passes a bot id, after checking the caller owns the bot.
+func (s *tradingServer) runStrategy(ctx context.Context, req *pb.StrategyRequest, botID string) (*pb.StatusResponse, error) {
 	// Create a new strategy instance
 	log.Printf("[Go Server] Starting strategy for %s with parameters: %v", req.Symbol, req.Parameters)
 	strategy := &Strategy{
@@ -305,8 +330,18 @@ func (s *tradingServer) StartStrategy(ctx context.Context, req *pb.StrategyReque
 		Parameters:   req.Parameters,
 		IsActive:     true,
 		CreatedAt:    time.Now(),
+		BotID:        botID,
+	}
+	if s.checkpoints != nil {
+		if err := s.checkpoints.restore(ctx, strategy); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 	}
 
+	// The strategy outlives this RPC; StopStrategy cancels it
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	strategy.cancel = cancel
+
 	// Store the strategy in the server's strategies map
 	s.mu.Lock()
 	s.strategies[strategy.ID] = strategy
@@ -314,7 +349,14 @@ func (s *tradingServer) StartStrategy(ctx context.Context, req *pb.StrategyReque
 
 	// Start strategy-specific processing in a goroutine
 	go func() {
-		strategy.Run(ctx, s)
+		strategy.Run(runCtx, s)
+		cancel()
+		if s.checkpoints != nil {
+			if err := s.checkpoints.save(context.Background(), strategy); err != nil {
+				log.Warn().Err(err).Str("bot_id", strategy.BotID).Msg("failed to checkpoint stopped strategy")
+			}
+		}
+		s.eventBus.Publish(Event{Type: EventStrategyStatus, Data: StrategyStatus{StrategyID: strategy.ID, BotID: strategy.BotID}})
 	}()
 
 	return &pb.StatusResponse{
@@ -324,48 +366,15 @@ func (s *tradingServer) StartStrategy(ctx context.Context, req *pb.StrategyReque
 	}, nil
 }
 
-// Service level RPC
-func (s *tradingServer) GetTradeHistory(ctx context.Context, req *pb.TradeHistoryRequest) (*pb.TradeHistoryResponse, error) {
-	if s.dbService == nil {
-		return nil, fmt.Errorf("trade history unavailable")
-	}
-
-	// Extract user ID from context (set by auth interceptor)
-	userID := req.GetUserId()
-
-	// Extract bot ID from request
-	botID := req.GetBotId()
-
-	// Validate request
-	if userID == "" && botID == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id or bot_id are required")
-	}
-
-	if botID != "" {
-		trades, err := s.dbService.GetTradesByBotID(ctx, botID)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to get trade history")
-			return nil, err
-		}
-
-		pbTrades := make([]*pb.Trade, len(trades))
-		for i, trade := range trades {
-			pbTrades[i] = &pb.Trade{
-				TradeId: trade.TradeId, BotId: trade.BotId,
-				Symbol: trade.Symbol, Side: trade.Side, Quantity: trade.Quantity,
-				Price: trade.Price, ExecutedAt: trade.ExecutedAt}
-		}
-		return &pb.TradeHistoryResponse{Trades: pbTrades}, nil
-	}
-	return nil, status.Error(codes.Internal, "bot_id is required")
-}
-
 // StopStrategy stops a running strategy
 func (s *tradingServer) StopStrategy(ctx context.Context, req *pb.StrategyRequest) (*pb.StatusResponse, error) {
 	fmt.Printf("[Go Server] Stopping strategy %s for %s\n", req.StrategyId, req.Symbol)
 
 	s.mu.Lock()
 	delete(s.activeSymbols, req.Symbol)
+	if strategy, ok := s.strategies[req.StrategyId]; ok && strategy.cancel != nil {
+		strategy.cancel()
+	}
 	s.mu.Unlock()
 
 	return &pb.StatusResponse{Success: true, Message: "Strategy stopped"}, nil
@@ -442,6 +451,10 @@ func (s *tradingServer) SubscribeTicks(req *pb.StrategyRequest, stream pb.Tradin
 	// In a real app, this would connect to an exchange feed.
 	// Here, we fetch real price from Coinbase.
 	for {
+		// Stop when the client goes away or the stream is closed for idleness
+		if stream.Context().Err() != nil {
+			return nil
+		}
 		// Coinbase uses symbol format like BTC-USD
 		coinbaseSymbol := req.Symbol
 
@@ -651,16 +664,25 @@ func (s *tradingServer) ListSymbols(ctx context.Context, _ *pb.Empty) (*pb.Symbo
 ///////////////////////////////////////
 
 func (s *portfolioServer) GetPortfolio(ctx context.Context, req *pb.PortfolioRequest) (*pb.PortfolioResponse, error) {
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
 	// TODO: Implement actual portfolio retrieval logic
 	return &pb.PortfolioResponse{}, nil
 }
 
 func (s *portfolioServer) StreamPortfolio(req *pb.PortfolioRequest, stream pb.PortfolioService_StreamPortfolioServer) error {
+	if err := authorizeBot(stream.Context(), s.bots, req.BotId); err != nil {
+		return err
+	}
 	// TODO: Implement actual portfolio streaming logic
 	return nil
 }
 
 func (s *portfolioServer) GetPerformanceHistory(ctx context.Context, req *pb.PerformanceHistoryRequest) (*pb.PerformanceHistoryResponse, error) {
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
 	// Implementation here
 	return &pb.PerformanceHistoryResponse{}, nil
 }
@@ -686,6 +708,16 @@ func (s *tradingServer) ExecuteTrade(ctx context.Context, req *pb.TradeRequest)
 	if _, err := uuid.Parse(req.BotId); err != nil {
 		return &pb.TradeResponse{Accepted: false, Message: "bot_id must be a valid UUID"}, nil
 	}
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
+
+	// A retried submit returns the execution recorded by the first attempt.
+	if req.ClientOrderId != "" && s.dbService != nil {
+		if existing, err := s.dbService.GetTradeByClientOrderID(ctx, req.BotId, req.ClientOrderId); err == nil {
+			return retriedTrade(existing, req)
+		}
+	}
 
 	// Get reference price (use provided price if >0 else fetch current)
 	execPrice := req.Price
@@ -701,24 +733,46 @@ func (s *tradingServer) ExecuteTrade(ctx context.Context, req *pb.TradeRequest)
 	// Implement portfolio update logic
 
 	trade := &pb.Trade{
-		TradeId:    uuid.New().String(),
-		Symbol:     req.Symbol,
-		Side:       req.Side,
-		Quantity:   req.Size,
-		Price:      execPrice,
-		ExecutedAt: time.Now().UnixNano(),
-		StrategyId: req.StrategyId,
-		BotId:      req.BotId,
+		TradeId:       uuid.New().String(),
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Quantity:      req.Size,
+		Price:         execPrice,
+		ExecutedAt:    time.Now().UnixNano(),
+		StrategyId:    req.StrategyId,
+		BotId:         req.BotId,
+		ClientOrderId: req.ClientOrderId,
 	}
 	if s.dbService != nil {
-		if err := s.dbService.RecordTrade(ctx, trade); err != nil {
+		err := s.dbService.RecordTrade(ctx, trade)
+		if errors.Is(err, errDuplicateClientOrderID) {
+			// Lost a race with a concurrent retry of the same submit.
+			existing, err := s.dbService.GetTradeByClientOrderID(ctx, req.BotId, req.ClientOrderId)
+			if err != nil {
+				return nil, err
+			}
+			return retriedTrade(existing, req)
+		}
+		if err != nil {
 			log.Error().Err(err).Msg("failed to record trade")
 		}
 	}
 
 	pnl := 0.0
 
-	return &pb.TradeResponse{Accepted: true, Message: "executed", ExecutedPrice: execPrice, Pnl: pnl}, nil
+	return &pb.TradeResponse{Accepted: true, Message: "executed", ExecutedPrice: execPrice, Pnl: pnl, TradeId: trade.TradeId}, nil
+}
+
+// retriedTrade answers a retried ExecuteTrade with the execution its first attempt
+// recorded. A client_order_id reused for a different trade fails with AlreadyExists.
+// A zero price asks for the market price, so it matches whatever price was used.
+func retriedTrade(existing *pb.Trade, req *pb.TradeRequest) (*pb.TradeResponse, error) {
+	if existing.Symbol != req.Symbol || existing.Side != req.Side ||
+		!sameDecimal(floatToDecimal(existing.Quantity), floatToDecimal(req.Size)) ||
+		(req.Price > 0 && !sameDecimal(floatToDecimal(existing.Price), floatToDecimal(req.Price))) {
+		return nil, status.Errorf(codes.AlreadyExists, "client_order_id %q was already used for a different trade", req.ClientOrderId)
+	}
+	return &pb.TradeResponse{Accepted: true, Message: "duplicate client_order_id, returning original execution", ExecutedPrice: existing.Price, TradeId: existing.TradeId}, nil
 }
 
 func main() {
@@ -733,6 +787,9 @@ func main() {
 		level = zerolog.InfoLevel
 	}
 	zerolog.SetGlobalLevel(level)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:], os.Stdout))
+	}
 	log.Info().Str("env", cfg.Env).Str("log_level", level.String()).Msg("starting service")
 
 	secret := cfg.AuthSecret
@@ -752,6 +809,15 @@ func main() {
 	if prevSecret != "" && len(prevSecret) < 32 {
 		log.Warn().Msg("AUTH_PREVIOUS_SECRET length <32")
 	}
+	// Tokens signed with the previous secret or key stay valid during a rotation
+	signingKeys, err := newSigningKeys(secret, prevSecret, cfg.AuthEd25519PrivateKey, cfg.AuthEd25519PreviousPublicKey)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid JWT signing keys")
+	}
+	log.Info().Str("kid", signingKeys.current.kid).Msg("JWT signing key loaded")
+	// Revoked access tokens; backed by the database once it is connected below
+	denylist := newJTIDenylist()
+	apiKeys := newAPIKeyAuth()
 	// Create a gRPC server with custom options
 	grpcServer := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -760,9 +826,14 @@ func main() {
 			Timeout:           1 * time.Second,
 		}),
 		grpc.UnaryInterceptor(chainUnary(
-			authUnaryInterceptor([]byte(secret)),
+			authUnaryInterceptor(signingKeys, denylist, apiKeys),
 			timeoutUnary(cfg.RequestTimeout),
 		)),
+		grpc.StreamInterceptor(chainStream(
+			authStreamInterceptor(signingKeys, denylist, apiKeys),
+			newStreamLimiter(cfg.StreamMaxPerUser).interceptor(),
+			idleTimeoutStream(cfg.StreamIdleTimeout),
+		)),
 	)
 
 	/////////////////////////
@@ -773,6 +844,19 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to initialize DBService")
 	}
+	// Refuse to run against a schema this build was not written for
+	migrations, err := newMigrator(dbService.pool)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load migrations")
+	}
+	if cfg.MigrateOnStart {
+		if _, err := migrations.Up(context.Background()); err != nil {
+			log.Fatal().Err(err).Msg("failed to apply migrations")
+		}
+	}
+	if err := migrations.Verify(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("unexpected database schema version")
+	}
 
 	portfolioService := newPortfolioServer(dbService)
 	pb.RegisterPortfolioServiceServer(grpcServer, portfolioService)
@@ -781,16 +865,127 @@ func main() {
 	tradingService.dbService = dbService
 	pb.RegisterTradingServiceServer(grpcServer, tradingService)
 
-	reg := newBotRegistry()
+	var botRepo BotRepository = dbService
+	if cfg.BotStoreFile != "" {
+		fileRepo, err := newFileBotRepository(cfg.BotStoreFile)
+		if err != nil {
+			log.Fatal().Err(err).Str("path", cfg.BotStoreFile).Msg("failed to open bot store")
+		}
+		if botRepo, err = newMirroredBotRepository(context.Background(), fileRepo, dbService); err != nil {
+			log.Fatal().Err(err).Str("path", cfg.BotStoreFile).Msg("failed to copy stored bots to the database")
+		}
+	}
+	reg, err := newBotRegistry(context.Background(), botRepo)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load bots")
+	}
 	botSvc := newBotServiceServer(reg, tradingService, dbService)
 	pb.RegisterBotServiceServer(grpcServer, botSvc)
 
-	authSvc := newAuthServer(secret)
+	authSvc := newAuthServer(signingKeys, dbService)
+	authSvc.sessions = dbService
+	authSvc.bots = botSvc
+	authSvc.accessTTL = cfg.AccessTokenTTL
+	authSvc.refreshTTL = cfg.RefreshTokenTTL
+	authSvc.denylist = denylist
+	denylist.db = dbService
+	authSvc.apiKeys = apiKeys
+	authSvc.mfaRequiredRoles = make(map[string]bool)
+	for _, r := range cfg.MFARequiredRoles {
+		authSvc.mfaRequiredRoles[r] = true
+	}
+	if len(cfg.CredentialsMasterKey) > 0 {
+		if authSvc.totpKey, err = newGCM(cfg.CredentialsMasterKey); err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize two-factor key")
+		}
+	}
+	apiKeys.db = dbService
+	authSvc.guard = newLoginGuard(dbService, cfg.LoginMaxFailures, cfg.LoginLockout)
+	authSvc.guard.trustedProxies = cfg.TrustedProxies
+	authSvc.mailer = newMailer(cfg)
+	authSvc.appBaseURL = cfg.AppBaseURL
+	if len(cfg.MFARequiredRoles) > 0 && !authSvc.mfaAvailable() {
+		log.Warn().Strs("roles", cfg.MFARequiredRoles).Msg("two-factor authentication is unavailable without POSTGRES_DSN and CREDENTIALS_MASTER_KEY; these roles cannot log in")
+	}
 	pb.RegisterAuthServiceServer(grpcServer, authSvc)
+	pb.RegisterAdminServiceServer(grpcServer, newAdminServiceServer(authSvc.store, botSvc))
 
-	orderSvc := newOrderServiceServer(dbService)
+	orderSvc := newOrderServiceServer(dbService, tradingService.eventBus, cfg.DaySession)
 	pb.RegisterOrderServiceServer(grpcServer, orderSvc)
 
+	// Background workers share a context that is canceled on shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	orderSvc.journal = newOrderJournal(dbService, cfg.OrderEventRetention)
+	go orderSvc.journal.Run(workerCtx)
+	go newOrderExpirer(dbService, tradingService.eventBus, orderSvc.journal, cfg.OrderExpiryInterval).Run(workerCtx)
+
+	// Parent-order algos slice through orderSvc and weight VWAP by observed traded volume
+	volProfile := newVolumeProfile()
+	go volProfile.Run(workerCtx, tradingService.eventBus)
+	lastPrice := func(ctx context.Context, symbol string) (float64, error) {
+		tick, err := tradingService.GetPrice(ctx, &pb.Tick{Symbol: symbol})
+		if err != nil {
+			return 0, err
+		}
+		return tick.Price, nil
+	}
+	orderSvc.algos = newAlgoEngine(orderSvc, tradingService.eventBus, lastPrice, volProfile)
+	orderSvc.algos.Start(workerCtx)
+
+	// Execution: paper bots fill in the simulator, is_live bots on Coinbase when enabled
+	paper := newPaperVenue(lastPrice)
+	paper.apply = func(ctx context.Context, fill VenueFill) {
+		if _, _, err := orderSvc.applyFill(ctx, fill); err != nil {
+			log.Error().Err(err).Str("order_id", fill.OrderID).Msg("failed to apply paper fill")
+		}
+	}
+	go paper.Run(workerCtx, tradingService.eventBus)
+	// Users' exchange keys are sealed under the master key; without one they cannot be stored
+	var vault *credentialVault
+	if len(cfg.CredentialsMasterKey) > 0 {
+		if vault, err = newCredentialVault(dbService, cfg.CredentialsMasterKey); err != nil {
+			log.Fatal().Err(err).Msg("failed to initialize credential vault")
+		}
+	}
+	pb.RegisterCredentialServiceServer(grpcServer, newCredentialServiceServer(vault))
+
+	var live ExecutionVenue
+	if cfg.LiveTradingEnabled {
+		// Bots trade on their owner's stored key; a configured platform key is used instead when set
+		var keys coinbaseKeyring = vaultCoinbaseKeyring{vault: vault, bots: reg.get}
+		if cfg.CoinbaseAPIKeyName != "" {
+			keys = staticCoinbaseKeyring{creds: coinbaseCredentials{KeyName: cfg.CoinbaseAPIKeyName, PrivateKey: cfg.CoinbaseAPIPrivateKey}}
+		}
+		coinbase := newCoinbaseVenue(cfg.CoinbaseAPIBaseURL, keys)
+		go newFillPoller(coinbase, orderSvc, cfg.CoinbaseFillPollInterval).Run(workerCtx)
+		orderSvc.reconciler = newReconciler(coinbase, dbService, orderSvc, cfg.ReconcileInterval, cfg.ReconcileAutoCorrect, cfg.ReconcileFillLookback)
+		go orderSvc.reconciler.Run(workerCtx)
+		live = coinbase
+		log.Warn().Str("venue", coinbase.Name()).Msg("live trading enabled")
+	}
+	orderSvc.venues = newVenueRouter(paper, live)
+	orderSvc.bots = reg.get
+	portfolioService.bots = reg.get
+	tradingService.bots = reg.get
+	go orderSvc.runVenueExpiry(workerCtx)
+	go denylist.Run(workerCtx, 30*time.Second)
+	go authSvc.guard.Run(workerCtx, time.Hour)
+	tradingService.checkpoints = newStrategyCheckpointer(dbService, cfg.StrategyCheckpointInterval)
+	go tradingService.checkpoints.Run(workerCtx, tradingService)
+	botSvc.snapshots = dbService
+	botSvc.snapshotEvery = cfg.PerformanceSnapshotInterval
+	go botSvc.RunStatusFeed(workerCtx)
+	botSvc.venues = orderSvc.venues
+	botSvc.resumeActiveBots(context.Background())
+
+	exportSvc := newExportServiceServer(dbService)
+	exportSvc.bots = reg.get
+	exportSvc.authHTTP = func(r *http.Request, fullMethod string) (context.Context, error) {
+		return authHTTPRequest(r, fullMethod, signingKeys, denylist, apiKeys)
+	}
+	pb.RegisterExportServiceServer(grpcServer, exportSvc)
+
 	subscriptionSvc := newSubscriptionServer()
 	pb.RegisterSubscriptionServiceServer(grpcServer, subscriptionSvc)
 
@@ -802,6 +997,8 @@ func main() {
 			_, _ = w.Write([]byte("ok"))
 		})
 		mux.HandleFunc("/stripe/webhook", handleStripeWebhook)
+		mux.HandleFunc("/.well-known/jwks.json", signingKeys.handleJWKS)
+		mux.Handle("/export/", exportSvc) // CSV, JSONL and Parquet downloads
 		handler := corsMiddleware().Handler(mux)
 		log.Info().Msgf("HTTP server with CORS listening on %s", addr)
 		srv := &http.Server{Addr: addr, Handler: handler}
@@ -855,6 +1052,17 @@ func main() {
 		}
 	}(cfg.HTTPHealthAddr)
 
+	// Internal metrics listener (loopback by default) for reconciliation counters
+	go func(addr string) {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		log.Info().Msgf("metrics listening on %s", addr)
+		srv := &http.Server{Addr: addr, Handler: mux}
+		if err := srv.ListenAndServe(); err != nil {
+			log.Warn().Err(err).Msg("metrics server exited")
+		}
+	}(cfg.MetricsAddr)
+
 	// Start gRPC server with graceful shutdown
 	lis, err := net.Listen("tcp", cfg.GRPCListenAddr)
 	if err != nil {
@@ -880,6 +1088,11 @@ func main() {
 	}
 
 	// Graceful stop
+	stopWorkers()
+	if tradingService.checkpoints != nil {
+		// Strategies keep running until exit, so save where they got to for the next start
+		tradingService.checkpoints.saveRunning(context.Background(), tradingService)
+	}
 	feed.Stop()
 	log.Info().Msg("market data feed stopped")
 	grpcServer.GracefulStop()
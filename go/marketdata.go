@@ -16,13 +16,18 @@ import (
 type EventType string
 
 const (
-	EventPriceTick EventType = "price_tick"
+	EventPriceTick   EventType = "price_tick"
+	EventOrderUpdate EventType = "order_update" // Data is an OrderEvent
+	EventBotStatus   EventType = "bot_status"   // Data is a *pb.Bot snapshot
+	// EventStrategyStatus is published when a running strategy exits; Data is a StrategyStatus
+	EventStrategyStatus EventType = "strategy_status"
 )
 
 // PriceTick represents a normalized price update
 type PriceTick struct {
 	Symbol string
 	Price  float64
+	Size   float64 // size of the last trade, 0 when unknown
 	Ts     time.Time
 }
 
@@ -85,6 +90,7 @@ type coinbaseTicker struct {
 	Type      string `json:"type"`
 	ProductID string `json:"product_id"`
 	Price     string `json:"price"`
+	LastSize  string `json:"last_size"`
 	Time      string `json:"time"`
 }
 
@@ -234,10 +240,14 @@ func (f *CoinbaseFeed) connectAndServe() error {
 			if err := json.Unmarshal([]byte(tk.Price), &p); err != nil {
 				continue
 			}
+			var size float64
+			if tk.LastSize != "" {
+				_ = json.Unmarshal([]byte(tk.LastSize), &size)
+			}
 			if f.onPrice != nil {
 				f.onPrice(tk.ProductID, p)
 			}
-			f.bus.Publish(Event{Type: EventPriceTick, Data: PriceTick{Symbol: tk.ProductID, Price: p, Ts: time.Now()}})
+			f.bus.Publish(Event{Type: EventPriceTick, Data: PriceTick{Symbol: tk.ProductID, Price: p, Size: size, Ts: time.Now()}})
 		}
 	}
 }
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// Schema migrations are NNN_name.up.sql and NNN_name.down.sql files, numbered from
+// 001 without gaps. Every file runs in its own transaction.
+//
+//go:embed db/migrations/*.sql
+var migrationFiles embed.FS
+
+const migrationDir = "db/migrations"
+
+// migrationLockID is the advisory lock key that serializes migration runs across
+// instances.
+const migrationLockID int64 = 0x61657468_6d696772 // "aeth" "migr"
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// loadMigrations reads the migrations in dir, ordered by version.
+func loadMigrations(fsys fs.FS, dir string) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*migration)
+	for _, e := range entries {
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: name must be NNN_name.up.sql or NNN_name.down.sql", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &migration{Version: version, Name: m[2]}
+			byVersion[version] = mg
+		}
+		if mg.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mg.Name, m[2])
+		}
+		if m[3] == "up" {
+			mg.Up = string(b)
+		} else {
+			mg.Down = string(b)
+		}
+	}
+	out := make([]migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		out = append(out, *mg)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	for i, mg := range out {
+		if mg.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if mg.Up == "" || mg.Down == "" {
+			return nil, fmt.Errorf("migration %03d_%s needs both an up and a down file", mg.Version, mg.Name)
+		}
+	}
+	return out, nil
+}
+
+// appliedMigration is a schema_migrations row.
+type appliedMigration struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+// migrator applies the embedded migrations and records them in schema_migrations.
+type migrator struct {
+	pool       *pgxpool.Pool
+	migrations []migration
+}
+
+func newMigrator(pool *pgxpool.Pool) (*migrator, error) {
+	ms, err := loadMigrations(migrationFiles, migrationDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return &migrator{pool: pool, migrations: ms}, nil
+}
+
+// latest is the schema version this build expects.
+func (m *migrator) latest() int { return len(m.migrations) }
+
+func (m *migrator) ensureTable(ctx context.Context) error {
+	_, err := m.pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// applied returns the recorded migrations, ordered by version.
+func (m *migrator) applied(ctx context.Context) ([]appliedMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := m.pool.Query(ctx, `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	defer rows.Close()
+	var out []appliedMigration
+	for rows.Next() {
+		var a appliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// step runs one migration in a transaction holding the migration lock. pick returns
+// the migration to run given the current version, or nil when there is nothing to do.
+func (m *migrator) step(ctx context.Context, pick func(current int) (*migration, bool, error)) (*migration, error) {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return nil, fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	var current int
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	mg, up, err := pick(current)
+	if mg == nil || err != nil {
+		return nil, err
+	}
+	if err := m.exec(ctx, tx, mg, up); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit migration %d: %w", mg.Version, err)
+	}
+	return mg, nil
+}
+
+func (m *migrator) exec(ctx context.Context, tx pgx.Tx, mg *migration, up bool) error {
+	sql, record, args := mg.Down, `DELETE FROM schema_migrations WHERE version = $1`, []any{mg.Version}
+	if up {
+		sql, record, args = mg.Up, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, []any{mg.Version, mg.Name}
+	}
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("migration %03d_%s failed: %w", mg.Version, mg.Name, err)
+	}
+	if _, err := tx.Exec(ctx, record, args...); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", mg.Version, err)
+	}
+	return nil
+}
+
+// Up applies every pending migration and returns the ones it applied.
+func (m *migrator) Up(ctx context.Context) ([]migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	var done []migration
+	for {
+		mg, err := m.step(ctx, func(current int) (*migration, bool, error) {
+			if current > m.latest() {
+				return nil, true, fmt.Errorf("database schema version %d is newer than this build (%d)", current, m.latest())
+			}
+			if current == m.latest() {
+				return nil, true, nil
+			}
+			return &m.migrations[current], true, nil
+		})
+		if err != nil || mg == nil {
+			return done, err
+		}
+		log.Info().Int("version", mg.Version).Str("name", mg.Name).Msg("migration applied")
+		done = append(done, *mg)
+	}
+}
+
+// Down reverts the latest n migrations and returns the ones it reverted.
+func (m *migrator) Down(ctx context.Context, n int) ([]migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	var done []migration
+	for range n {
+		mg, err := m.step(ctx, func(current int) (*migration, bool, error) {
+			if current > m.latest() {
+				return nil, false, fmt.Errorf("database schema version %d is newer than this build (%d); revert it with a newer build", current, m.latest())
+			}
+			if current == 0 {
+				return nil, false, nil
+			}
+			return &m.migrations[current-1], false, nil
+		})
+		if err != nil || mg == nil {
+			return done, err
+		}
+		log.Info().Int("version", mg.Version).Str("name", mg.Name).Msg("migration reverted")
+		done = append(done, *mg)
+	}
+	return done, nil
+}
+
+// Verify returns an error unless the database has exactly this build's migrations
+// applied.
+func (m *migrator) Verify(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for i, a := range applied {
+		if a.Version != i+1 {
+			return fmt.Errorf("schema_migrations is missing version %d", i+1)
+		}
+		if a.Version > m.latest() {
+			return fmt.Errorf("database schema version %d is newer than this build (%d)", applied[len(applied)-1].Version, m.latest())
+		}
+		if want := m.migrations[i].Name; a.Name != want {
+			return fmt.Errorf("database has migration %d as %q, this build has %q", a.Version, a.Name, want)
+		}
+	}
+	if len(applied) < m.latest() {
+		return fmt.Errorf("database schema version %d is behind this build (%d); run `migrate up` or set DB_MIGRATE_ON_START=true", len(applied), m.latest())
+	}
+	return nil
+}
+
+// Status writes each migration and when it was applied.
+func (m *migrator) Status(ctx context.Context, w io.Writer) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	at := make(map[int]appliedMigration, len(applied))
+	for _, a := range applied {
+		at[a.Version] = a
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED")
+	for _, mg := range m.migrations {
+		when := "pending"
+		if a, ok := at[mg.Version]; ok {
+			when = a.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%03d\t%s\t%s\n", mg.Version, mg.Name, when)
+	}
+	for _, a := range applied {
+		if a.Version > m.latest() {
+			fmt.Fprintf(tw, "%03d\t%s\t%s (unknown to this build)\n", a.Version, a.Name, a.AppliedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	return tw.Flush()
+}
+
+// runMigrate implements `trading_service migrate up|down [n]|status` and returns the
+// process exit code.
+func runMigrate(cfg *AppConfig, args []string, stdout io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stdout, "usage: migrate up | down [n] | status")
+		return 2
+	}
+	db, err := NewDBService(cfg.PostgresDSN)
+	if err != nil {
+		log.Error().Err(err).Msg("migrate: database unavailable")
+		return 1
+	}
+	defer db.Close()
+	m, err := newMigrator(db.pool)
+	if err != nil {
+		log.Error().Err(err).Msg("migrate")
+		return 1
+	}
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("migrate up")
+			return 1
+		}
+		fmt.Fprintf(stdout, "applied %d migration(s); schema version %d\n", len(done), m.latest())
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+				fmt.Fprintln(stdout, "usage: migrate down [n], n >= 1")
+				return 2
+			}
+		}
+		done, err := m.Down(ctx, n)
+		if err != nil {
+			log.Error().Err(err).Msg("migrate down")
+			return 1
+		}
+		fmt.Fprintf(stdout, "reverted %d migration(s)\n", len(done))
+	case "status":
+		if err := m.Status(ctx, stdout); err != nil {
+			log.Error().Err(err).Msg("migrate status")
+			return 1
+		}
+	default:
+		fmt.Fprintln(stdout, "usage: migrate up | down [n] | status")
+		return 2
+	}
+	return 0
+}
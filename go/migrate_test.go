@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	ms, err := loadMigrations(migrationFiles, migrationDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) < 13 {
+		t.Fatalf("expected at least 13 migrations, got %d", len(ms))
+	}
+	if ms[0].Name != "initial_schema" || !strings.Contains(ms[0].Up, "CREATE TABLE IF NOT EXISTS users") {
+		t.Errorf("unexpected first migration %03d_%s", ms[0].Version, ms[0].Name)
+	}
+}
+
+func TestLoadMigrationsRejectsBadSets(t *testing.T) {
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+	for name, fsys := range map[string]fstest.MapFS{
+		"gap": {
+			"m/001_a.up.sql": file("SELECT 1"), "m/001_a.down.sql": file("SELECT 1"),
+			"m/003_c.up.sql": file("SELECT 1"), "m/003_c.down.sql": file("SELECT 1"),
+		},
+		"missing down": {
+			"m/001_a.up.sql": file("SELECT 1"),
+		},
+		"bad name": {
+			"m/001_a.sql": file("SELECT 1"),
+		},
+		"two names": {
+			"m/001_a.up.sql": file("SELECT 1"), "m/001_b.down.sql": file("SELECT 1"),
+		},
+	} {
+		if _, err := loadMigrations(fsys, "m"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	ms, err := loadMigrations(fstest.MapFS{
+		"m/002_b.up.sql": file("up b"), "m/002_b.down.sql": file("down b"),
+		"m/001_a.up.sql": file("up a"), "m/001_a.down.sql": file("down a"),
+	}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 || ms[0].Name != "a" || ms[1].Up != "up b" || ms[1].Down != "down b" {
+		t.Errorf("unexpected migrations %+v", ms)
+	}
+}
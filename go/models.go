@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -42,6 +43,7 @@ type Subscription struct {
 type Strategy struct {
 	ID           string
 	UserID       string
+	BotID        string // the bot it runs for; checkpoints are saved under it
 	Symbol       string
 	StrategyType string
 	Parameters   map[string]string
@@ -49,4 +51,13 @@ type Strategy struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	mu           sync.Mutex
+	cancel       context.CancelFunc // stops Run
+	prices       []float64          // rolling window of observed prices, oldest first
+}
+
+// StrategyStatus reports a strategy that stopped running.
+type StrategyStatus struct {
+	StrategyID string
+	BotID      string
+	Active     bool
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sessionBoundary is the daily cutoff at which DAY orders expire, expressed as an
+// offset from local midnight in loc.
+type sessionBoundary struct {
+	offset time.Duration
+	loc    *time.Location
+}
+
+// parseSessionBoundary parses an "HH:MM" close time in the named time zone.
+func parseSessionBoundary(hhmm, tz string) (sessionBoundary, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return sessionBoundary{}, fmt.Errorf("invalid session close %q (want HH:MM): %w", hhmm, err)
+	}
+	loc := time.UTC
+	if tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return sessionBoundary{}, fmt.Errorf("invalid session time zone %q: %w", tz, err)
+		}
+	}
+	return sessionBoundary{offset: time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, loc: loc}, nil
+}
+
+// next returns the first session boundary strictly after t.
+func (b sessionBoundary) next(t time.Time) time.Time {
+	loc := b.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	lt := t.In(loc)
+	midnight := time.Date(lt.Year(), lt.Month(), lt.Day(), 0, 0, 0, 0, loc)
+	boundary := midnight.Add(b.offset)
+	if !boundary.After(lt) {
+		boundary = time.Date(lt.Year(), lt.Month(), lt.Day()+1, 0, 0, 0, 0, loc).Add(b.offset)
+	}
+	return boundary
+}
+
+// resolveExpiry validates the time-in-force of a new order and returns the moment it
+// should expire. A zero time means the order never expires (GTC).
+func resolveExpiry(tif pb.TimeInForce, expireAt *timestamppb.Timestamp, now time.Time, session sessionBoundary) (time.Time, error) {
+	switch tif {
+	case pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED, pb.TimeInForce_GTC:
+		return time.Time{}, nil
+	case pb.TimeInForce_IOC, pb.TimeInForce_FOK:
+		// Nothing rests on the book: whatever is not filled on arrival expires.
+		return now, nil
+	case pb.TimeInForce_GTD:
+		if expireAt == nil {
+			return time.Time{}, fmt.Errorf("expire_at is required for GTD orders")
+		}
+		if err := expireAt.CheckValid(); err != nil {
+			return time.Time{}, fmt.Errorf("invalid expire_at: %w", err)
+		}
+		t := expireAt.AsTime()
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("expire_at must be in the future")
+		}
+		return t, nil
+	case pb.TimeInForce_DAY:
+		return session.next(now), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported time_in_force %v", tif)
+	}
+}
+
+// orderExpirer periodically moves open orders past their expiry to EXPIRED and
+// publishes an order event for each one.
+type orderExpirer struct {
+	db       *DBService
+	bus      *EventBus
+	journal  *orderJournal
+	interval time.Duration
+}
+
+func newOrderExpirer(db *DBService, bus *EventBus, journal *orderJournal, interval time.Duration) *orderExpirer {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &orderExpirer{db: db, bus: bus, journal: journal, interval: interval}
+}
+
+// Run sweeps until ctx is canceled.
+func (e *orderExpirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			e.sweep(ctx, now)
+		}
+	}
+}
+
+func (e *orderExpirer) sweep(ctx context.Context, now time.Time) {
+	expired, err := e.db.ExpireOrders(ctx, now)
+	if err != nil {
+		log.Warn().Err(err).Msg("order expiry sweep failed")
+		return
+	}
+	for _, order := range expired {
+		log.Info().Str("order_id", order.Id).Str("bot_id", order.BotId).Str("time_in_force", order.TimeInForce.String()).Msg("order expired")
+		publishOrderEvent(ctx, e.bus, e.journal, OrderEvent{Order: order})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestResolveExpiry(t *testing.T) {
+	session, err := parseSessionBoundary("21:00", "UTC")
+	if err != nil {
+		t.Fatalf("parse session: %v", err)
+	}
+	now := time.Date(2025, 8, 15, 14, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		tif      pb.TimeInForce
+		expireAt *timestamppb.Timestamp
+		want     time.Time
+		wantErr  bool
+	}{
+		{name: "unspecified never expires", tif: pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED},
+		{name: "gtc never expires", tif: pb.TimeInForce_GTC},
+		{name: "ioc expires immediately", tif: pb.TimeInForce_IOC, want: now},
+		{name: "fok expires immediately", tif: pb.TimeInForce_FOK, want: now},
+		{name: "gtd uses expire_at", tif: pb.TimeInForce_GTD, expireAt: timestamppb.New(now.Add(time.Hour)), want: now.Add(time.Hour)},
+		{name: "gtd requires expire_at", tif: pb.TimeInForce_GTD, wantErr: true},
+		{name: "gtd rejects past expire_at", tif: pb.TimeInForce_GTD, expireAt: timestamppb.New(now.Add(-time.Minute)), wantErr: true},
+		{name: "day expires at session close", tif: pb.TimeInForce_DAY, want: time.Date(2025, 8, 15, 21, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		got, err := resolveExpiry(tc.tif, tc.expireAt, now, session)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", tc.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSessionBoundaryRollsToNextDay(t *testing.T) {
+	session, err := parseSessionBoundary("21:00", "UTC")
+	if err != nil {
+		t.Fatalf("parse session: %v", err)
+	}
+	atClose := time.Date(2025, 8, 15, 21, 0, 0, 0, time.UTC)
+	want := time.Date(2025, 8, 16, 21, 0, 0, 0, time.UTC)
+	if got := session.next(atClose); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := session.next(atClose.Add(time.Second)); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, err := parseSessionBoundary("9pm", "UTC"); err == nil {
+		t.Error("expected error for malformed close time")
+	}
+}
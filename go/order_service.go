@@ -2,35 +2,76 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	pb "aetherion/gen"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 func decimalValueToNumeric(dv *pb.DecimalValue) string {
 	if dv == nil {
 		return "0"
 	}
-	return fmt.Sprintf("%d.%09d", dv.Units, abs(dv.Nanos))
+	if dv.Units < 0 || dv.Nanos < 0 {
+		// Values between -1 and 0 carry their sign in nanos alone
+		return fmt.Sprintf("-%d.%09d", -dv.Units, abs(dv.Nanos))
+	}
+	return fmt.Sprintf("%d.%09d", dv.Units, dv.Nanos)
 }
 
 func numericValueToDecimal(n string) *pb.DecimalValue {
 	if n == "" {
 		return nil
 	}
-	var units int64
-	var nanos int32
-	fmt.Sscanf(n, "%d.%09d", &units, &nanos)
+	// Postgres renders NUMERIC(20, 8) with 8 fractional digits, so the fraction is
+	// right-padded to nanos rather than read as a 9-digit integer.
+	whole, frac, _ := strings.Cut(n, ".")
+	units, _ := strconv.ParseInt(whole, 10, 64)
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	nanos, _ := strconv.ParseInt((frac + "000000000")[:9], 10, 32)
+	if strings.HasPrefix(whole, "-") {
+		nanos = -nanos // nanos has the sign of the value, as floatToDecimal makes it
+	}
 	return &pb.DecimalValue{
 		Units: units,
-		Nanos: abs(nanos),
+		Nanos: int32(nanos),
+	}
+}
+
+// decimalToFloat converts a DecimalValue to float64 for arithmetic where float
+// precision is acceptable (averages, slippage, slice sizing).
+func decimalToFloat(dv *pb.DecimalValue) float64 {
+	if dv == nil {
+		return 0
 	}
+	f := float64(dv.Units)
+	if dv.Units < 0 {
+		return f - float64(abs(dv.Nanos))/1e9
+	}
+	return f + float64(dv.Nanos)/1e9
+}
+
+// floatToDecimal converts a float64 to a DecimalValue rounded to 8 decimal places,
+// the precision of the NUMERIC(20, 8) columns it is stored in.
+func floatToDecimal(f float64) *pb.DecimalValue {
+	scaled := int64(math.Round(f * 1e8))
+	units := scaled / 1e8
+	nanos := int32(scaled%1e8) * 10
+	return &pb.DecimalValue{Units: units, Nanos: nanos}
 }
 
 func abs(n int32) int32 {
@@ -40,17 +81,72 @@ func abs(n int32) int32 {
 	return n
 }
 
+// OrderEvent is journaled and published on the EventBus (as EventOrderUpdate) whenever
+// an order changes status or receives a fill.
+type OrderEvent struct {
+	Order *pb.Order
+	Fill  *pb.Trade // set when the event reports an execution
+	Ts    time.Time
+}
+
+// publishOrderEvent journals an order event before broadcasting it on the EventBus.
+// The bus is lossy, so the journal is written here rather than from a subscription:
+// a fill missing from the journal could never be replayed to a reconnecting stream.
+func publishOrderEvent(ctx context.Context, bus *EventBus, journal *orderJournal, oe OrderEvent) {
+	if oe.Order == nil {
+		return
+	}
+	if oe.Ts.IsZero() {
+		oe.Ts = time.Now()
+	}
+	journal.record(ctx, oe)
+	if bus != nil {
+		bus.Publish(Event{Type: EventOrderUpdate, Data: oe})
+	}
+}
+
 type OrderServiceServer struct {
 	pb.OrderServiceServer
-	mu       sync.RWMutex
-	dbclient *DBService
+	mu         sync.RWMutex
+	dbclient   *DBService
+	bus        *EventBus
+	session    sessionBoundary // cutoff for DAY orders
+	algos      *algoEngine     // parent-order algos (injected)
+	journal    *orderJournal   // sequenced order updates for streaming (injected)
+	venues     *venueRouter    // paper or live execution (injected)
+	reconciler *reconciler     // drift checks against the live venue (injected)
+	bots       botLookup       // bot ownership for authorization (injected)
 }
 
-func newOrderServiceServer(dbclient *DBService) *OrderServiceServer {
-	return &OrderServiceServer{dbclient: dbclient}
+func newOrderServiceServer(dbclient *DBService, bus *EventBus, session sessionBoundary) *OrderServiceServer {
+	return &OrderServiceServer{dbclient: dbclient, bus: bus, session: session}
 }
 
 func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
+	// A retried submit returns the order created by the first attempt.
+	if req.ClientOrderId != "" {
+		existing, err := s.dbclient.GetOrderByClientOrderID(ctx, req.BotId, req.ClientOrderId)
+		if err == nil {
+			return retriedOrder(existing, req)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	expireAt, err := resolveExpiry(req.TimeInForce, req.ExpireAt, now, s.session)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tif := req.TimeInForce
+	if tif == pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED {
+		tif = pb.TimeInForce_GTC
+	}
+
 	order := &pb.Order{
 		Id:                uuid.New().String(),
 		BotId:             req.BotId,
@@ -62,42 +158,54 @@ func (s *OrderServiceServer) CreateOrder(ctx context.Context, req *pb.CreateOrde
 		QuantityFilled:    numericValueToDecimal("0"),
 		LimitPrice:        req.LimitPrice,
 		StopPrice:         req.StopPrice,
-		CreatedAt: &timestamppb.Timestamp{
-			Seconds: time.Now().Unix(),
-			Nanos:   int32(time.Now().Nanosecond()),
-		},
-		UpdatedAt: &timestamppb.Timestamp{
-			Seconds: time.Now().Unix(),
-			Nanos:   int32(time.Now().Nanosecond()),
-		},
-		Trades: []*pb.Trade{},
-	}
-
-	// Convert DecimalValue fields to string for DB
-	quantityRequestedStr := decimalValueToNumeric(order.QuantityRequested)
-	quantityFilledStr := decimalValueToNumeric(order.QuantityFilled)
-	limitPriceStr := decimalValueToNumeric(order.LimitPrice)
-	stopPriceStr := decimalValueToNumeric(order.StopPrice)
-
-	// Store in dbclient, pass numeric strings
-	orderID, err := s.dbclient.CreateOrder(
-		ctx,
-		order.Id,
-		order.BotId,
-		order.Symbol,
-		order.Side.String(),
-		order.Type.String(),
-		order.Status,
-		quantityRequestedStr,
-		quantityFilledStr,
-		limitPriceStr,
-		stopPriceStr,
-	)
+		TimeInForce:       tif,
+		ClientOrderId:     req.ClientOrderId,
+		CreatedAt:         timestamppb.New(now),
+		UpdatedAt:         timestamppb.New(now),
+		Trades:            []*pb.Trade{},
+	}
+	if !expireAt.IsZero() {
+		order.ExpireAt = timestamppb.New(expireAt)
+	}
+
+	orderID, err := s.dbclient.CreateOrder(ctx, order)
+	if errors.Is(err, errDuplicateClientOrderID) {
+		// Lost a race with a concurrent retry of the same submit.
+		existing, err := s.dbclient.GetOrderByClientOrderID(ctx, req.BotId, req.ClientOrderId)
+		if err != nil {
+			return nil, err
+		}
+		return retriedOrder(existing, req)
+	}
 	if err != nil {
 		return nil, err
 	}
 	order.Id = orderID
-	return order, nil
+	publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: order})
+	return s.submitToVenue(ctx, order)
+}
+
+// retriedOrder returns the order a submit with a known client_order_id created, after
+// checking that req repeats it. A client_order_id reused for a different order is a
+// client bug, so it fails with AlreadyExists instead of looking like a success.
+func retriedOrder(existing *pb.Order, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	tif := req.TimeInForce
+	if tif == pb.TimeInForce_TIME_IN_FORCE_UNSPECIFIED {
+		tif = pb.TimeInForce_GTC
+	}
+	if existing.Symbol != req.Symbol || existing.Side != req.Side || existing.Type != req.Type || existing.TimeInForce != tif ||
+		!sameDecimal(existing.QuantityRequested, req.Quantity) ||
+		!sameDecimal(existing.LimitPrice, req.LimitPrice) ||
+		!sameDecimal(existing.StopPrice, req.StopPrice) {
+		return nil, status.Errorf(codes.AlreadyExists, "client_order_id %q was already used for a different order", req.ClientOrderId)
+	}
+	return existing, nil
+}
+
+// sameDecimal compares decimals at the 8 places they are stored with; nil counts as zero.
+func sameDecimal(a, b *pb.DecimalValue) bool {
+	x, y := floatToDecimal(decimalToFloat(a)), floatToDecimal(decimalToFloat(b))
+	return x.Units == y.Units && x.Nanos == y.Nanos
 }
 
 func (s *OrderServiceServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
@@ -109,39 +217,70 @@ func (s *OrderServiceServer) CancelOrder(ctx context.Context, req *pb.CancelOrde
 	if err != nil {
 		return nil, err
 	}
-	// Update status to CANCELED
-	order.Status = pb.OrderStatus_CANCELED
-	order.UpdatedAt = &timestamppb.Timestamp{
-		Seconds: time.Now().Unix(),
-		Nanos:   int32(time.Now().Nanosecond()),
+	if err := authorizeBot(ctx, s.bots, order.BotId); err != nil {
+		return nil, err
+	}
+	if !isOpenOrderStatus(order.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order is already %s", order.Status)
+	}
+	// Cancel at the venue first so an order that is still working there is not
+	// reported as canceled.
+	if err := s.cancelAtVenue(ctx, order); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "cancel at %s failed: %v", order.Venue, err)
 	}
 
-	// // Persist the update
-	// err = s.dbclient.UpdateOrderStatus(ctx, order.Id, pb.OrderStatus_CANCELED)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
+	// Persist the update
+	order, err = s.dbclient.UpdateOrderStatus(ctx, order.Id, pb.OrderStatus_CANCELED)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "order is no longer open")
+		}
+		return nil, err
+	}
+	publishOrderEvent(ctx, s.bus, s.journal, OrderEvent{Order: order})
 	return order, nil
 }
 
+// isOpenOrderStatus reports whether an order in this status can still fill, be canceled or expire.
+func isOpenOrderStatus(st pb.OrderStatus) bool {
+	switch st {
+	case pb.OrderStatus_NEW, pb.OrderStatus_SUBMITTED, pb.OrderStatus_PARTIALLY_FILLED:
+		return true
+	}
+	return false
+}
+
 func (s *OrderServiceServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	if req.OrderId == "" && req.ClientOrderId != "" {
+		if req.BotId == "" {
+			return nil, status.Error(codes.InvalidArgument, "bot_id is required to look up by client_order_id")
+		}
+		if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+			return nil, err
+		}
+		order, err := s.dbclient.GetOrderByClientOrderID(ctx, req.BotId, req.ClientOrderId)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return order, err
+	}
 	order, err := s.dbclient.GetOrder(ctx, req.OrderId)
 	if err != nil {
 		return nil, err
 	}
+	if err := authorizeBot(ctx, s.bots, order.BotId); err != nil {
+		return nil, err
+	}
 	return order, nil
 }
 
 func (s *OrderServiceServer) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return nil, err
+	}
 	orders, err := s.dbclient.ListOrders(ctx, req.BotId, req.Limit, req.Offset)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.ListOrdersResponse{Orders: orders}, nil
 }
-
-func (s *OrderServiceServer) GetTradeHistory(ctx context.Context, req *pb.TradeHistoryRequest) (*pb.TradeHistoryResponse, error) {
-	// TODO: Return trades for user/bot
-	return &pb.TradeHistoryResponse{}, nil
-}
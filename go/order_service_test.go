@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetriedOrderMustMatch(t *testing.T) {
+	// As stored: NUMERIC(20, 8) and an unspecified time in force saved as GTC
+	existing := &pb.Order{
+		Id: "order-1", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Type: pb.OrderType_LIMIT, TimeInForce: pb.TimeInForce_GTC,
+		QuantityRequested: floatToDecimal(0.5), LimitPrice: floatToDecimal(100), ClientOrderId: "c-1",
+	}
+	retry := func() *pb.CreateOrderRequest {
+		return &pb.CreateOrderRequest{
+			BotId: "bot", ClientOrderId: "c-1", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Type: pb.OrderType_LIMIT,
+			Quantity: &pb.DecimalValue{Units: 0, Nanos: 500000000}, LimitPrice: &pb.DecimalValue{Units: 100},
+		}
+	}
+
+	got, err := retriedOrder(existing, retry())
+	if err != nil || got != existing {
+		t.Fatalf("expected a retry to return the original order, got %v, %v", got, err)
+	}
+
+	cases := map[string]func(*pb.CreateOrderRequest){
+		"symbol":        func(r *pb.CreateOrderRequest) { r.Symbol = "ETH-USD" },
+		"side":          func(r *pb.CreateOrderRequest) { r.Side = pb.OrderSide_SELL },
+		"type":          func(r *pb.CreateOrderRequest) { r.Type = pb.OrderType_MARKET },
+		"quantity":      func(r *pb.CreateOrderRequest) { r.Quantity = floatToDecimal(0.6) },
+		"limit price":   func(r *pb.CreateOrderRequest) { r.LimitPrice = floatToDecimal(101) },
+		"stop price":    func(r *pb.CreateOrderRequest) { r.StopPrice = floatToDecimal(90) },
+		"time in force": func(r *pb.CreateOrderRequest) { r.TimeInForce = pb.TimeInForce_IOC },
+	}
+	for name, change := range cases {
+		req := retry()
+		change(req)
+		if _, err := retriedOrder(existing, req); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("%s differs: expected AlreadyExists, got %v", name, err)
+		}
+	}
+}
+
+func TestRetriedTradeMustMatch(t *testing.T) {
+	existing := &pb.Trade{TradeId: "trade-1", Symbol: "BTC-USD", Side: "BUY", Quantity: 0.5, Price: 101, ClientOrderId: "c-1"}
+
+	// No price asks for the market price, which the original execution already used
+	for _, price := range []float64{0, 101} {
+		resp, err := retriedTrade(existing, &pb.TradeRequest{Symbol: "BTC-USD", Side: "BUY", Size: 0.5, Price: price, ClientOrderId: "c-1"})
+		if err != nil || resp.TradeId != "trade-1" || resp.ExecutedPrice != 101 {
+			t.Errorf("price %v: expected the original execution, got %v, %v", price, resp, err)
+		}
+	}
+	for _, req := range []*pb.TradeRequest{
+		{Symbol: "BTC-USD", Side: "SELL", Size: 0.5},
+		{Symbol: "BTC-USD", Side: "BUY", Size: 1},
+		{Symbol: "BTC-USD", Side: "BUY", Size: 0.5, Price: 99},
+	} {
+		if _, err := retriedTrade(existing, req); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("%v: expected AlreadyExists, got %v", req, err)
+		}
+	}
+}
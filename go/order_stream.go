@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderJournalReplayBatch bounds how many journaled updates are read per query during replay.
+const orderJournalReplayBatch = 500
+
+// orderJournal turns order events into sequenced OrderUpdates. Each update is persisted
+// to order_events so reconnecting streams can resume from the last sequence they saw,
+// then fanned out to live subscribers of the order's bot. Events are recorded by
+// publishOrderEvent where they happen rather than read from the EventBus, which drops
+// events when a subscriber falls behind.
+type orderJournal struct {
+	db        *DBService
+	retention time.Duration
+
+	mu     sync.Mutex
+	subs   map[int]*orderJournalSub
+	nextID int
+}
+
+type orderJournalSub struct {
+	botID string
+	ch    chan *pb.OrderUpdate
+}
+
+func newOrderJournal(db *DBService, retention time.Duration) *orderJournal {
+	return &orderJournal{db: db, retention: retention, subs: make(map[int]*orderJournalSub)}
+}
+
+// Run prunes updates older than the retention until ctx is canceled.
+func (j *orderJournal) Run(ctx context.Context) {
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-prune.C:
+			if j.retention > 0 {
+				if n, err := j.db.PruneOrderEvents(ctx, time.Now().Add(-j.retention)); err == nil && n > 0 {
+					log.Info().Int64("deleted", n).Msg("pruned order events")
+				}
+			}
+		}
+	}
+}
+
+// record journals an order event and delivers it to the bot's live streams. A nil
+// journal records nothing.
+func (j *orderJournal) record(ctx context.Context, oe OrderEvent) {
+	if j == nil {
+		return
+	}
+	update := &pb.OrderUpdate{
+		Type:      pb.OrderUpdateType_ORDER_UPDATE_STATUS,
+		Order:     oe.Order,
+		Fill:      oe.Fill,
+		EventTime: timestamppb.New(oe.Ts),
+	}
+	if oe.Fill != nil {
+		update.Type = pb.OrderUpdateType_ORDER_UPDATE_FILL
+	}
+	// An update that could not be journaled is still delivered live, with sequence 0
+	// marking it as not resumable.
+	if j.db != nil {
+		// The event has already happened, so journal it even if the caller's request ends
+		if seq, err := j.db.AppendOrderEvent(context.WithoutCancel(ctx), update); err == nil {
+			update.Sequence = seq
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for id, sub := range j.subs {
+		if sub.botID != update.Order.GetBotId() {
+			continue
+		}
+		select {
+		case sub.ch <- update:
+		default:
+			// The stream fell behind; end it so the client resumes from its last sequence
+			// instead of silently missing updates.
+			close(sub.ch)
+			delete(j.subs, id)
+		}
+	}
+}
+
+func (j *orderJournal) subscribe(botID string) (int, <-chan *pb.OrderUpdate) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	id := j.nextID
+	j.nextID++
+	sub := &orderJournalSub{botID: botID, ch: make(chan *pb.OrderUpdate, 256)}
+	j.subs[id] = sub
+	return id, sub.ch
+}
+
+func (j *orderJournal) unsubscribe(id int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if sub, ok := j.subs[id]; ok {
+		close(sub.ch)
+		delete(j.subs, id)
+	}
+}
+
+// StreamOrderUpdates replays journaled updates after from_sequence and then streams
+// status transitions and fills for the bot's orders as they happen.
+func (s *OrderServiceServer) StreamOrderUpdates(req *pb.StreamOrderUpdatesRequest, stream pb.OrderService_StreamOrderUpdatesServer) error {
+	if req.BotId == "" {
+		return status.Error(codes.InvalidArgument, "bot_id is required")
+	}
+	if s.journal == nil {
+		return status.Error(codes.Unavailable, "order stream not initialized")
+	}
+	ctx := stream.Context()
+	if err := authorizeBot(ctx, s.bots, req.BotId); err != nil {
+		return err
+	}
+
+	// Subscribe before replaying so nothing published in between is lost; the
+	// overlap is dropped by sequence below.
+	id, live := s.journal.subscribe(req.BotId)
+	defer s.journal.unsubscribe(id)
+
+	last := req.FromSequence
+	if req.FromSequence > 0 {
+		for {
+			batch, err := s.dbclient.ListOrderEventsAfter(ctx, req.BotId, last, orderJournalReplayBatch)
+			if err != nil {
+				return status.Errorf(codes.Internal, "replay failed: %v", err)
+			}
+			for _, update := range batch {
+				if err := stream.Send(update); err != nil {
+					return err
+				}
+				last = update.Sequence
+			}
+			if len(batch) < orderJournalReplayBatch {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-live:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "order stream fell behind; reconnect with from_sequence set to the last sequence received")
+			}
+			if update.Sequence != 0 && update.Sequence <= last {
+				continue
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			if update.Sequence != 0 {
+				last = update.Sequence
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+)
+
+func TestOrderJournalFansOutByBot(t *testing.T) {
+	j := newOrderJournal(nil, 0)
+	id, ch := j.subscribe("bot-a")
+	defer j.unsubscribe(id)
+
+	j.record(context.Background(), OrderEvent{Order: &pb.Order{Id: "o1", BotId: "bot-b"}, Ts: time.Now()})
+	j.record(context.Background(), OrderEvent{Order: &pb.Order{Id: "o2", BotId: "bot-a"}, Fill: &pb.Trade{TradeId: "t1"}, Ts: time.Now()})
+
+	select {
+	case update := <-ch:
+		if update.Order.Id != "o2" {
+			t.Fatalf("expected update for o2, got %s", update.Order.Id)
+		}
+		if update.Type != pb.OrderUpdateType_ORDER_UPDATE_FILL {
+			t.Errorf("expected fill update, got %v", update.Type)
+		}
+	default:
+		t.Fatal("expected an update for bot-a")
+	}
+	select {
+	case update := <-ch:
+		t.Fatalf("unexpected extra update %v", update)
+	default:
+	}
+}
+
+func TestOrderJournalDropsSlowSubscriber(t *testing.T) {
+	j := newOrderJournal(nil, 0)
+	id, ch := j.subscribe("bot-a")
+	defer j.unsubscribe(id)
+
+	for i := 0; i < 257; i++ {
+		j.record(context.Background(), OrderEvent{Order: &pb.Order{Id: "o", BotId: "bot-a"}, Ts: time.Now()})
+	}
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != 256 {
+		t.Errorf("expected the buffered 256 updates before close, got %d", n)
+	}
+}
+
+func TestPublishOrderEventRecordsWithoutTheBus(t *testing.T) {
+	j := newOrderJournal(nil, 0)
+	id, ch := j.subscribe("bot-a")
+	defer j.unsubscribe(id)
+
+	// A full bus subscription drops the event, but the journal still records it
+	bus := NewEventBus()
+	subID, _ := bus.Subscribe(0)
+	defer bus.Unsubscribe(subID)
+	publishOrderEvent(context.Background(), bus, j, OrderEvent{Order: &pb.Order{Id: "o1", BotId: "bot-a"}, Fill: &pb.Trade{TradeId: "t1"}})
+
+	select {
+	case update := <-ch:
+		if update.Fill.GetTradeId() != "t1" || update.EventTime.AsTime().IsZero() {
+			t.Errorf("unexpected update %v", update)
+		}
+	default:
+		t.Fatal("expected the fill to be journaled")
+	}
+	publishOrderEvent(context.Background(), nil, nil, OrderEvent{Order: &pb.Order{Id: "o2"}})
+}
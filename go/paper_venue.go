@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// paperVenue simulates execution against the market data feed. Market orders fill in
+// full at the current price; limit and stop orders rest until a price tick makes them
+// marketable. Fills are applied through apply, which OrderService provides.
+type paperVenue struct {
+	price priceSource
+	apply func(ctx context.Context, fill VenueFill)
+
+	mu      sync.Mutex
+	resting map[string]*pb.Order // venue order id -> order
+	fills   []VenueFill          // recent executions, served by Fills
+}
+
+// paperFillHistory bounds how many executions the simulator keeps for Fills.
+const paperFillHistory = 10000
+
+func newPaperVenue(price priceSource) *paperVenue {
+	return &paperVenue{price: price, resting: make(map[string]*pb.Order)}
+}
+
+func (v *paperVenue) Name() string { return "paper" }
+
+func (v *paperVenue) PlaceOrder(ctx context.Context, order *pb.Order) (*VenueOrderAck, error) {
+	ack := &VenueOrderAck{VenueOrderID: "paper-" + uuid.New().String(), Status: pb.OrderStatus_SUBMITTED}
+	last, err := v.price(ctx, order.Symbol)
+	if err != nil || last <= 0 {
+		if order.Type == pb.OrderType_MARKET {
+			return nil, fmt.Errorf("no price for %s", order.Symbol)
+		}
+		last = 0
+	}
+
+	if px, ok := paperExecutionPrice(order, last); ok {
+		fill := v.record(order, ack.VenueOrderID, px)
+		ack.Fills = append(ack.Fills, fill)
+		return ack, nil
+	}
+	// Non-marketable IOC and FOK orders are not worked; the expirer closes them.
+	if order.TimeInForce == pb.TimeInForce_IOC || order.TimeInForce == pb.TimeInForce_FOK {
+		return ack, nil
+	}
+	resting := proto.Clone(order).(*pb.Order)
+	resting.Venue = v.Name()
+	resting.VenueOrderId = ack.VenueOrderID
+	v.mu.Lock()
+	v.resting[ack.VenueOrderID] = resting
+	v.mu.Unlock()
+	return ack, nil
+}
+
+func (v *paperVenue) CancelOrder(ctx context.Context, order *pb.Order) error {
+	v.mu.Lock()
+	delete(v.resting, order.VenueOrderId)
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *paperVenue) Fills(ctx context.Context, since time.Time) ([]VenueFill, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var out []VenueFill
+	for _, f := range v.fills {
+		if !f.Ts.Before(since) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// Run matches resting orders against price ticks until ctx is canceled. It also drops
+// resting orders that were canceled or expired through OrderService.
+func (v *paperVenue) Run(ctx context.Context, bus *EventBus) {
+	id, ch := bus.Subscribe(1024)
+	defer bus.Unsubscribe(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			switch evt.Type {
+			case EventPriceTick:
+				tick := evt.Data.(PriceTick)
+				for _, fill := range v.match(tick) {
+					if v.apply != nil {
+						v.apply(ctx, fill)
+					}
+				}
+			case EventOrderUpdate:
+				order := evt.Data.(OrderEvent).Order
+				if order.GetVenue() == v.Name() && !isOpenOrderStatus(order.Status) {
+					v.mu.Lock()
+					delete(v.resting, order.VenueOrderId)
+					v.mu.Unlock()
+				}
+			}
+		}
+	}
+}
+
+// match fills every resting order on the tick's symbol that the tick price makes marketable.
+func (v *paperVenue) match(tick PriceTick) []VenueFill {
+	v.mu.Lock()
+	var triggered []*pb.Order
+	for venueID, order := range v.resting {
+		if order.Symbol != tick.Symbol {
+			continue
+		}
+		if _, ok := paperExecutionPrice(order, tick.Price); ok {
+			triggered = append(triggered, order)
+			delete(v.resting, venueID)
+		}
+	}
+	v.mu.Unlock()
+
+	fills := make([]VenueFill, 0, len(triggered))
+	for _, order := range triggered {
+		px, _ := paperExecutionPrice(order, tick.Price)
+		fills = append(fills, v.record(order, order.VenueOrderId, px))
+	}
+	return fills
+}
+
+// record creates a fill for the order's remaining quantity and keeps it for Fills.
+func (v *paperVenue) record(order *pb.Order, venueOrderID string, price float64) VenueFill {
+	fill := VenueFill{
+		Venue:        v.Name(),
+		TradeID:      uuid.New().String(),
+		VenueOrderID: venueOrderID,
+		OrderID:      order.Id,
+		Symbol:       order.Symbol,
+		Side:         order.Side,
+		Quantity:     decimalToFloat(order.QuantityRequested) - decimalToFloat(order.QuantityFilled),
+		Price:        price,
+		Liquidity:    "TAKER",
+		Ts:           time.Now(),
+	}
+	if order.Type == pb.OrderType_LIMIT && venueOrderID == order.VenueOrderId {
+		// Resting limit orders were on the book when the market came to them.
+		fill.Liquidity = "MAKER"
+	}
+	v.mu.Lock()
+	v.fills = append(v.fills, fill)
+	if len(v.fills) > paperFillHistory {
+		v.fills = v.fills[len(v.fills)-paperFillHistory:]
+	}
+	v.mu.Unlock()
+	log.Debug().Str("order_id", order.Id).Float64("qty", fill.Quantity).Float64("price", price).Msg("paper fill")
+	return fill
+}
+
+// paperExecutionPrice reports whether an order is marketable at the last price and,
+// if so, the price it fills at. Limit orders fill at their limit or better.
+func paperExecutionPrice(order *pb.Order, last float64) (float64, bool) {
+	if last <= 0 {
+		return 0, false
+	}
+	buy := order.Side == pb.OrderSide_BUY
+	limit := decimalToFloat(order.LimitPrice)
+	stop := decimalToFloat(order.StopPrice)
+	switch order.Type {
+	case pb.OrderType_MARKET:
+		return last, true
+	case pb.OrderType_LIMIT:
+		if (buy && last <= limit) || (!buy && last >= limit) {
+			return last, true
+		}
+	case pb.OrderType_STOP:
+		// A stop with a limit price is a stop-limit: it fills only while the limit is still marketable.
+		triggered := (buy && last >= stop) || (!buy && last <= stop)
+		if triggered && (limit == 0 || (buy && last <= limit) || (!buy && last >= limit)) {
+			return last, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VenueOpenOrder is an order the venue reports as open.
+type VenueOpenOrder struct {
+	Account       string // owning user id; empty for the platform account
+	VenueOrderID  string
+	ClientOrderID string // the internal order id for orders placed by this service
+	Symbol        string
+	Side          string
+	Filled        float64
+}
+
+// VenueBalance is an account's total holding of one currency at a venue.
+type VenueBalance struct {
+	Account  string
+	Currency string
+	Total    float64
+}
+
+// reconcilableVenue is a venue that can report the state the reconciler compares against.
+type reconcilableVenue interface {
+	ExecutionVenue
+	OpenOrders(ctx context.Context) ([]VenueOpenOrder, error)
+	Balances(ctx context.Context) ([]VenueBalance, error)
+}
+
+// reconcileMetrics is published at /debug/vars on the metrics listener.
+var reconcileMetrics = expvar.NewMap("reconciliation")
+
+// reconciler periodically compares open orders, fills and balances at a venue with
+// what DBService has recorded, and keeps the latest report for the RPCs.
+type reconciler struct {
+	venue       reconcilableVenue
+	db          *DBService
+	orders      *OrderServiceServer
+	interval    time.Duration
+	autoCorrect bool          // import missing fills on scheduled runs
+	lookback    time.Duration // how far back venue fills are compared
+	grace       time.Duration // orders and fills younger than this are still in flight
+
+	runMu sync.Mutex // one run at a time
+	mu    sync.RWMutex
+	last  *pb.ReconciliationReport
+}
+
+func newReconciler(venue reconcilableVenue, db *DBService, orders *OrderServiceServer, interval time.Duration, autoCorrect bool, lookback time.Duration) *reconciler {
+	return &reconciler{
+		venue:       venue,
+		db:          db,
+		orders:      orders,
+		interval:    interval,
+		autoCorrect: autoCorrect,
+		lookback:    lookback,
+		grace:       time.Minute,
+	}
+}
+
+func (r *reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.run(ctx, r.autoCorrect)
+		}
+	}
+}
+
+// Last returns the most recent report, or nil before the first run.
+func (r *reconciler) Last() *pb.ReconciliationReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.last
+}
+
+func (r *reconciler) run(ctx context.Context, autoCorrect bool) *pb.ReconciliationReport {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	now := time.Now()
+	report := &pb.ReconciliationReport{RunAt: timestamppb.New(now), Venue: r.venue.Name()}
+	err := errors.Join(
+		r.reconcileOrders(ctx, report, now),
+		r.reconcileFills(ctx, report, now, autoCorrect),
+		r.reconcileBalances(ctx, report),
+	)
+	if err != nil {
+		report.Error = err.Error()
+		reconcileMetrics.Add("errors", 1)
+		log.Warn().Err(err).Str("venue", report.Venue).Msg("reconciliation incomplete")
+	}
+
+	reconcileMetrics.Add("runs", 1)
+	reconcileMetrics.Add("fills_imported", int64(report.FillsImported))
+	for _, d := range report.Discrepancies {
+		reconcileMetrics.Add("discrepancies."+d.Type.String(), 1)
+	}
+	last := new(expvar.Int)
+	last.Set(now.Unix())
+	reconcileMetrics.Set("last_run_unix", last)
+	if n := len(report.Discrepancies); n > 0 {
+		log.Warn().Int("discrepancies", n).Int32("fills_imported", report.FillsImported).Str("venue", report.Venue).Msg("reconciliation found drift")
+	}
+
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+	return report
+}
+
+func (r *reconciler) reconcileOrders(ctx context.Context, report *pb.ReconciliationReport, now time.Time) error {
+	internal, err := r.db.ListOpenVenueOrders(ctx, r.venue.Name())
+	if err != nil {
+		return err
+	}
+	venueOpen, err := r.venue.OpenOrders(ctx)
+	if err != nil {
+		return fmt.Errorf("venue open orders: %w", err)
+	}
+	missing, unmatched := diffOpenOrders(internal, venueOpen, now.Add(-r.grace))
+	for _, o := range missing {
+		report.Discrepancies = append(report.Discrepancies, &pb.Discrepancy{
+			Type:         pb.DiscrepancyType_ORDER_MISSING_AT_VENUE,
+			Venue:        report.Venue,
+			OrderId:      o.Id,
+			VenueOrderId: o.VenueOrderId,
+			Symbol:       o.Symbol,
+			Detail:       fmt.Sprintf("internal status %s", o.Status),
+		})
+	}
+	for _, vo := range unmatched {
+		d := &pb.Discrepancy{
+			Type:         pb.DiscrepancyType_ORDER_UNKNOWN_INTERNALLY,
+			Venue:        report.Venue,
+			Account:      vo.Account,
+			VenueOrderId: vo.VenueOrderID,
+			Symbol:       vo.Symbol,
+		}
+		if order, err := r.db.GetOrderByVenueOrderID(ctx, report.Venue, vo.VenueOrderID); err == nil {
+			d.Type = pb.DiscrepancyType_ORDER_CLOSED_INTERNALLY
+			d.OrderId = order.Id
+			d.Detail = fmt.Sprintf("internal status %s", order.Status)
+		}
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+	return nil
+}
+
+// diffOpenOrders matches internal open orders with the venue's by venue order id, or by
+// client order id for orders whose acknowledgement was never recorded. Internal orders
+// updated after cutoff are skipped since the venue may not reflect them yet.
+func diffOpenOrders(internal []*pb.Order, venueOpen []VenueOpenOrder, cutoff time.Time) (missing []*pb.Order, unmatched []VenueOpenOrder) {
+	byVenueID := make(map[string]bool, len(venueOpen))
+	byClientID := make(map[string]bool, len(venueOpen))
+	for _, vo := range venueOpen {
+		byVenueID[vo.VenueOrderID] = true
+		if vo.ClientOrderID != "" {
+			byClientID[vo.ClientOrderID] = true
+		}
+	}
+	known := make(map[string]bool, len(internal))
+	for _, o := range internal {
+		if o.VenueOrderId != "" {
+			known[o.VenueOrderId] = true
+		}
+		known[o.Id] = true
+		if byVenueID[o.VenueOrderId] || byClientID[o.Id] {
+			continue
+		}
+		if o.UpdatedAt != nil && o.UpdatedAt.AsTime().After(cutoff) {
+			continue
+		}
+		missing = append(missing, o)
+	}
+	for _, vo := range venueOpen {
+		if known[vo.VenueOrderID] || (vo.ClientOrderID != "" && known[vo.ClientOrderID]) {
+			continue
+		}
+		unmatched = append(unmatched, vo)
+	}
+	return missing, unmatched
+}
+
+func (r *reconciler) reconcileFills(ctx context.Context, report *pb.ReconciliationReport, now time.Time, autoCorrect bool) error {
+	fills, err := r.venue.Fills(ctx, now.Add(-r.lookback))
+	if err != nil {
+		return fmt.Errorf("venue fills: %w", err)
+	}
+	cutoff := now.Add(-r.grace)
+	for _, fill := range fills {
+		if fill.Ts.After(cutoff) {
+			continue // the fill poller has not had a chance to import it yet
+		}
+		exists, err := r.db.TradeExists(ctx, fillTradeID(fill.Venue, fill.TradeID))
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		// Trades made outside the platform, e.g. by hand on the venue, have no order here
+		if _, err := r.orders.orderForFill(ctx, fill); errors.Is(err, pgx.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		d := &pb.Discrepancy{
+			Type:         pb.DiscrepancyType_FILL_MISSING,
+			Venue:        report.Venue,
+			OrderId:      fill.OrderID,
+			VenueOrderId: fill.VenueOrderID,
+			Symbol:       fill.Symbol,
+			Detail:       fmt.Sprintf("venue trade %s: %s %g @ %g", fill.TradeID, fill.Side, fill.Quantity, fill.Price),
+		}
+		if autoCorrect {
+			if _, _, err := r.orders.applyFill(ctx, fill); err != nil {
+				d.Detail += "; import failed: " + err.Error()
+			} else {
+				d.Corrected = true
+				report.FillsImported++
+			}
+		}
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+	return nil
+}
+
+func (r *reconciler) reconcileBalances(ctx context.Context, report *pb.ReconciliationReport) error {
+	balances, err := r.venue.Balances(ctx)
+	if err != nil {
+		return fmt.Errorf("venue balances: %w", err)
+	}
+	positions, err := r.db.ListLivePositions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range balanceShortfalls(positions, balances) {
+		d.Venue = report.Venue
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+	return nil
+}
+
+// balanceShortfalls reports currencies where live bots' net positions add up to more
+// than the venue holds. Holdings above the bots' positions are not drift: the account
+// may hold funds no bot traded. When the venue reports the platform account, every
+// user's positions count against it; otherwise each user is compared with their own
+// account, and users without a reported account are skipped.
+func balanceShortfalls(positions []livePosition, balances []VenueBalance) []*pb.Discrepancy {
+	type key struct{ account, currency string }
+	held := make(map[key]float64)
+	accounts := make(map[string]bool)
+	for _, b := range balances {
+		held[key{b.Account, b.Currency}] += b.Total
+		accounts[b.Account] = true
+	}
+	internal := make(map[key]float64)
+	for _, p := range positions {
+		account := p.UserID
+		if accounts[""] {
+			account = ""
+		} else if !accounts[account] {
+			continue
+		}
+		base, _, _ := strings.Cut(p.Symbol, "-")
+		internal[key{account, base}] += p.Quantity
+	}
+	var out []*pb.Discrepancy
+	for k, qty := range internal {
+		if qty <= quantityEpsilon || qty <= held[k]+quantityEpsilon {
+			continue
+		}
+		out = append(out, &pb.Discrepancy{
+			Type:    pb.DiscrepancyType_BALANCE_SHORTFALL,
+			Account: k.account,
+			Symbol:  k.currency,
+			Detail:  fmt.Sprintf("live bots hold %g, venue balance is %g", qty, held[k]),
+		})
+	}
+	return out
+}
+
+// GetReconciliationReport returns the latest scheduled or on-demand reconciliation.
+func (s *OrderServiceServer) GetReconciliationReport(ctx context.Context, _ *pb.Empty) (*pb.ReconciliationReport, error) {
+	if s.reconciler == nil {
+		return nil, status.Error(codes.Unavailable, "reconciliation requires live trading")
+	}
+	report := s.reconciler.Last()
+	if report == nil {
+		return nil, status.Error(codes.NotFound, "no reconciliation has run yet")
+	}
+	return report, nil
+}
+
+// RunReconciliation reconciles now and returns the report.
+func (s *OrderServiceServer) RunReconciliation(ctx context.Context, req *pb.RunReconciliationRequest) (*pb.ReconciliationReport, error) {
+	if s.reconciler == nil {
+		return nil, status.Error(codes.Unavailable, "reconciliation requires live trading")
+	}
+	return s.reconciler.run(ctx, req.AutoCorrect), nil
+}
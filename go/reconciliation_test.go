@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiffOpenOrders(t *testing.T) {
+	now := time.Now()
+	old := timestamppb.New(now.Add(-time.Hour))
+	internal := []*pb.Order{
+		{Id: "matched", VenueOrderId: "v1", UpdatedAt: old},
+		{Id: "by-client-id", UpdatedAt: old},
+		{Id: "gone", VenueOrderId: "v3", UpdatedAt: old},
+		{Id: "in-flight", VenueOrderId: "v4", UpdatedAt: timestamppb.New(now)},
+	}
+	venueOpen := []VenueOpenOrder{
+		{VenueOrderID: "v1"},
+		{VenueOrderID: "v2", ClientOrderID: "by-client-id"},
+		{VenueOrderID: "v9", ClientOrderID: "someone-else"},
+	}
+	missing, unmatched := diffOpenOrders(internal, venueOpen, now.Add(-time.Minute))
+	if len(missing) != 1 || missing[0].Id != "gone" {
+		t.Errorf("expected only 'gone' missing at venue, got %v", missing)
+	}
+	if len(unmatched) != 1 || unmatched[0].VenueOrderID != "v9" {
+		t.Errorf("expected only v9 unmatched, got %v", unmatched)
+	}
+}
+
+func TestBalanceShortfalls(t *testing.T) {
+	positions := []livePosition{
+		{UserID: "u1", Symbol: "BTC-USD", Quantity: 2},
+		{UserID: "u1", Symbol: "ETH-USD", Quantity: 1},
+		{UserID: "u2", Symbol: "BTC-USD", Quantity: 5}, // no account reported
+	}
+	balances := []VenueBalance{
+		{Account: "u1", Currency: "BTC", Total: 1.5},
+		{Account: "u1", Currency: "ETH", Total: 3},
+	}
+	got := balanceShortfalls(positions, balances)
+	if len(got) != 1 || got[0].Account != "u1" || got[0].Symbol != "BTC" {
+		t.Fatalf("expected one BTC shortfall for u1, got %v", got)
+	}
+
+	// The platform account covers every user's positions.
+	got = balanceShortfalls(positions, []VenueBalance{{Currency: "BTC", Total: 6}, {Currency: "ETH", Total: 1}})
+	if len(got) != 1 || got[0].Symbol != "BTC" || got[0].Account != "" {
+		t.Fatalf("expected a platform BTC shortfall (7 > 6), got %v", got)
+	}
+}
+
+// fillsVenue reports fixed fills and nothing else.
+type fillsVenue struct{ fills []VenueFill }
+
+func (v *fillsVenue) Name() string { return "coinbase" }
+func (v *fillsVenue) PlaceOrder(context.Context, *pb.Order) (*VenueOrderAck, error) {
+	return nil, nil
+}
+func (v *fillsVenue) CancelOrder(context.Context, *pb.Order) error { return nil }
+func (v *fillsVenue) Fills(context.Context, time.Time) ([]VenueFill, error) {
+	return v.fills, nil
+}
+func (v *fillsVenue) OpenOrders(context.Context) ([]VenueOpenOrder, error) { return nil, nil }
+func (v *fillsVenue) Balances(context.Context) ([]VenueBalance, error)     { return nil, nil }
+
+func TestReconcileFillsIgnoresTradesWithoutAnOrder(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	u := &userAccount{Username: "reconciler", Email: "reconciler@example.com", PasswordHash: hashPassword("pw"), Role: roleUser}
+	if err := db.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	bot := &pb.Bot{BotId: uuid.NewString(), UserId: u.ID, Name: "bot", Symbol: "BTC-USD", Strategy: "MOMENTUM", AccountValue: 1000}
+	if err := db.CreateBot(ctx, bot); err != nil {
+		t.Fatal(err)
+	}
+	order := &pb.Order{
+		Id: uuid.NewString(), BotId: bot.BotId, Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Type: pb.OrderType_MARKET,
+		Status: pb.OrderStatus_SUBMITTED, QuantityRequested: floatToDecimal(0.5), QuantityFilled: floatToDecimal(0),
+	}
+	if _, err := db.CreateOrder(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	venue := &fillsVenue{fills: []VenueFill{
+		{Venue: "coinbase", TradeID: "ours", OrderID: order.Id, VenueOrderID: "v-1", Symbol: "BTC-USD", Side: pb.OrderSide_BUY, Quantity: 0.5, Price: 100, Ts: old},
+		{Venue: "coinbase", TradeID: "manual", VenueOrderID: "v-manual", Symbol: "BTC-USD", Side: pb.OrderSide_SELL, Quantity: 1, Price: 100, Ts: old},
+	}}
+	orders := newOrderServiceServer(db, NewEventBus(), sessionBoundary{})
+	r := newReconciler(venue, db, orders, time.Minute, false, 24*time.Hour)
+
+	report := &pb.ReconciliationReport{Venue: venue.Name()}
+	if err := r.reconcileFills(ctx, report, time.Now(), false); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Discrepancies) != 1 || report.Discrepancies[0].OrderId != order.Id {
+		t.Errorf("expected only the fill of our order to be missing, got %v", report.Discrepancies)
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAccessTokenTTL  = time.Hour
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// jtiDenylist holds revoked access tokens until they expire. Revocations made by this
+// instance apply immediately; others are picked up by Run.
+type jtiDenylist struct {
+	db     *DBService
+	mu     sync.RWMutex
+	denied map[string]time.Time // jti -> token expiry
+}
+
+func newJTIDenylist() *jtiDenylist {
+	return &jtiDenylist{denied: make(map[string]time.Time)}
+}
+
+func (d *jtiDenylist) isDenied(jti string) bool {
+	if d == nil || jti == "" {
+		return false
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.denied[jti]
+	return ok
+}
+
+func (d *jtiDenylist) add(jti string, exp time.Time) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.denied[jti] = exp
+	d.mu.Unlock()
+}
+
+// reload replaces the local denylist with the database's.
+func (d *jtiDenylist) reload(ctx context.Context) error {
+	if d.db == nil {
+		return nil
+	}
+	denied, err := d.db.ListDeniedJTIs(ctx)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.denied = denied
+	d.mu.Unlock()
+	return nil
+}
+
+// Run reloads the denylist and purges expired session state every interval.
+func (d *jtiDenylist) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.reload(ctx); err != nil {
+			log.Warn().Err(err).Msg("jti denylist: reload failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if d.db != nil {
+				if err := d.db.PurgeExpiredSessions(ctx); err != nil {
+					log.Warn().Err(err).Msg("jti denylist: purge failed")
+				}
+			}
+		}
+	}
+}
+
+// newRefreshToken returns an opaque refresh token and the hash stored for it.
+func newRefreshToken() (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// signAccessToken mints an access token. sid ties it to its refresh session, if any.
+func (a *authServer) signAccessToken(userID, role, jti, sid string, exp time.Time) (string, error) {
+	claims := jwt.MapClaims{"sub": userID, "exp": exp.Unix(), "role": role, "jti": jti}
+	if sid != "" {
+		claims["sid"] = sid
+	}
+	return a.keys.sign(claims)
+}
+
+// issueTokens fills resp with a new access token and, when sessions are stored, a
+// refresh token starting a new session.
+func (a *authServer) issueTokens(ctx context.Context, resp *pb.AuthResponse, userID, username, role string) error {
+	now := time.Now()
+	jti := uuid.New().String()
+	accessExp := now.Add(a.accessTTL)
+	var sid string
+	if a.sessions != nil {
+		refresh, hash, err := newRefreshToken()
+		if err != nil {
+			return status.Errorf(codes.Internal, "refresh token generation failed: %v", err)
+		}
+		t := &refreshToken{
+			ID:              uuid.New().String(),
+			FamilyID:        uuid.New().String(),
+			UserID:          userID,
+			Username:        username,
+			TokenHash:       hash,
+			AccessJTI:       jti,
+			AccessExpiresAt: accessExp,
+			ExpiresAt:       now.Add(a.refreshTTL),
+		}
+		if err := a.sessions.InsertRefreshToken(ctx, t); err != nil {
+			return status.Errorf(codes.Internal, "session creation failed: %v", err)
+		}
+		sid = t.FamilyID
+		resp.RefreshToken = refresh
+		resp.RefreshExpiresAtUnix = t.ExpiresAt.Unix()
+	}
+	signed, err := a.signAccessToken(userID, role, jti, sid, accessExp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "token signing failed: %v", err)
+	}
+	resp.Token = signed
+	resp.ExpiresAtUnix = accessExp.Unix()
+	resp.Role = role
+	return nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh
+// token. Each refresh token works once; presenting a used one ends its session.
+func (a *authServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.AuthResponse, error) {
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions are not configured")
+	}
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+	now := time.Now()
+	refresh, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "refresh token generation failed: %v", err)
+	}
+	next := &refreshToken{
+		ID:              uuid.New().String(),
+		TokenHash:       hash,
+		AccessJTI:       uuid.New().String(),
+		AccessExpiresAt: now.Add(a.accessTTL),
+		ExpiresAt:       now.Add(a.refreshTTL),
+	}
+	prev, err := a.sessions.RotateRefreshToken(ctx, hashRefreshToken(req.RefreshToken), next)
+	switch {
+	case errors.Is(err, errRefreshTokenReused):
+		log.Warn().Msg("refresh token reuse detected; session revoked")
+		a.reloadDenylist(ctx)
+		return nil, status.Error(codes.Unauthenticated, "refresh token reuse detected; session revoked")
+	case errors.Is(err, errRefreshTokenInvalid):
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "refresh failed: %v", err)
+	}
+
+	u, err := a.store.UserByID(ctx, prev.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "user no longer exists")
+	}
+	signed, err := a.signAccessToken(prev.UserID, u.Role, next.AccessJTI, prev.FamilyID, next.AccessExpiresAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "token signing failed: %v", err)
+	}
+	return &pb.AuthResponse{
+		Success:              true,
+		Message:              "refreshed",
+		Token:                signed,
+		ExpiresAtUnix:        next.AccessExpiresAt.Unix(),
+		Role:                 u.Role,
+		Username:             prev.Username,
+		RefreshToken:         refresh,
+		RefreshExpiresAtUnix: next.ExpiresAt.Unix(),
+	}, nil
+}
+
+// Logout revokes the calling access token and ends its session, plus the session of
+// refresh_token when given.
+func (a *authServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.StatusResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions are not configured")
+	}
+	if c.TokenID != "" {
+		if err := a.sessions.DenyJTI(ctx, c.TokenID, c.ExpiresAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "logout failed: %v", err)
+		}
+		a.denylist.add(c.TokenID, c.ExpiresAt)
+	}
+	sessions := []string{c.SessionID}
+	if req.RefreshToken != "" {
+		familyID, owner, err := a.sessions.RefreshTokenFamily(ctx, hashRefreshToken(req.RefreshToken))
+		if err == nil && owner == c.UserID {
+			sessions = append(sessions, familyID)
+		}
+	}
+	for _, sid := range sessions {
+		if sid == "" {
+			continue
+		}
+		if err := a.sessions.RevokeRefreshFamily(ctx, c.UserID, sid); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "logout failed: %v", err)
+		}
+	}
+	a.reloadDenylist(ctx)
+	return &pb.StatusResponse{Success: true, Message: "logged out"}, nil
+}
+
+// ListSessions returns the caller's active sessions.
+func (a *authServer) ListSessions(ctx context.Context, _ *pb.Empty) (*pb.ListSessionsResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions are not configured")
+	}
+	sessions, err := a.sessions.ListRefreshSessions(ctx, c.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list sessions failed: %v", err)
+	}
+	for _, s := range sessions {
+		s.Current = s.Id == c.SessionID
+	}
+	return &pb.ListSessionsResponse{Sessions: sessions}, nil
+}
+
+// RevokeSession ends one of the caller's sessions; admins may end anyone's.
+func (a *authServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.StatusResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if a.sessions == nil {
+		return nil, status.Error(codes.FailedPrecondition, "sessions are not configured")
+	}
+	if _, err := uuid.Parse(req.SessionId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "session_id must be a valid UUID")
+	}
+	owner := c.UserID
+	if c.IsAdmin() {
+		owner = ""
+	}
+	if err := a.sessions.RevokeRefreshFamily(ctx, owner, req.SessionId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "revoke session failed: %v", err)
+	}
+	a.reloadDenylist(ctx)
+	return &pb.StatusResponse{Success: true, Message: "session revoked", Id: req.SessionId}, nil
+}
+
+func (a *authServer) reloadDenylist(ctx context.Context) {
+	if a.denylist == nil {
+		return
+	}
+	if err := a.denylist.reload(ctx); err != nil {
+		log.Warn().Err(err).Msg("jti denylist: reload failed")
+	}
+}
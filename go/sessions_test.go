@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevokedAccessTokenIsRejected(t *testing.T) {
+	keys, err := newSigningKeys("0123456789abcdef0123456789abcdef", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &authServer{keys: keys}
+	exp := time.Now().Add(time.Hour)
+	token, err := a.signAccessToken("user-1", roleUser, "jti-1", "session-1", exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	denylist := newJTIDenylist()
+	c, err := callerFromMetadata(ctx, keys, denylist, nil)
+	if err != nil {
+		t.Fatalf("expected a valid token, got %v", err)
+	}
+	if c.UserID != "user-1" || c.TokenID != "jti-1" || c.SessionID != "session-1" || c.ExpiresAt.Unix() != exp.Unix() {
+		t.Errorf("unexpected caller %+v", c)
+	}
+
+	denylist.add("jti-1", exp)
+	if _, err := callerFromMetadata(ctx, keys, denylist, nil); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for a revoked token, got %v", err)
+	}
+}
+
+func TestRefreshTokenHash(t *testing.T) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) < 40 || string(hashRefreshToken(token)) != string(hash) {
+		t.Errorf("expected a long token whose hash matches, got %q", token)
+	}
+	other, _, _ := newRefreshToken()
+	if other == token {
+		t.Error("expected refresh tokens to be unique")
+	}
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	u := &userAccount{Username: "rotator", PasswordHash: hashPassword("pw"), Role: roleUser}
+	if err := db.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	newToken := func() (*refreshToken, []byte) {
+		_, hash, err := newRefreshToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &refreshToken{ID: uuid.NewString(), TokenHash: hash, AccessJTI: uuid.NewString(),
+			AccessExpiresAt: now.Add(time.Hour), ExpiresAt: now.Add(24 * time.Hour)}, hash
+	}
+
+	first, firstHash := newToken()
+	first.FamilyID, first.UserID, first.Username = uuid.NewString(), u.ID, u.Username
+	if err := db.InsertRefreshToken(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	second, secondHash := newToken()
+	prev, err := db.RotateRefreshToken(ctx, firstHash, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev.ID != first.ID || second.FamilyID != first.FamilyID || second.UserID != u.ID || second.Username != u.Username {
+		t.Errorf("expected the new token to join the session, got prev %+v next %+v", prev, second)
+	}
+	third, thirdHash := newToken()
+	if _, err := db.RotateRefreshToken(ctx, secondHash, third); err != nil {
+		t.Fatal(err)
+	}
+
+	// Presenting a rotated token again ends the whole session
+	replay, _ := newToken()
+	if _, err := db.RotateRefreshToken(ctx, firstHash, replay); !errors.Is(err, errRefreshTokenReused) {
+		t.Fatalf("expected errRefreshTokenReused, got %v", err)
+	}
+	if _, err := db.RotateRefreshToken(ctx, thirdHash, replay); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("expected the latest token to be revoked with its family, got %v", err)
+	}
+	denied, err := db.ListDeniedJTIs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tok := range []*refreshToken{first, second, third} {
+		if _, ok := denied[tok.AccessJTI]; !ok {
+			t.Errorf("expected access token %s of the revoked session to be denied", tok.AccessJTI)
+		}
+	}
+	if sessions, err := db.ListRefreshSessions(ctx, u.ID); err != nil || len(sessions) != 0 {
+		t.Errorf("expected no active sessions, got %v, %v", sessions, err)
+	}
+
+	unknown, _ := newToken()
+	if _, err := db.RotateRefreshToken(ctx, hashRefreshToken("nope"), unknown); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("expected errRefreshTokenInvalid for an unknown token, got %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesAccessTokens(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	keys, err := newSigningKeys("0123456789abcdef0123456789abcdef", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newAuthServer(keys, db)
+	a.sessions = db
+	a.denylist = newJTIDenylist()
+	a.denylist.db = db
+	if err := db.CreateUser(ctx, &userAccount{Username: "alice", PasswordHash: hashPassword("pw"), Role: roleUser}); err != nil {
+		t.Fatal(err)
+	}
+
+	login, err := a.Login(ctx, &pb.AuthRequest{Username: "alice", Password: "pw"})
+	if err != nil || !login.Success {
+		t.Fatalf("login failed: %v %v", login, err)
+	}
+	refreshed, err := a.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken || refreshed.Username != "alice" {
+		t.Errorf("expected a new refresh token for alice, got %v", refreshed)
+	}
+
+	_, err = a.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected reuse to be refused, got %v", err)
+	}
+	if _, err := a.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the rotated token to be revoked too, got %v", err)
+	}
+	md := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+refreshed.Token))
+	if _, err := callerFromMetadata(md, keys, a.denylist, nil); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the session's access token to be denied, got %v", err)
+	}
+}
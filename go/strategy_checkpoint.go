@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// Checkpointer is implemented by strategies whose state should survive restarts.
+// Checkpoint returns the state and the version of its format. Restore loads state
+// that Checkpoint wrote at version, which may be older than the current format.
+type Checkpointer interface {
+	Checkpoint() (version int, state []byte, err error)
+	Restore(version int, state []byte) error
+}
+
+var _ Checkpointer = (*Strategy)(nil)
+
+// strategyStateVersion is the current Strategy checkpoint format. When the format
+// changes, bump it and keep a case in Restore that reads the older versions.
+const strategyStateVersion = 1
+
+// defaultStrategyWindow is how many prices a strategy keeps without a "window" parameter.
+const defaultStrategyWindow = 20
+
+// strategyStateV1 is the version 1 checkpoint of a Strategy.
+type strategyStateV1 struct {
+	Prices []float64 `json:"prices"` // oldest first
+}
+
+func (s *Strategy) running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.IsActive
+}
+
+// windowSize is the number of prices the strategy keeps.
+func (s *Strategy) windowSize() int {
+	if n, err := strconv.Atoi(s.Parameters["window"]); err == nil && n > 0 {
+		return n
+	}
+	return defaultStrategyWindow
+}
+
+// observe adds a price to the window and returns the window's mean and oldest price.
+func (s *Strategy) observe(price float64) (mean, first float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices = append(s.prices, price)
+	if n := s.windowSize(); len(s.prices) > n {
+		s.prices = append([]float64(nil), s.prices[len(s.prices)-n:]...)
+	}
+	for _, p := range s.prices {
+		mean += p
+	}
+	return mean / float64(len(s.prices)), s.prices[0]
+}
+
+func (s *Strategy) Checkpoint() (int, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := json.Marshal(strategyStateV1{Prices: s.prices})
+	return strategyStateVersion, b, err
+}
+
+func (s *Strategy) Restore(version int, state []byte) error {
+	var st strategyStateV1
+	switch version {
+	case 1:
+		if err := json.Unmarshal(state, &st); err != nil {
+			return fmt.Errorf("invalid version %d state: %w", version, err)
+		}
+	default:
+		return fmt.Errorf("unsupported state version %d (this build writes %d)", version, strategyStateVersion)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.windowSize(); len(st.Prices) > n {
+		st.Prices = st.Prices[len(st.Prices)-n:]
+	}
+	s.prices = st.Prices
+	return nil
+}
+
+// checkpointStore keeps one checkpoint per bot. DBService implements it.
+type checkpointStore interface {
+	SaveStrategyState(ctx context.Context, cp *strategyCheckpoint) error
+	LoadStrategyState(ctx context.Context, botID string) (*strategyCheckpoint, error)
+}
+
+// strategyCheckpointer saves the state of running strategies every interval and when
+// they stop, and restores it when a bot's strategy starts again.
+type strategyCheckpointer struct {
+	store    checkpointStore
+	interval time.Duration
+
+	mu    sync.Mutex
+	saved map[string][]byte // last state saved per bot, so unchanged state is not rewritten
+}
+
+func newStrategyCheckpointer(store checkpointStore, interval time.Duration) *strategyCheckpointer {
+	return &strategyCheckpointer{store: store, interval: interval, saved: make(map[string][]byte)}
+}
+
+// restore loads the bot's last checkpoint into a strategy that has not started yet.
+// State saved by a different strategy type is ignored.
+func (c *strategyCheckpointer) restore(ctx context.Context, st *Strategy) error {
+	if st.BotID == "" {
+		return nil
+	}
+	saved, err := c.store.LoadStrategyState(ctx, st.BotID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if saved.StrategyType != st.StrategyType {
+		log.Info().Str("bot_id", st.BotID).Str("saved", saved.StrategyType).Str("strategy", st.StrategyType).Msg("ignoring checkpoint of another strategy type")
+		return nil
+	}
+	if err := st.Restore(saved.Version, saved.State); err != nil {
+		return fmt.Errorf("failed to restore strategy state saved %s: %w", saved.UpdatedAt.UTC().Format(time.RFC3339), err)
+	}
+	log.Info().Str("bot_id", st.BotID).Int("version", saved.Version).Time("saved_at", saved.UpdatedAt).Msg("strategy state restored")
+	return nil
+}
+
+// save stores the strategy's state unless it is unchanged since the last save.
+func (c *strategyCheckpointer) save(ctx context.Context, st *Strategy) error {
+	if st.BotID == "" {
+		return nil
+	}
+	version, state, err := st.Checkpoint()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	unchanged := bytes.Equal(c.saved[st.BotID], state)
+	c.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+	err = c.store.SaveStrategyState(ctx, &strategyCheckpoint{BotID: st.BotID, StrategyType: st.StrategyType, Version: version, State: state})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.saved[st.BotID] = state
+	c.mu.Unlock()
+	return nil
+}
+
+// Run saves every running strategy each interval until ctx is canceled.
+func (c *strategyCheckpointer) Run(ctx context.Context, trading *tradingServer) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.saveRunning(ctx, trading)
+		}
+	}
+}
+
+func (c *strategyCheckpointer) saveRunning(ctx context.Context, trading *tradingServer) {
+	trading.mu.RLock()
+	strategies := make([]*Strategy, 0, len(trading.strategies))
+	for _, st := range trading.strategies {
+		strategies = append(strategies, st)
+	}
+	trading.mu.RUnlock()
+	for _, st := range strategies {
+		if !st.running() {
+			continue
+		}
+		if err := c.save(ctx, st); err != nil {
+			log.Warn().Err(err).Str("bot_id", st.BotID).Str("strategy_id", st.ID).Msg("failed to checkpoint strategy")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "aetherion/gen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// memCheckpointStore is an in-memory checkpointStore that counts saves.
+type memCheckpointStore struct {
+	states map[string]*strategyCheckpoint
+	saves  int
+}
+
+func (m *memCheckpointStore) SaveStrategyState(_ context.Context, cp *strategyCheckpoint) error {
+	m.states[cp.BotID] = cp
+	m.saves++
+	return nil
+}
+
+func (m *memCheckpointStore) LoadStrategyState(_ context.Context, botID string) (*strategyCheckpoint, error) {
+	cp, ok := m.states[botID]
+	if !ok {
+		return nil, fmt.Errorf("failed to load strategy state: %w", pgx.ErrNoRows)
+	}
+	return cp, nil
+}
+
+func TestStrategyCheckpointRoundTrip(t *testing.T) {
+	st := &Strategy{Parameters: map[string]string{"window": "3"}}
+	for _, p := range []float64{1, 2, 3, 4} {
+		st.observe(p)
+	}
+	if mean, first := st.observe(5); mean != 4 || first != 3 {
+		t.Errorf("expected mean 4 and first 3 over the last 3 prices, got %v and %v", mean, first)
+	}
+	version, state, err := st.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := &Strategy{Parameters: map[string]string{"window": "2"}}
+	if err := restored.Restore(version, state); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(restored.prices) != "[4 5]" {
+		t.Errorf("expected the newest prices to fit the smaller window, got %v", restored.prices)
+	}
+	if err := restored.Restore(strategyStateVersion+1, state); err == nil {
+		t.Error("expected a newer state version to be refused")
+	}
+}
+
+func TestStrategyCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	store := &memCheckpointStore{states: map[string]*strategyCheckpoint{}}
+	c := newStrategyCheckpointer(store, 0)
+
+	st := &Strategy{BotID: "bot", StrategyType: "MOMENTUM", IsActive: true}
+	if err := c.restore(ctx, st); err != nil {
+		t.Fatalf("expected a bot without a checkpoint to start fresh, got %v", err)
+	}
+	st.observe(100)
+	for range 2 {
+		if err := c.save(ctx, st); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if store.saves != 1 {
+		t.Errorf("expected unchanged state to be saved once, got %d saves", store.saves)
+	}
+
+	other := &Strategy{BotID: "bot", StrategyType: "MEAN_REVERSION"}
+	if err := c.restore(ctx, other); err != nil || len(other.prices) != 0 {
+		t.Errorf("expected another strategy type's state to be ignored, got %v, %v", other.prices, err)
+	}
+
+	trading := newTradingServer()
+	trading.checkpoints = c
+	resp, err := trading.runStrategy(ctx, &pb.StrategyRequest{Symbol: "BTC-USD", Parameters: map[string]string{"type": "MOMENTUM"}}, "bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trading.mu.RLock()
+	started := trading.strategies[resp.Id]
+	trading.mu.RUnlock()
+	defer trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: resp.Id})
+	started.mu.Lock()
+	prices := fmt.Sprint(started.prices)
+	started.mu.Unlock()
+	if prices != "[100]" {
+		t.Errorf("expected runStrategy to restore the checkpoint, got %v", prices)
+	}
+
+	store.states["bot"].Version = 99
+	_, err = trading.runStrategy(ctx, &pb.StrategyRequest{Symbol: "BTC-USD", Parameters: map[string]string{"type": "MOMENTUM"}}, "bot")
+	if err == nil || !strings.Contains(err.Error(), "unsupported state version 99") {
+		t.Errorf("expected an unreadable checkpoint to stop the start, got %v", err)
+	}
+
+	// A bot id in the public RPC's parameters never reaches the checkpoint
+	resp, err = trading.StartStrategy(ctx, &pb.StrategyRequest{Symbol: "BTC-USD", Parameters: map[string]string{"type": "MOMENTUM", "user_id": "bot"}})
+	if err != nil {
+		t.Fatalf("expected the strategy to start without a checkpoint, got %v", err)
+	}
+	defer trading.StopStrategy(ctx, &pb.StrategyRequest{StrategyId: resp.Id})
+	trading.mu.RLock()
+	started = trading.strategies[resp.Id]
+	trading.mu.RUnlock()
+	if started.BotID != "" {
+		t.Errorf("expected no bot id, got %q", started.BotID)
+	}
+}
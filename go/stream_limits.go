@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// contextStream replaces a ServerStream's context so interceptors can pass values to handlers.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+// streamLimiter caps how many server streams each caller may hold open at once.
+type streamLimiter struct {
+	max    int
+	mu     sync.Mutex
+	active map[string]int
+}
+
+func newStreamLimiter(max int) *streamLimiter {
+	return &streamLimiter{max: max, active: make(map[string]int)}
+}
+
+func (l *streamLimiter) acquire(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[key] >= l.max {
+		return false
+	}
+	l.active[key]++
+	return true
+}
+
+func (l *streamLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[key] <= 1 {
+		delete(l.active, key)
+	} else {
+		l.active[key]--
+	}
+}
+
+// streamKey identifies the caller a stream counts against: the authenticated user, or
+// the peer address for callers without one.
+func streamKey(ctx context.Context) string {
+	if c, ok := callerFromContext(ctx); ok && c.UserID != "" {
+		return "user:" + c.UserID
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return "peer:" + p.Addr.String()
+	}
+	return "anonymous"
+}
+
+// interceptor rejects streams beyond the caller's limit with ResourceExhausted. It must
+// run after authStreamInterceptor so streams are counted per user.
+func (l *streamLimiter) interceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if l.max <= 0 {
+			return handler(srv, ss)
+		}
+		key := streamKey(ss.Context())
+		if !l.acquire(key) {
+			return status.Errorf(codes.ResourceExhausted, "too many open streams (max %d)", l.max)
+		}
+		defer l.release(key)
+		return handler(srv, ss)
+	}
+}
+
+var errStreamIdle = errors.New("stream idle")
+
+// activityStream records when a message was last sent or received.
+type activityStream struct {
+	contextStream
+	last atomic.Int64 // unix nanos
+}
+
+func (s *activityStream) touch() { s.last.Store(time.Now().UnixNano()) }
+
+func (s *activityStream) idleFor() time.Duration {
+	return time.Since(time.Unix(0, s.last.Load()))
+}
+
+func (s *activityStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	s.touch()
+	return err
+}
+
+func (s *activityStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	s.touch()
+	return err
+}
+
+// idleTimeoutStream cancels a stream's context when no message has been sent or
+// received for d, and reports it to the client as DeadlineExceeded.
+func idleTimeoutStream(d time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if d <= 0 {
+			return handler(srv, ss)
+		}
+		ctx, cancel := context.WithCancelCause(ss.Context())
+		defer cancel(nil)
+		s := &activityStream{contextStream: contextStream{ServerStream: ss, ctx: ctx}}
+		s.touch()
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ctx.Done():
+					return
+				case <-timer.C:
+					idle := s.idleFor()
+					if idle >= d {
+						cancel(errStreamIdle)
+						return
+					}
+					timer.Reset(d - idle)
+				}
+			}
+		}()
+
+		err := handler(srv, s)
+		if errors.Is(context.Cause(ctx), errStreamIdle) {
+			return status.Errorf(codes.DeadlineExceeded, "stream idle for %s", d)
+		}
+		return err
+	}
+}
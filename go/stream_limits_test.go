@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context    { return f.ctx }
+func (f *fakeServerStream) SendMsg(m interface{}) error { return nil }
+
+func TestStreamLimiterPerUser(t *testing.T) {
+	limit := newStreamLimiter(1).interceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/trading.TradingService/StreamPrice"}
+	alice := &fakeServerStream{ctx: withCaller(context.Background(), caller{UserID: "alice", Role: roleUser})}
+	bob := &fakeServerStream{ctx: withCaller(context.Background(), caller{UserID: "bob", Role: roleUser})}
+
+	holding := make(chan struct{})
+	release := make(chan struct{})
+	go limit(nil, alice, info, func(interface{}, grpc.ServerStream) error {
+		close(holding)
+		<-release
+		return nil
+	})
+	<-holding
+
+	noop := func(interface{}, grpc.ServerStream) error { return nil }
+	if err := limit(nil, alice, info, noop); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted for a second stream, got %v", err)
+	}
+	if err := limit(nil, bob, info, noop); err != nil {
+		t.Errorf("expected another user's stream to be allowed, got %v", err)
+	}
+	close(release)
+}
+
+func TestIdleTimeoutStream(t *testing.T) {
+	// Sends are 20x more frequent than the timeout, so a slow machine cannot make an
+	// active stream look idle, and the active stream outlives the timeout.
+	const timeout = 500 * time.Millisecond
+	idle := idleTimeoutStream(timeout)
+	info := &grpc.StreamServerInfo{FullMethod: "/trading.BotService/StreamBotStatus"}
+	ss := &fakeServerStream{ctx: context.Background()}
+
+	// A stream that keeps sending stays open.
+	err := idle(nil, ss, info, func(_ interface{}, s grpc.ServerStream) error {
+		for i := 0; i < 30; i++ {
+			time.Sleep(timeout / 20)
+			if err := s.SendMsg(nil); err != nil {
+				return err
+			}
+		}
+		return s.Context().Err()
+	})
+	if err != nil {
+		t.Fatalf("expected an active stream to stay open, got %v", err)
+	}
+
+	// A silent stream is closed with DeadlineExceeded.
+	err = idle(nil, ss, info, func(_ interface{}, s grpc.ServerStream) error {
+		<-s.Context().Done()
+		return nil
+	})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded for an idle stream, got %v", err)
+	}
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TOTP parameters (RFC 6238 defaults, which every authenticator app supports).
+const (
+	totpIssuer        = "Aetherion"
+	totpPeriod        = 30 // seconds
+	totpDigits        = 6
+	totpSkew          = 1 // accept codes one period early or late
+	totpSecretBytes   = 20
+	recoveryCodeCount = 10
+	challengeTTL      = 5 * time.Minute
+)
+
+// Challenge token types, carried in the typ claim. The auth interceptors reject any
+// token with a typ, so challenge tokens only work with the TOTP RPCs.
+const (
+	challengeLogin  = "mfa_login"
+	challengeEnroll = "mfa_enroll"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func totpStep(t time.Time) int64 { return t.Unix() / totpPeriod }
+
+// totpCode is the HOTP value (RFC 4226) of secret at a time step.
+func totpCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	off := sum[len(sum)-1] & 0x0f
+	v := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, v%1_000_000)
+}
+
+// matchTOTP checks code against the steps around now and returns the step it matched.
+func matchTOTP(secret []byte, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	cur := totpStep(now)
+	for step := cur - totpSkew; step <= cur+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpProvisioningURI returns the otpauth:// URI authenticator apps import.
+func totpProvisioningURI(account string, secret []byte) string {
+	q := url.Values{}
+	q.Set("secret", totpEncoding.EncodeToString(secret))
+	q.Set("issuer", totpIssuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(totpIssuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// newRecoveryCodes returns recovery codes like "3f9a1-c07be" and their hashes.
+func newRecoveryCodes() ([]string, [][]byte, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([][]byte, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		h := hex.EncodeToString(b)
+		codes[i] = h[:5] + "-" + h[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely.
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return sum[:]
+}
+
+func totpAAD(userID string) []byte {
+	return []byte("totp/" + userID)
+}
+
+// mfaAvailable reports whether TOTP can be stored: it needs sessions (Postgres users)
+// and the credentials master key to seal secrets.
+func (a *authServer) mfaAvailable() bool {
+	return a.sessions != nil && a.totpKey != nil
+}
+
+// challenge is the user a challenge token was issued to.
+type challenge struct {
+	UserID   string
+	Username string
+	Role     string
+}
+
+func (a *authServer) signChallenge(typ string, ch challenge) (string, error) {
+	return a.keys.sign(jwt.MapClaims{
+		"sub":  ch.UserID,
+		"usr":  ch.Username,
+		"role": ch.Role,
+		"typ":  typ,
+		"jti":  uuid.New().String(),
+		"exp":  time.Now().Add(challengeTTL).Unix(),
+	})
+}
+
+func (a *authServer) parseChallenge(tokenStr, typ string) (challenge, error) {
+	token, err := a.keys.parse(tokenStr)
+	if err != nil {
+		return challenge{}, status.Error(codes.Unauthenticated, "invalid or expired challenge token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["typ"] != typ {
+		return challenge{}, status.Error(codes.Unauthenticated, "invalid challenge token")
+	}
+	var ch challenge
+	ch.UserID, _ = claims["sub"].(string)
+	ch.Username, _ = claims["usr"].(string)
+	ch.Role, _ = claims["role"].(string)
+	if ch.UserID == "" {
+		return challenge{}, status.Error(codes.Unauthenticated, "invalid challenge token")
+	}
+	return ch, nil
+}
+
+// secondFactor decides whether a password login must continue with TOTP. It returns
+// the response to send instead of tokens, or nil when the login may complete.
+func (a *authServer) secondFactor(ctx context.Context, ch challenge) *pb.AuthResponse {
+	required := a.mfaRequiredRoles[ch.Role]
+	if !a.mfaAvailable() {
+		if required {
+			log.Error().Str("role", ch.Role).Msg("two-factor authentication required but not configured; set CREDENTIALS_MASTER_KEY and POSTGRES_DSN")
+			return &pb.AuthResponse{Success: false, Message: "two-factor authentication is required but not available"}
+		}
+		return nil
+	}
+	t, err := a.sessions.GetTOTP(ctx, ch.UserID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Err(err).Msg("two-factor lookup failed")
+		return &pb.AuthResponse{Success: false, Message: "login failed"}
+	}
+	typ := challengeLogin
+	switch {
+	case err == nil && t.Enabled:
+	case required:
+		typ = challengeEnroll
+	default:
+		return nil
+	}
+	token, err := a.signChallenge(typ, ch)
+	if err != nil {
+		log.Error().Err(err).Msg("challenge signing failed")
+		return &pb.AuthResponse{Success: false, Message: "login failed"}
+	}
+	resp := &pb.AuthResponse{
+		Success:        false,
+		Username:       ch.Username,
+		Role:           ch.Role,
+		ChallengeToken: token,
+	}
+	if typ == challengeLogin {
+		resp.Message = "two-factor code required"
+		resp.MfaRequired = true
+	} else {
+		resp.Message = "two-factor enrollment required"
+		resp.MfaEnrollmentRequired = true
+	}
+	return resp
+}
+
+// totpSubject is the user an EnrollTOTP or VerifyTOTP call acts on: the holder of an
+// enrollment challenge token, or else the signed-in caller.
+func (a *authServer) totpSubject(ctx context.Context, challengeToken string) (challenge, bool, error) {
+	if challengeToken != "" {
+		ch, err := a.parseChallenge(challengeToken, challengeEnroll)
+		return ch, true, err
+	}
+	c, err := callerFromMetadata(ctx, a.keys, a.denylist, a.apiKeys)
+	if err != nil {
+		return challenge{}, false, err
+	}
+	if c.APIKeyID != "" {
+		return challenge{}, false, status.Error(codes.PermissionDenied, "api keys cannot manage two-factor authentication")
+	}
+	u, err := a.store.UserByID(ctx, c.UserID)
+	if err != nil {
+		return challenge{}, false, status.Error(codes.Unauthenticated, "user no longer exists")
+	}
+	return challenge{UserID: u.ID, Username: u.Username, Role: u.Role}, false, nil
+}
+
+// EnrollTOTP starts enrollment with a new secret. Enrollment completes once
+// VerifyTOTP accepts a code generated from it.
+func (a *authServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	if !a.mfaAvailable() {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not configured")
+	}
+	ch, _, err := a.totpSubject(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	secret := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, status.Errorf(codes.Internal, "secret generation failed: %v", err)
+	}
+	sealed, err := gcmSeal(a.totpKey, secret, totpAAD(ch.UserID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "secret sealing failed: %v", err)
+	}
+	if err := a.sessions.PutPendingTOTP(ctx, ch.UserID, sealed); err != nil {
+		if errors.Is(err, errTOTPEnabled) {
+			return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+		}
+		return nil, status.Errorf(codes.Internal, "enrollment failed: %v", err)
+	}
+	return &pb.EnrollTOTPResponse{
+		Secret:          totpEncoding.EncodeToString(secret),
+		ProvisioningUri: totpProvisioningURI(ch.Username, secret),
+	}, nil
+}
+
+// VerifyTOTP completes enrollment and returns recovery codes. With an enrollment
+// challenge token it also completes the login that issued it.
+func (a *authServer) VerifyTOTP(ctx context.Context, req *pb.VerifyTOTPRequest) (*pb.VerifyTOTPResponse, error) {
+	if !a.mfaAvailable() {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not configured")
+	}
+	ch, fromChallenge, err := a.totpSubject(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	t, err := a.sessions.GetTOTP(ctx, ch.UserID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && t.Enabled) {
+		return nil, status.Error(codes.FailedPrecondition, "no two-factor enrollment in progress")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "verification failed: %v", err)
+	}
+	secret, err := gcmOpen(a.totpKey, t.SecretSealed, totpAAD(ch.UserID))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "secret unsealing failed: %v", err)
+	}
+	step, ok := matchTOTP(secret, req.Code, time.Now())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+	recovery, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "recovery code generation failed: %v", err)
+	}
+	if err := a.sessions.EnableTOTP(ctx, ch.UserID, step, hashes); err != nil {
+		if errors.Is(err, errTOTPEnabled) {
+			return nil, status.Error(codes.FailedPrecondition, "no two-factor enrollment in progress")
+		}
+		return nil, status.Errorf(codes.Internal, "enabling two-factor failed: %v", err)
+	}
+	log.Info().Str("user_id", ch.UserID).Msg("two-factor authentication enabled")
+
+	resp := &pb.VerifyTOTPResponse{RecoveryCodes: recovery}
+	if fromChallenge {
+		auth := &pb.AuthResponse{Success: true, Message: "ok", Username: ch.Username}
+		if err := a.issueTokens(ctx, auth, ch.UserID, ch.Username, ch.Role); err != nil {
+			return nil, err
+		}
+		resp.Auth = auth
+	}
+	return resp, nil
+}
+
+// CompleteLogin finishes a login that returned mfa_required, with a TOTP code or a
+// recovery code.
+func (a *authServer) CompleteLogin(ctx context.Context, req *pb.CompleteLoginRequest) (*pb.AuthResponse, error) {
+	if !a.mfaAvailable() {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not configured")
+	}
+	ch, err := a.parseChallenge(req.ChallengeToken, challengeLogin)
+	if err != nil {
+		return nil, err
+	}
+	ip := a.guard.clientIP(ctx)
+	if err := a.guard.check(ctx, ch.Username, ip); err != nil {
+		return nil, err
+	}
+	if err := a.checkSecondFactor(ctx, ch.UserID, req.Code, req.RecoveryCode); err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			a.guard.fail(ctx, ch.Username, ip, "invalid second factor")
+		}
+		return nil, err
+	}
+	a.guard.succeed(ctx, ch.Username, ip, "second factor")
+	resp := &pb.AuthResponse{Success: true, Message: "ok", Username: ch.Username}
+	if u, err := a.store.UserByID(ctx, ch.UserID); err == nil {
+		resp.Email = u.Email
+	}
+	if err := a.issueTokens(ctx, resp, ch.UserID, ch.Username, ch.Role); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DisableTOTP turns off two-factor authentication for the caller, unless their role
+// requires it.
+func (a *authServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.StatusResponse, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return nil, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.APIKeyID != "" {
+		return nil, status.Error(codes.PermissionDenied, "api keys cannot manage two-factor authentication")
+	}
+	if !a.mfaAvailable() {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not configured")
+	}
+	if a.mfaRequiredRoles[c.Role] {
+		return nil, status.Errorf(codes.FailedPrecondition, "two-factor authentication is required for role %s", c.Role)
+	}
+	code, recovery := req.Code, ""
+	if len(strings.TrimSpace(code)) != totpDigits {
+		code, recovery = "", req.Code
+	}
+	if err := a.checkSecondFactor(ctx, c.UserID, code, recovery); err != nil {
+		return nil, err
+	}
+	if err := a.sessions.DeleteTOTP(ctx, c.UserID); err != nil {
+		return nil, status.Errorf(codes.Internal, "disabling two-factor failed: %v", err)
+	}
+	log.Info().Str("user_id", c.UserID).Msg("two-factor authentication disabled")
+	return &pb.StatusResponse{Success: true, Message: "two-factor authentication disabled"}, nil
+}
+
+// checkSecondFactor accepts a recovery code, or else a TOTP code that has not been
+// used before.
+func (a *authServer) checkSecondFactor(ctx context.Context, userID, code, recovery string) error {
+	if recovery != "" {
+		ok, err := a.sessions.UseRecoveryCode(ctx, userID, hashRecoveryCode(recovery))
+		if err != nil {
+			return status.Errorf(codes.Internal, "recovery code check failed: %v", err)
+		}
+		if !ok {
+			return status.Error(codes.Unauthenticated, "invalid recovery code")
+		}
+		log.Warn().Str("user_id", userID).Msg("recovery code used")
+		return nil
+	}
+	t, err := a.sessions.GetTOTP(ctx, userID)
+	if err != nil || !t.Enabled {
+		return status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+	secret, err := gcmOpen(a.totpKey, t.SecretSealed, totpAAD(userID))
+	if err != nil {
+		return status.Errorf(codes.Internal, "secret unsealing failed: %v", err)
+	}
+	step, ok := matchTOTP(secret, code, time.Now())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid code")
+	}
+	fresh, err := a.sessions.UseTOTPStep(ctx, userID, step)
+	if err != nil {
+		return status.Errorf(codes.Internal, "code check failed: %v", err)
+	}
+	if !fresh {
+		return status.Error(codes.Unauthenticated, "code already used")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B (SHA-1), truncated to six digits.
+	secret := []byte("12345678901234567890")
+	for unix, want := range map[int64]string{59: "287082", 1111111109: "081804", 2000000000: "279037"} {
+		if got := totpCode(secret, totpStep(time.Unix(unix, 0))); got != want {
+			t.Errorf("totpCode at %d = %s, want %s", unix, got, want)
+		}
+	}
+
+	now := time.Unix(1111111109, 0)
+	prev := totpCode(secret, totpStep(now)-1)
+	if step, ok := matchTOTP(secret, prev, now); !ok || step != totpStep(now)-1 {
+		t.Errorf("expected the previous period's code to match, got %d %v", step, ok)
+	}
+	if _, ok := matchTOTP(secret, totpCode(secret, totpStep(now)-2), now); ok {
+		t.Error("expected a code two periods old to be rejected")
+	}
+
+	uri := totpProvisioningURI("alice", secret)
+	if !strings.HasPrefix(uri, "otpauth://totp/Aetherion:alice?") || !strings.Contains(uri, "secret="+totpEncoding.EncodeToString(secret)) {
+		t.Errorf("unexpected provisioning uri %s", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", recoveryCodeCount, len(codes))
+	}
+	loose := strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))
+	if string(hashRecoveryCode(loose)) != string(hashes[0]) {
+		t.Errorf("expected %q to hash like %q", loose, codes[0])
+	}
+}
+
+func TestChallengeTokens(t *testing.T) {
+	keys, err := newSigningKeys("0123456789abcdef0123456789abcdef", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &authServer{keys: keys, mfaRequiredRoles: map[string]bool{roleAdmin: true}}
+	ch := challenge{UserID: "u1", Username: "alice", Role: roleAdmin}
+	token, err := a.signChallenge(challengeLogin, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := a.parseChallenge(token, challengeLogin); err != nil || got != ch {
+		t.Errorf("expected the challenge back, got %+v %v", got, err)
+	}
+	if _, err := a.parseChallenge(token, challengeEnroll); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected a login challenge to be rejected for enrollment, got %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	if _, err := callerFromMetadata(ctx, keys, nil, nil); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected a challenge token to be rejected as an access token, got %v", err)
+	}
+
+	// A role that requires 2FA cannot log in when 2FA is not configured.
+	if resp := a.secondFactor(context.Background(), ch); resp == nil || resp.Success {
+		t.Errorf("expected an admin login to be refused without two-factor support, got %+v", resp)
+	}
+	if resp := a.secondFactor(context.Background(), challenge{UserID: "u2", Role: roleUser}); resp != nil {
+		t.Errorf("expected a user login to proceed, got %+v", resp)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTradePageSize = 100
+	maxTradePageSize     = 1000
+)
+
+// encodeTradeCursor makes the opaque next_page_token for a page ending at c.
+func encodeTradeCursor(c keysetCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.At.UnixMicro(), 10) + "_" + c.ID))
+}
+
+func decodeTradeCursor(token string) (*keysetCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	micros, id, ok := strings.Cut(string(b), "_")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	return &keysetCursor{At: time.UnixMicro(us), ID: id}, nil
+}
+
+// tradeFilterFromRequest validates the filters of a trade history request.
+func tradeFilterFromRequest(req *pb.TradeHistoryRequest) (historyFilter, error) {
+	f := historyFilter{UserID: req.UserId, BotID: req.BotId, Symbol: req.Symbol, Side: strings.ToUpper(req.Side)}
+	if f.Side != "" && f.Side != "BUY" && f.Side != "SELL" {
+		return f, status.Error(codes.InvalidArgument, "side must be BUY or SELL")
+	}
+	for _, id := range []string{f.UserID, f.BotID} {
+		if _, err := uuid.Parse(id); id != "" && err != nil {
+			return f, status.Error(codes.InvalidArgument, "user_id and bot_id must be UUIDs")
+		}
+	}
+	if req.StartTime != nil {
+		f.Start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		f.End = req.EndTime.AsTime()
+	}
+	if !f.Start.IsZero() && !f.End.IsZero() && !f.Start.Before(f.End) {
+		return f, status.Error(codes.InvalidArgument, "start_time must be before end_time")
+	}
+	return f, nil
+}
+
+// authorizeHistoryFilter checks the caller may read the history f selects: that of a
+// bot they own or of their own account. Admins and services may read anyone's.
+func authorizeHistoryFilter(ctx context.Context, bots botLookup, f historyFilter) error {
+	if f.BotID != "" {
+		return authorizeBot(ctx, bots, f.BotID)
+	}
+	return authorizeUser(ctx, f.UserID)
+}
+
+// defaultToOwnHistory narrows a filter that names neither a user nor a bot to the
+// caller's own account. Admins and services are left to read everyone's.
+func defaultToOwnHistory(ctx context.Context, f *historyFilter) {
+	if c, ok := callerFromContext(ctx); ok && f.UserID == "" && f.BotID == "" && !c.IsPrivileged() {
+		f.UserID = c.UserID
+	}
+}
+
+// GetTradeHistory returns a page of the trades matching the request's filters, newest
+// first, with the number of matching trades and their totals by symbol. Without a
+// user_id or bot_id, users get their own trades.
+func (s *OrderServiceServer) GetTradeHistory(ctx context.Context, req *pb.TradeHistoryRequest) (*pb.TradeHistoryResponse, error) {
+	f, err := tradeFilterFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defaultToOwnHistory(ctx, &f)
+	if err := authorizeHistoryFilter(ctx, s.bots, f); err != nil {
+		return nil, err
+	}
+	var after *keysetCursor
+	if req.PageToken != "" {
+		if after, err = decodeTradeCursor(req.PageToken); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+	size := int(req.PageSize)
+	if size <= 0 {
+		size = defaultTradePageSize
+	}
+	size = min(size, maxTradePageSize)
+
+	// One extra row tells whether there is another page
+	trades, err := s.dbclient.ListTrades(ctx, f, after, size+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list trades: %v", err)
+	}
+	resp := &pb.TradeHistoryResponse{Trades: trades}
+	if len(trades) > size {
+		resp.Trades = trades[:size]
+		last := resp.Trades[size-1]
+		resp.NextPageToken = encodeTradeCursor(keysetCursor{At: last.ExecutedAtTimestamp.AsTime(), ID: last.TradeId})
+	}
+	if resp.Summaries, err = s.dbclient.SummarizeTrades(ctx, f); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to summarize trades: %v", err)
+	}
+	for _, sum := range resp.Summaries {
+		resp.TotalCount += sum.TradeCount
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTradeCursorRoundTrip(t *testing.T) {
+	c := keysetCursor{At: time.Date(2024, 5, 1, 9, 0, 0, 123456000, time.UTC), ID: uuid.NewString()}
+	got, err := decodeTradeCursor(encodeTradeCursor(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.At.Equal(c.At) || got.ID != c.ID {
+		t.Errorf("expected %v, got %v", c, got)
+	}
+	for _, bad := range []string{"!!", "bm90LWEtY3Vyc29y", encodeTradeCursor(keysetCursor{ID: "x"})} {
+		if _, err := decodeTradeCursor(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestTradeFilterFromRequest(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	botID := uuid.NewString()
+	f, err := tradeFilterFromRequest(&pb.TradeHistoryRequest{
+		BotId: botID, Symbol: "BTC-USD", Side: "buy",
+		StartTime: timestamppb.New(start), EndTime: timestamppb.New(start.Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	where, args := f.where("executed_at")
+	if want := "t.bot_id = $1 AND t.symbol = $2 AND t.side = $3 AND t.executed_at >= $4 AND t.executed_at < $5"; where != want {
+		t.Errorf("expected %q, got %q", want, where)
+	}
+	if len(args) != 5 || args[2] != "BUY" {
+		t.Errorf("unexpected args %v", args)
+	}
+	if where, args := (historyFilter{}).where("executed_at"); where != "TRUE" || len(args) != 0 {
+		t.Errorf("expected an empty filter to match everything, got %q %v", where, args)
+	}
+
+	for _, req := range []*pb.TradeHistoryRequest{
+		{Side: "HOLD"},
+		{BotId: "not-a-uuid"},
+		{StartTime: timestamppb.New(start), EndTime: timestamppb.New(start)},
+	} {
+		if _, err := tradeFilterFromRequest(req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument for %v, got %v", req, err)
+		}
+	}
+}
+
+func TestGetTradeHistoryAuthorization(t *testing.T) {
+	alice, bob := uuid.NewString(), uuid.NewString()
+	s := &OrderServiceServer{bots: func(id string) (*pb.Bot, bool) { return &pb.Bot{BotId: id, UserId: alice}, true }}
+	ctx := withCaller(context.Background(), caller{UserID: bob, Role: roleUser})
+	if _, err := s.GetTradeHistory(ctx, &pb.TradeHistoryRequest{BotId: uuid.NewString()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected another user's bot to be refused, got %v", err)
+	}
+	if _, err := s.GetTradeHistory(ctx, &pb.TradeHistoryRequest{UserId: alice}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected another user's trades to be refused, got %v", err)
+	}
+	_, err := s.GetTradeHistory(ctx, &pb.TradeHistoryRequest{UserId: bob, PageToken: "%%"})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "page_token") {
+		t.Errorf("expected a bad page_token to be refused, got %v", err)
+	}
+	// Naming neither a user nor a bot reads the caller's own trades, so it gets as far
+	// as the page token
+	_, err = s.GetTradeHistory(ctx, &pb.TradeHistoryRequest{PageToken: "%%"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a request without filters to be allowed, got %v", err)
+	}
+	if _, err := s.GetTradeHistory(context.Background(), &pb.TradeHistoryRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected a request without a caller to be refused, got %v", err)
+	}
+}
+
+func TestDefaultToOwnHistory(t *testing.T) {
+	bob := caller{UserID: uuid.NewString(), Role: roleUser}
+	cases := []struct {
+		name   string
+		caller caller
+		in     historyFilter
+		want   string
+	}{
+		{name: "user without filters", caller: bob, want: bob.UserID},
+		{name: "user naming a bot", caller: bob, in: historyFilter{BotID: "b1"}},
+		{name: "user naming a user", caller: bob, in: historyFilter{UserID: "u1"}, want: "u1"},
+		{name: "admin without filters", caller: caller{UserID: "admin", Role: roleAdmin}},
+	}
+	for _, tc := range cases {
+		f := tc.in
+		defaultToOwnHistory(withCaller(context.Background(), tc.caller), &f)
+		if f.UserID != tc.want {
+			t.Errorf("%s: expected user %q, got %q", tc.name, tc.want, f.UserID)
+		}
+	}
+}
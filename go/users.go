@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "aetherion/gen"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userStore is the user repository. DBService implements it on the shared pool;
+// memUserStore is an in-memory version for tests.
+type userStore interface {
+	CreateUser(ctx context.Context, u *userAccount) error
+	UserByUsername(ctx context.Context, username string) (*userAccount, error)
+	UserByID(ctx context.Context, userID string) (*userAccount, error)
+	UserByEmail(ctx context.Context, email string) (*userAccount, error)
+	UpdateUserEmail(ctx context.Context, userID, email string) error
+	UpdatePassword(ctx context.Context, userID, passwordHash, keepSession string) error
+	DeleteUser(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context, limit, offset int) ([]*userAccount, error)
+}
+
+// memUserStore implements userStore in-memory. It has no sessions or bots to cascade to.
+type memUserStore struct {
+	mu    sync.Mutex
+	users map[string]*userAccount // by id
+}
+
+func newMemUserStore() *memUserStore {
+	return &memUserStore{users: make(map[string]*userAccount)}
+}
+
+func (m *memUserStore) CreateUser(_ context.Context, u *userAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.users {
+		if v.Username == u.Username {
+			return errUserExists
+		}
+	}
+	u.ID, u.CreatedAt = uuid.New().String(), time.Now()
+	cp := *u
+	m.users[u.ID] = &cp
+	return nil
+}
+
+// find returns a copy of the first user, in signup order, that match accepts.
+func (m *memUserStore) find(match func(*userAccount) bool) (*userAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found *userAccount
+	for _, v := range m.users {
+		if match(v) && (found == nil || v.CreatedAt.Before(found.CreatedAt)) {
+			found = v
+		}
+	}
+	if found == nil {
+		return nil, errUserNotFound
+	}
+	cp := *found
+	return &cp, nil
+}
+
+func (m *memUserStore) UserByUsername(_ context.Context, username string) (*userAccount, error) {
+	return m.find(func(u *userAccount) bool { return u.Username == username })
+}
+
+func (m *memUserStore) UserByID(_ context.Context, userID string) (*userAccount, error) {
+	return m.find(func(u *userAccount) bool { return u.ID == userID })
+}
+
+func (m *memUserStore) UserByEmail(_ context.Context, email string) (*userAccount, error) {
+	return m.find(func(u *userAccount) bool { return strings.EqualFold(u.Email, email) })
+}
+
+func (m *memUserStore) UpdateUserEmail(_ context.Context, userID, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[userID]
+	if !ok {
+		return errUserNotFound
+	}
+	if u.Email != email {
+		u.Email, u.EmailVerified = email, false
+	}
+	return nil
+}
+
+func (m *memUserStore) UpdatePassword(_ context.Context, userID, passwordHash, _ string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[userID]
+	if !ok {
+		return errUserNotFound
+	}
+	u.PasswordHash = passwordHash
+	return nil
+}
+
+func (m *memUserStore) DeleteUser(_ context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[userID]; !ok {
+		return errUserNotFound
+	}
+	delete(m.users, userID)
+	return nil
+}
+
+// ListUsers returns users ordered by signup time.
+func (m *memUserStore) ListUsers(_ context.Context, limit, offset int) ([]*userAccount, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	all := make([]*userAccount, 0, len(m.users))
+	for _, u := range m.users {
+		cp := *u
+		all = append(all, &cp)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].CreatedAt.Equal(all[j].CreatedAt) {
+			return all[i].CreatedAt.Before(all[j].CreatedAt)
+		}
+		return all[i].Username < all[j].Username
+	})
+	if offset >= len(all) {
+		return nil, nil
+	}
+	return all[offset:min(offset+limit, len(all))], nil
+}
+
+// accountCaller returns the signed-in caller of an account management RPC. API keys
+// cannot change the account they belong to.
+func accountCaller(ctx context.Context) (caller, error) {
+	c, ok := callerFromContext(ctx)
+	if !ok || c.UserID == "" {
+		return caller{}, status.Error(codes.Unauthenticated, "auth required")
+	}
+	if c.APIKeyID != "" {
+		return caller{}, status.Error(codes.PermissionDenied, "api keys cannot manage accounts")
+	}
+	return c, nil
+}
+
+// UpdateProfile changes the caller's email address. A new address is unverified until
+// the link mailed to it is opened.
+func (a *authServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UserInfo, error) {
+	c, err := accountCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "a valid email is required")
+	}
+	if err := a.store.UpdateUserEmail(ctx, c.UserID, addr.Address); err != nil {
+		if errors.Is(err, errUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "update profile failed: %v", err)
+	}
+	u, err := a.store.UserByID(ctx, c.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "update profile failed: %v", err)
+	}
+	if !u.EmailVerified && a.sessions != nil {
+		if err := a.sendAccountToken(ctx, u.ID, u.Email, tokenEmailVerification); err != nil && !errors.Is(err, errAccountTokenThrottled) {
+			log.Warn().Err(err).Str("user_id", u.ID).Msg("verification email not queued")
+		}
+	}
+	return u.info(), nil
+}
+
+// ChangePassword sets a new password after checking the current one, and ends the
+// caller's other sessions. Wrong passwords count towards the login backoff.
+func (a *authServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.StatusResponse, error) {
+	c, err := accountCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "current_password and new_password are required")
+	}
+	u, err := a.store.UserByID(ctx, c.UserID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	ip := a.guard.clientIP(ctx)
+	if err := a.guard.check(ctx, u.Username, ip); err != nil {
+		return nil, err
+	}
+	if !verifyPassword(u.PasswordHash, req.CurrentPassword) {
+		a.guard.fail(ctx, u.Username, ip, "invalid password (change password)")
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+	if err := a.store.UpdatePassword(ctx, u.ID, hashPassword(req.NewPassword), c.SessionID); err != nil {
+		return nil, status.Errorf(codes.Internal, "change password failed: %v", err)
+	}
+	a.reloadDenylist(ctx)
+	log.Info().Str("user_id", u.ID).Msg("password changed")
+	return &pb.StatusResponse{Success: true, Message: "password changed; other sessions were signed out"}, nil
+}
+
+// DeleteAccount deletes the caller's account after checking their password, or, for
+// admins, the account in user_id. The user's bots are stopped and deleted with it.
+// Wrong passwords count towards the login backoff.
+func (a *authServer) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.StatusResponse, error) {
+	c, err := accountCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := c.UserID
+	if req.UserId != "" && req.UserId != c.UserID {
+		if !c.IsAdmin() {
+			return nil, status.Error(codes.PermissionDenied, "only admins can delete other accounts")
+		}
+		target = req.UserId
+	} else {
+		u, err := a.store.UserByID(ctx, c.UserID)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		ip := a.guard.clientIP(ctx)
+		if err := a.guard.check(ctx, u.Username, ip); err != nil {
+			return nil, err
+		}
+		if !verifyPassword(u.PasswordHash, req.Password) {
+			a.guard.fail(ctx, u.Username, ip, "invalid password (delete account)")
+			return nil, status.Error(codes.PermissionDenied, "password is incorrect")
+		}
+	}
+	if err := a.store.DeleteUser(ctx, target); err != nil {
+		if errors.Is(err, errUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "delete account failed: %v", err)
+	}
+	var bots []string
+	if a.bots != nil {
+		bots = a.bots.removeUserBots(ctx, target)
+	}
+	a.apiKeys.forgetUser(target)
+	a.reloadDenylist(ctx)
+	log.Warn().Str("user_id", target).Str("by", c.UserID).Strs("bots", bots).Msg("account deleted")
+	return &pb.StatusResponse{Success: true, Message: "account deleted", Id: target}, nil
+}
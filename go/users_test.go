@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	pb "aetherion/gen"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChangePasswordChecksCurrentPassword(t *testing.T) {
+	ctx := context.Background()
+	store := newMemUserStore()
+	a := newAuthServer(nil, store)
+	u := &userAccount{Username: "alice", Email: "alice@example.com", PasswordHash: hashPassword("old"), Role: roleUser}
+	if err := store.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateUser(ctx, &userAccount{Username: "alice"}); err != errUserExists {
+		t.Errorf("expected errUserExists for a taken username, got %v", err)
+	}
+	ctx = withCaller(ctx, caller{UserID: u.ID, Role: roleUser})
+
+	_, err := a.ChangePassword(ctx, &pb.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "new"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for a wrong current password, got %v", err)
+	}
+	if _, err := a.ChangePassword(ctx, &pb.ChangePasswordRequest{CurrentPassword: "old", NewPassword: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := store.UserByUsername(ctx, "alice")
+	if !verifyPassword(got.PasswordHash, "new") {
+		t.Error("expected the new password to be stored")
+	}
+
+	apiCtx := withCaller(context.Background(), caller{UserID: u.ID, Role: roleUser, APIKeyID: "key-1"})
+	if _, err := a.ChangePassword(apiCtx, &pb.ChangePasswordRequest{CurrentPassword: "new", NewPassword: "x"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected api keys to be refused, got %v", err)
+	}
+}
+
+func TestDeleteAccountRemovesBots(t *testing.T) {
+	ctx := context.Background()
+	store := newMemUserStore()
+	a := newAuthServer(nil, store)
+	alice := &userAccount{Username: "alice", PasswordHash: hashPassword("pw"), Role: roleUser}
+	bob := &userAccount{Username: "bob", PasswordHash: hashPassword("pw"), Role: roleUser}
+	for _, u := range []*userAccount{alice, bob} {
+		if err := store.CreateUser(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo, err := newFileBotRepository(filepath.Join(t.TempDir(), "bots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg, _ := newBotRegistry(ctx, repo)
+	for _, b := range []*pb.Bot{{BotId: "b1", UserId: alice.ID, IsActive: true}, {BotId: "b2", UserId: bob.ID}} {
+		if err := reg.create(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a.bots = &botServiceServer{reg: reg}
+
+	bobCtx := withCaller(ctx, caller{UserID: bob.ID, Role: roleUser})
+	if _, err := a.DeleteAccount(bobCtx, &pb.DeleteAccountRequest{UserId: alice.ID}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected users to be unable to delete other accounts, got %v", err)
+	}
+	aliceCtx := withCaller(ctx, caller{UserID: alice.ID, Role: roleUser})
+	if _, err := a.DeleteAccount(aliceCtx, &pb.DeleteAccountRequest{Password: "nope"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected a wrong password to be refused, got %v", err)
+	}
+	if _, err := a.DeleteAccount(aliceCtx, &pb.DeleteAccountRequest{Password: "pw"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.UserByID(ctx, alice.ID); err != errUserNotFound {
+		t.Errorf("expected alice to be deleted, got %v", err)
+	}
+	if _, ok := a.bots.reg.get("b1"); ok {
+		t.Error("expected alice's bot to be unregistered")
+	}
+	if _, ok := a.bots.reg.get("b2"); !ok {
+		t.Error("expected bob's bot to remain")
+	}
+
+	adminCtx := withCaller(ctx, caller{UserID: "admin-1", Role: roleAdmin})
+	if _, err := a.DeleteAccount(adminCtx, &pb.DeleteAccountRequest{UserId: bob.ID}); err != nil {
+		t.Fatalf("expected admins to delete any account, got %v", err)
+	}
+	if users, _ := store.ListUsers(ctx, 10, 0); len(users) != 0 {
+		t.Errorf("expected no users left, got %d", len(users))
+	}
+}
+
+func TestDeleteAccountPasswordIsThrottled(t *testing.T) {
+	ctx := context.Background()
+	store := newMemUserStore()
+	a := newAuthServer(nil, store)
+	u := &userAccount{Username: "alice", PasswordHash: hashPassword("pw"), Role: roleUser}
+	if err := store.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	ctx = withCaller(ctx, caller{UserID: u.ID, Role: roleUser})
+	var err error
+	for i := 0; i < 10 && status.Code(err) != codes.ResourceExhausted; i++ {
+		_, err = a.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "guess"})
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected repeated wrong passwords to back off, got %v", err)
+	}
+	if _, err := a.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "pw"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected the right password to wait out the backoff too, got %v", err)
+	}
+}
+
+func TestGetUserHidesOtherUsersDetails(t *testing.T) {
+	ctx := context.Background()
+	store := newMemUserStore()
+	a := newAuthServer(nil, store)
+	alice := &userAccount{Username: "alice", Email: "alice@example.com", PasswordHash: hashPassword("pw"), Role: roleUser}
+	if err := store.CreateUser(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+	req := &pb.GetUserRequest{Username: "alice"}
+
+	info, err := a.GetUser(withCaller(ctx, caller{UserID: "bob", Role: roleUser}), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Username != "alice" || info.Email != "alice@example.com" || info.UserId != "" || info.Role != "" {
+		t.Errorf("expected only public fields for another user, got %v", info)
+	}
+	for _, c := range []caller{{UserID: alice.ID, Role: roleUser}, {UserID: "admin-1", Role: roleAdmin}} {
+		info, err := a.GetUser(withCaller(ctx, c), req)
+		if err != nil || info.UserId != alice.ID || info.Role != roleUser {
+			t.Errorf("%s: expected the full profile, got %v, %v", c.Role, info, err)
+		}
+	}
+}
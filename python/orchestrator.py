@@ -29,6 +29,9 @@ class TradingOrchestrator:
         # this is what actually sets bot's account value (initially) from docker compose. It's then overriden by bot_service.go.
         self.account_value = float(os.environ.get('INITIAL_ACCOUNT_VALUE', '1000000.0'))
         self.auth_secret = os.environ.get('AUTH_SECRET', None)
+        # An API key with the admin scope, created by a service account, replaces the
+        # locally minted JWT when set.
+        self.api_key = os.environ.get('AETHERION_API_KEY', None)
         if self.auth_secret:
             masked_secret = self.auth_secret[:4] + '...' + self.auth_secret[-4:] if len(self.auth_secret) > 8 else '***'
             print(f"[DEBUG] AUTH_SECRET loaded: {masked_secret}")
@@ -56,6 +59,7 @@ class TradingOrchestrator:
             return None
         claims = {
             'sub': 'orchestrator',
+            'role': 'service',  # may act on every user's bots
             'iat': int(time.time()),
             'exp': int(time.time()) + 3600  # 1 hour expiry
         }
@@ -81,10 +85,13 @@ class TradingOrchestrator:
             risk_stub = trading_api_pb2_grpc.RiskServiceStub(risk_channel)  
             while True:
                 try:
-                    token = self._generate_jwt()  # <-- Move here
                     metadata = []
-                    if token:
-                        metadata.append(('authorization', f'Bearer {token}'))
+                    if self.api_key:
+                        metadata.append(('x-api-key', self.api_key))
+                    else:
+                        token = self._generate_jwt()  # <-- Move here
+                        if token:
+                            metadata.append(('authorization', f'Bearer {token}'))
                     # print(f"[DEBUG] gRPC metadata: {metadata}")
 
                     # 1. Fetch all bots from Go backend